@@ -0,0 +1,442 @@
+package openai
+
+import (
+	"context"
+	"path"
+
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+// The endpoints in this file manage the organization itself and require an admin API key (see WithAdminKey).
+
+// ProjectRequest contains all relevant fields for requests to create or modify a project.
+type ProjectRequest struct {
+	// Name is the friendly name of the project, which appears in reports.
+	Name string `json:"name"`
+}
+
+// Project represents an individual project within the organization.
+type Project struct {
+	ResponseMeta
+
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	Name      string         `json:"name"`
+	CreatedAt uint64         `json:"created_at"`
+	// ArchivedAt is the Unix timestamp (in seconds) of when the project was archived, or null.
+	ArchivedAt *uint64 `json:"archived_at"`
+	// Status is one of "active" or "archived".
+	Status string `json:"status"`
+}
+
+// ProjectUserRequest contains all relevant fields for requests to add a user to a project or modify their role.
+type ProjectUserRequest struct {
+	// UserID is the ID of the user. Only used when adding a user to a project.
+	UserID string `json:"user_id,omitempty"`
+	// Role is one of "owner" or "member".
+	Role string `json:"role"`
+}
+
+// ProjectUser represents an individual user in a project.
+type ProjectUser struct {
+	ResponseMeta
+
+	ID     string         `json:"id"`
+	Object objects.Object `json:"object"`
+	Name   string         `json:"name"`
+	Email  string         `json:"email"`
+	// Role is one of "owner" or "member".
+	Role    string `json:"role"`
+	AddedAt uint64 `json:"added_at"`
+}
+
+// InviteRequest contains all relevant fields for requests to invite a user to the organization.
+type InviteRequest struct {
+	// Email is the email address of the individual to whom the invite will be sent.
+	Email string `json:"email"`
+	// Role is one of "owner" or "reader".
+	Role string `json:"role"`
+}
+
+// Invite represents an individual invite to the organization.
+type Invite struct {
+	ResponseMeta
+
+	ID     string         `json:"id"`
+	Object objects.Object `json:"object"`
+	Email  string         `json:"email"`
+	// Role is one of "owner" or "reader".
+	Role string `json:"role"`
+	// Status is one of "accepted", "expired", or "pending".
+	Status     string  `json:"status"`
+	InvitedAt  uint64  `json:"invited_at"`
+	ExpiresAt  uint64  `json:"expires_at"`
+	AcceptedAt *uint64 `json:"accepted_at,omitempty"`
+}
+
+// ServiceAccountRequest contains all relevant fields for requests to create a service account.
+type ServiceAccountRequest struct {
+	// Name is the name of the service account being created.
+	Name string `json:"name"`
+}
+
+// ServiceAccount represents an individual service account in a project.
+type ServiceAccount struct {
+	ResponseMeta
+
+	ID     string         `json:"id"`
+	Object objects.Object `json:"object"`
+	Name   string         `json:"name"`
+	// Role is one of "owner" or "member".
+	Role      string `json:"role"`
+	CreatedAt uint64 `json:"created_at"`
+	// APIKey is the unredacted API key of the service account. It is only returned when the service account is
+	// created.
+	APIKey *ServiceAccountAPIKey `json:"api_key,omitempty"`
+}
+
+// ServiceAccountAPIKey is the API key created along with a service account.
+type ServiceAccountAPIKey struct {
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	Name      string         `json:"name"`
+	Value     string         `json:"value"`
+	CreatedAt uint64         `json:"created_at"`
+}
+
+// ProjectAPIKey represents an individual API key in a project.
+type ProjectAPIKey struct {
+	ResponseMeta
+
+	ID     string         `json:"id"`
+	Object objects.Object `json:"object"`
+	Name   string         `json:"name"`
+	// RedactedValue is the redacted value of the API key.
+	RedactedValue string `json:"redacted_value"`
+	CreatedAt     uint64 `json:"created_at"`
+	Owner         *struct {
+		// Type is one of "user" or "service_account".
+		Type           string          `json:"type"`
+		User           *ProjectUser    `json:"user,omitempty"`
+		ServiceAccount *ServiceAccount `json:"service_account,omitempty"`
+	} `json:"owner"`
+}
+
+// CreateProject creates a new project in the organization.
+func (c *Client) CreateProject(ctx context.Context, pr *ProjectRequest, opts ...RequestOption) (*Project, error) {
+	var res, err = c.post(ctx, routes.OrganizationProjects, pr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &Project{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ListProjects returns a list of the organization's projects. Paginate the results with WithListOptions.
+func (c *Client) ListProjects(ctx context.Context, opts ...RequestOption) (*List[*Project], error) {
+	var res, err = c.get(ctx, routes.OrganizationProjects, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*Project]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveProject retrieves a project.
+func (c *Client) RetrieveProject(ctx context.Context, id string, opts ...RequestOption) (*Project, error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &Project{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ModifyProject modifies a project in the organization.
+func (c *Client) ModifyProject(ctx context.Context, id string, pr *ProjectRequest, opts ...RequestOption) (*Project, error) {
+	var res, err = c.post(ctx, path.Join(routes.OrganizationProjects, id), pr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &Project{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ArchiveProject archives a project in the organization. Archived projects cannot be used or updated.
+func (c *Client) ArchiveProject(ctx context.Context, id string, opts ...RequestOption) (*Project, error) {
+	var res, err = c.post(ctx, path.Join(routes.OrganizationProjects, id, "archive"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &Project{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ListProjectUsers returns a list of the users in a project. Paginate the results with WithListOptions.
+func (c *Client) ListProjectUsers(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ProjectUser], error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "users"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*ProjectUser]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// CreateProjectUser adds a user to a project. Users must already be members of the organization to be added to a
+// project.
+func (c *Client) CreateProjectUser(ctx context.Context, projectID string, ur *ProjectUserRequest, opts ...RequestOption) (*ProjectUser, error) {
+	var res, err = c.post(ctx, path.Join(routes.OrganizationProjects, projectID, "users"), ur, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &ProjectUser{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// RetrieveProjectUser retrieves a user in a project.
+func (c *Client) RetrieveProjectUser(ctx context.Context, projectID, userID string, opts ...RequestOption) (*ProjectUser, error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "users", userID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &ProjectUser{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ModifyProjectUser modifies a user's role in a project.
+func (c *Client) ModifyProjectUser(ctx context.Context, projectID, userID string, ur *ProjectUserRequest, opts ...RequestOption) (*ProjectUser, error) {
+	var res, err = c.post(ctx, path.Join(routes.OrganizationProjects, projectID, "users", userID), ur, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &ProjectUser{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// DeleteProjectUser removes a user from a project.
+func (c *Client) DeleteProjectUser(ctx context.Context, projectID, userID string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.OrganizationProjects, projectID, "users", userID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// ListInvites returns a list of the organization's invites. Paginate the results with WithListOptions.
+func (c *Client) ListInvites(ctx context.Context, opts ...RequestOption) (*List[*Invite], error) {
+	var res, err = c.get(ctx, routes.OrganizationInvites, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*Invite]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// CreateInvite creates an invite for a user to the organization. The invite must be accepted by the user before they
+// have access to the organization.
+func (c *Client) CreateInvite(ctx context.Context, ir *InviteRequest, opts ...RequestOption) (*Invite, error) {
+	var res, err = c.post(ctx, routes.OrganizationInvites, ir, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var i = &Invite{}
+	if err = res.decode(i); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// RetrieveInvite retrieves an invite.
+func (c *Client) RetrieveInvite(ctx context.Context, id string, opts ...RequestOption) (*Invite, error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationInvites, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var i = &Invite{}
+	if err = res.decode(i); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// DeleteInvite deletes an invite. If the invite has already been accepted, it cannot be deleted.
+func (c *Client) DeleteInvite(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.OrganizationInvites, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// ListServiceAccounts returns a list of the service accounts in a project. Paginate the results with WithListOptions.
+func (c *Client) ListServiceAccounts(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ServiceAccount], error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "service_accounts"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*ServiceAccount]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// CreateServiceAccount creates a new service account in a project. The returned *ServiceAccount contains the
+// unredacted API key of the service account, which cannot be retrieved again.
+func (c *Client) CreateServiceAccount(ctx context.Context, projectID string, sr *ServiceAccountRequest, opts ...RequestOption) (*ServiceAccount, error) {
+	var res, err = c.post(ctx, path.Join(routes.OrganizationProjects, projectID, "service_accounts"), sr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var sa = &ServiceAccount{}
+	if err = res.decode(sa); err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// RetrieveServiceAccount retrieves a service account in a project.
+func (c *Client) RetrieveServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...RequestOption) (*ServiceAccount, error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "service_accounts", serviceAccountID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var sa = &ServiceAccount{}
+	if err = res.decode(sa); err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// DeleteServiceAccount deletes a service account from a project.
+func (c *Client) DeleteServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.OrganizationProjects, projectID, "service_accounts", serviceAccountID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// ListProjectAPIKeys returns a list of the API keys in a project. Paginate the results with WithListOptions.
+func (c *Client) ListProjectAPIKeys(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ProjectAPIKey], error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "api_keys"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*ProjectAPIKey]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveProjectAPIKey retrieves an API key in a project.
+func (c *Client) RetrieveProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...RequestOption) (*ProjectAPIKey, error) {
+	var res, err = c.get(ctx, path.Join(routes.OrganizationProjects, projectID, "api_keys", keyID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var k = &ProjectAPIKey{}
+	if err = res.decode(k); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// DeleteProjectAPIKey deletes an API key from a project.
+func (c *Client) DeleteProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.OrganizationProjects, projectID, "api_keys", keyID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,194 @@
+package openai
+
+import (
+	"context"
+	"io"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// API is implemented by *Client. Depend on it (or on one of the narrower interfaces it embeds) rather than on *Client
+// so that a mock, such as the one in the openaimock package, can be injected in tests.
+type API interface {
+	AdminAPI
+	AssistantsAPI
+	AudioAPI
+	BatchesAPI
+	ChatAPI
+	CompletionsAPI
+	EditsAPI
+	EmbeddingsAPI
+	EnginesAPI
+	FilesAPI
+	FineTunesAPI
+	FineTuningAPI
+	ImagesAPI
+	ModelsAPI
+	ModerationsAPI
+	UploadsAPI
+	VectorStoresAPI
+}
+
+var _ API = (*Client)(nil)
+
+// AdminAPI covers the organization administration endpoints (projects, users, invites, service accounts, API keys,
+// costs, and audit logs).
+type AdminAPI interface {
+	CreateProject(ctx context.Context, pr *ProjectRequest, opts ...RequestOption) (*Project, error)
+	ListProjects(ctx context.Context, opts ...RequestOption) (*List[*Project], error)
+	RetrieveProject(ctx context.Context, id string, opts ...RequestOption) (*Project, error)
+	ModifyProject(ctx context.Context, id string, pr *ProjectRequest, opts ...RequestOption) (*Project, error)
+	ArchiveProject(ctx context.Context, id string, opts ...RequestOption) (*Project, error)
+	ListProjectUsers(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ProjectUser], error)
+	CreateProjectUser(ctx context.Context, projectID string, ur *ProjectUserRequest, opts ...RequestOption) (*ProjectUser, error)
+	RetrieveProjectUser(ctx context.Context, projectID, userID string, opts ...RequestOption) (*ProjectUser, error)
+	ModifyProjectUser(ctx context.Context, projectID, userID string, ur *ProjectUserRequest, opts ...RequestOption) (*ProjectUser, error)
+	DeleteProjectUser(ctx context.Context, projectID, userID string, opts ...RequestOption) (*DeletionResponse, error)
+	ListInvites(ctx context.Context, opts ...RequestOption) (*List[*Invite], error)
+	CreateInvite(ctx context.Context, ir *InviteRequest, opts ...RequestOption) (*Invite, error)
+	RetrieveInvite(ctx context.Context, id string, opts ...RequestOption) (*Invite, error)
+	DeleteInvite(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error)
+	ListServiceAccounts(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ServiceAccount], error)
+	CreateServiceAccount(ctx context.Context, projectID string, sr *ServiceAccountRequest, opts ...RequestOption) (*ServiceAccount, error)
+	RetrieveServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...RequestOption) (*ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...RequestOption) (*DeletionResponse, error)
+	ListProjectAPIKeys(ctx context.Context, projectID string, opts ...RequestOption) (*List[*ProjectAPIKey], error)
+	RetrieveProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...RequestOption) (*ProjectAPIKey, error)
+	DeleteProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...RequestOption) (*DeletionResponse, error)
+	GetCosts(ctx context.Context, cr *CostsRequest, opts ...RequestOption) (*CostsPage, error)
+	ListAuditLogs(ctx context.Context, ar *AuditLogsRequest, opts ...RequestOption) (*List[*AuditLog], error)
+}
+
+// AssistantsAPI covers the assistants endpoints.
+type AssistantsAPI interface {
+	CreateAssistant(ctx context.Context, ar *AssistantRequest, opts ...RequestOption) (*Assistant, error)
+	ListAssistants(ctx context.Context, opts ...RequestOption) (*List[*Assistant], error)
+	RetrieveAssistant(ctx context.Context, id string, opts ...RequestOption) (*Assistant, error)
+	ModifyAssistant(ctx context.Context, id string, ar *AssistantRequest, opts ...RequestOption) (*Assistant, error)
+	DeleteAssistant(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error)
+}
+
+// AudioAPI covers the audio endpoints.
+type AudioAPI interface {
+	CreateTranscription(ctx context.Context, tr *TranscriptionRequest, opts ...RequestOption) (*TranscriptionResponse, error)
+	CreateSpeech(ctx context.Context, sr *SpeechRequest, opts ...RequestOption) (io.ReadCloser, error)
+}
+
+// BatchesAPI covers the batches endpoints.
+type BatchesAPI interface {
+	CreateBatch(ctx context.Context, br *BatchRequest, opts ...RequestOption) (*Batch, error)
+	RetrieveBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error)
+	CancelBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error)
+	ListBatches(ctx context.Context, opts ...RequestOption) (*List[*Batch], error)
+}
+
+// ChatAPI covers the chat completions endpoint.
+type ChatAPI interface {
+	CreateChatCompletion(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error)
+	CreateFineTunedChatCompletion(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error)
+	CreateChatCompletionStream(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*Stream[*ChatCompletionChunk[models.Chat]], error)
+	CreateFineTunedChatCompletionStream(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*Stream[*ChatCompletionChunk[models.FineTunedModel]], error)
+	RetrieveChatCompletion(ctx context.Context, id string, opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error)
+	ListChatCompletions(ctx context.Context, lr *ChatCompletionsListRequest, opts ...RequestOption) (*List[*ChatCompletionResponse[models.Chat]], error)
+	DeleteChatCompletion(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error)
+}
+
+// CompletionsAPI covers the completions endpoint.
+type CompletionsAPI interface {
+	CreateCompletion(ctx context.Context, cr *CompletionRequest[models.Completion], opts ...RequestOption) (*CompletionResponse[models.Completion], error)
+	CreateFineTunedCompletion(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error)
+}
+
+// EditsAPI covers the edits endpoint.
+type EditsAPI interface {
+	CreateEdit(ctx context.Context, er *EditsRequest, opts ...RequestOption) (*EditsResponse, error)
+}
+
+// EmbeddingsAPI covers the embeddings endpoint.
+type EmbeddingsAPI interface {
+	CreateEmbeddings(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error)
+}
+
+// EnginesAPI covers the deprecated engines endpoints.
+type EnginesAPI interface {
+	ListEngines(ctx context.Context, opts ...RequestOption) (*List[*Engine], error)
+	GetEngine(ctx context.Context, id string, opts ...RequestOption) (*Engine, error)
+}
+
+// FilesAPI covers the files endpoints.
+type FilesAPI interface {
+	ListFiles(ctx context.Context, opts ...RequestOption) (*List[*File], error)
+	UploadFile(ctx context.Context, fr *FileRequest, opts ...RequestOption) (*File, error)
+	DeleteFile(ctx context.Context, id string, opts ...RequestOption) error
+	RetrieveFile(ctx context.Context, id string, opts ...RequestOption) (*File, error)
+	GetFileContent(ctx context.Context, id string, w io.Writer, opts ...RequestOption) error
+}
+
+// FineTunesAPI covers the deprecated fine-tunes endpoints.
+type FineTunesAPI interface {
+	CreateFineTune(ctx context.Context, ftr *FineTuneRequest, opts ...RequestOption) (*FineTuneResponse, error)
+	ListFineTunes(ctx context.Context, opts ...RequestOption) (*List[*FineTuneResponse], error)
+	RetrieveFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneResponse, error)
+	CancelFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneResponse, error)
+	ListFineTuneEvents(ctx context.Context, id string, opts ...RequestOption) (*List[*Event], error)
+	DeleteFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneDeletionResponse, error)
+}
+
+// FineTuningAPI covers the fine-tuning endpoints.
+type FineTuningAPI interface {
+	CreateFineTuningJob(ctx context.Context, fr *FineTuningJobRequest, opts ...RequestOption) (*FineTuningJob, error)
+	ListFineTuningJobs(ctx context.Context, opts ...RequestOption) (*List[*FineTuningJob], error)
+	RetrieveFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error)
+	CancelFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error)
+	ListFineTuningEvents(ctx context.Context, id string, opts ...RequestOption) (*List[*FineTuningJobEvent], error)
+	StreamFineTuningEvents(ctx context.Context, id string, opts ...RequestOption) (*Stream[*FineTuningJobEvent], error)
+	ListFineTuningCheckpoints(ctx context.Context, id string, opts ...RequestOption) (*List[*FineTuningJobCheckpoint], error)
+	CreateCheckpointPermissions(ctx context.Context, checkpoint string, pr *CheckpointPermissionRequest, opts ...RequestOption) (*List[*CheckpointPermission], error)
+	ListCheckpointPermissions(ctx context.Context, checkpoint, projectID string, opts ...RequestOption) (*List[*CheckpointPermission], error)
+	DeleteCheckpointPermission(ctx context.Context, checkpoint, id string, opts ...RequestOption) (*DeletionResponse, error)
+}
+
+// ImagesAPI covers the images endpoints.
+type ImagesAPI interface {
+	CreateImage(ctx context.Context, ir *CreateImageRequest, opts ...RequestOption) (*ImageResponse, error)
+	EditImage(ctx context.Context, eir *EditImageRequest, opts ...RequestOption) (*ImageResponse, error)
+	ImageVariation(ctx context.Context, vir *VariationImageRequest, opts ...RequestOption) (*ImageResponse, error)
+}
+
+// ModelsAPI covers the models endpoints.
+type ModelsAPI interface {
+	ListModels(ctx context.Context, opts ...RequestOption) (*List[*Model], error)
+	RetrieveModel(ctx context.Context, id string, opts ...RequestOption) (*Model, error)
+	DeleteModel(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error)
+}
+
+// ModerationsAPI covers the moderations endpoint.
+type ModerationsAPI interface {
+	CreateModeration(ctx context.Context, mr *ModerationRequest, opts ...RequestOption) (*ModerationResponse, error)
+}
+
+// UploadsAPI covers the uploads endpoints.
+type UploadsAPI interface {
+	CreateUpload(ctx context.Context, ur *UploadRequest, opts ...RequestOption) (*Upload, error)
+	AddUploadPart(ctx context.Context, uploadID string, data io.Reader, opts ...RequestOption) (*UploadPart, error)
+	UploadParts(ctx context.Context, uploadID string, r io.Reader, opts *UploadPartsOptions, reqOpts ...RequestOption) ([]string, error)
+	CompleteUpload(ctx context.Context, uploadID string, cr *CompleteUploadRequest, opts ...RequestOption) (*Upload, error)
+	CancelUpload(ctx context.Context, uploadID string, opts ...RequestOption) (*Upload, error)
+}
+
+// VectorStoresAPI covers the vector stores endpoints.
+type VectorStoresAPI interface {
+	CreateVectorStore(ctx context.Context, vr *VectorStoreRequest, opts ...RequestOption) (*VectorStore, error)
+	ListVectorStores(ctx context.Context, opts ...RequestOption) (*List[*VectorStore], error)
+	RetrieveVectorStore(ctx context.Context, id string, opts ...RequestOption) (*VectorStore, error)
+	ModifyVectorStore(ctx context.Context, id string, vr *VectorStoreRequest, opts ...RequestOption) (*VectorStore, error)
+	DeleteVectorStore(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error)
+	CreateVectorStoreFile(ctx context.Context, vectorStoreID string, fr *VectorStoreFileRequest, opts ...RequestOption) (*VectorStoreFile, error)
+	ListVectorStoreFiles(ctx context.Context, vectorStoreID string, opts ...RequestOption) (*List[*VectorStoreFile], error)
+	RetrieveVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...RequestOption) (*VectorStoreFile, error)
+	DeleteVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...RequestOption) (*DeletionResponse, error)
+	CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, br *VectorStoreFileBatchRequest, opts ...RequestOption) (*VectorStoreFileBatch, error)
+	RetrieveVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*VectorStoreFileBatch, error)
+	CancelVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*VectorStoreFileBatch, error)
+	ListVectorStoreFileBatchFiles(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*List[*VectorStoreFile], error)
+}
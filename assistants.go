@@ -0,0 +1,185 @@
+package openai
+
+import (
+	"context"
+	"path"
+
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+	"github.com/fabiustech/openai/tools"
+)
+
+// AssistantTool represents a tool enabled on an assistant.
+type AssistantTool struct {
+	// Type is the type of tool.
+	Type tools.Type `json:"type"`
+	// Function describes the function which the model may call. Must be set if Type is tools.TypeFunction.
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// FunctionDefinition describes a function which the model may call.
+type FunctionDefinition struct {
+	// Name is the name of the function to be called. Must be a-z, A-Z, 0-9, or contain underscores and dashes, with a
+	// maximum length of 64.
+	Name string `json:"name"`
+	// Description is a description of what the function does, used by the model to choose when and how to call the
+	// function.
+	Description string `json:"description,omitempty"`
+	// Parameters are the parameters the function accepts, described as a JSON Schema object. Omitting Parameters
+	// defines a function with an empty parameter list.
+	//
+	// https://json-schema.org/understanding-json-schema
+	Parameters any `json:"parameters,omitempty"`
+	// Strict enables strict schema adherence when generating the function call: the arguments always follow the exact
+	// schema of Parameters, which must meet the same requirements as a strict JSONSchema.
+	// Defaults to false.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ToolResources contains the resources made available to an assistant's tools. The resources are specific to the type
+// of tool.
+type ToolResources struct {
+	CodeInterpreter *CodeInterpreterResources `json:"code_interpreter,omitempty"`
+	FileSearch      *FileSearchResources      `json:"file_search,omitempty"`
+}
+
+// CodeInterpreterResources contains the resources made available to the code interpreter tool.
+type CodeInterpreterResources struct {
+	// FileIDs is a list of file IDs made available to the code interpreter tool. There can be a maximum of 20 files
+	// associated with the tool.
+	FileIDs []string `json:"file_ids,omitempty"`
+}
+
+// FileSearchResources contains the resources made available to the file search tool.
+type FileSearchResources struct {
+	// VectorStoreIDs is the vector store attached to this assistant. There can be a maximum of 1 vector store attached
+	// to the assistant.
+	VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
+}
+
+// AssistantRequest contains all relevant fields for requests to the assistants endpoints.
+type AssistantRequest struct {
+	// Model specifies the ID of the model to use. Required when creating an assistant.
+	Model models.Chat `json:"model,omitempty"`
+	// Name is the name of the assistant. The maximum length is 256 characters.
+	Name *string `json:"name,omitempty"`
+	// Description is the description of the assistant. The maximum length is 512 characters.
+	Description *string `json:"description,omitempty"`
+	// Instructions are the system instructions that the assistant uses. The maximum length is 256,000 characters.
+	Instructions *string `json:"instructions,omitempty"`
+	// Tools is a list of tools enabled on the assistant. There can be a maximum of 128 tools per assistant.
+	Tools []*AssistantTool `json:"tools,omitempty"`
+	// ToolResources is a set of resources that are used by the assistant's tools.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+	// Metadata is a set of 16 key-value pairs that can be attached to the assistant. Keys can be a maximum of 64
+	// characters long and values can be a maximum of 512 characters long.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Temperature specifies what sampling temperature to use, between 0 and 2. Higher values like 0.8 will make the
+	// output more random, while lower values like 0.2 will make it more focused and deterministic.
+	// Defaults to 1.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// TopP specifies an alternative to sampling with temperature, called nucleus sampling, where the model considers
+	// the results of the tokens with top_p probability mass. So 0.1 means only the tokens comprising the top 10%
+	// probability mass are considered. OpenAI generally recommends altering this or temperature but not both.
+	// Defaults to 1.
+	TopP *float64 `json:"top_p,omitempty"`
+}
+
+// model returns the model of the request (see WithModelRoute).
+func (ar *AssistantRequest) model() string {
+	return ar.Model.String()
+}
+
+// Assistant represents an assistant that can call the model and use tools.
+type Assistant struct {
+	ResponseMeta
+
+	ID            string            `json:"id"`
+	Object        objects.Object    `json:"object"`
+	CreatedAt     uint64            `json:"created_at"`
+	Name          *string           `json:"name"`
+	Description   *string           `json:"description"`
+	Model         models.Chat       `json:"model"`
+	Instructions  *string           `json:"instructions"`
+	Tools         []*AssistantTool  `json:"tools"`
+	ToolResources *ToolResources    `json:"tool_resources"`
+	Metadata      map[string]string `json:"metadata"`
+	Temperature   *float64          `json:"temperature"`
+	TopP          *float64          `json:"top_p"`
+}
+
+// CreateAssistant creates an assistant with a model and instructions.
+func (c *Client) CreateAssistant(ctx context.Context, ar *AssistantRequest, opts ...RequestOption) (*Assistant, error) {
+	var res, err = c.post(ctx, routes.Assistants, ar, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var a = &Assistant{}
+	if err = res.decode(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ListAssistants returns a list of assistants. Paginate the results with WithListOptions.
+func (c *Client) ListAssistants(ctx context.Context, opts ...RequestOption) (*List[*Assistant], error) {
+	var res, err = c.get(ctx, routes.Assistants, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*Assistant]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveAssistant retrieves an assistant.
+func (c *Client) RetrieveAssistant(ctx context.Context, id string, opts ...RequestOption) (*Assistant, error) {
+	var res, err = c.get(ctx, path.Join(routes.Assistants, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var a = &Assistant{}
+	if err = res.decode(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ModifyAssistant modifies an assistant. Only the fields set in |ar| are updated.
+func (c *Client) ModifyAssistant(ctx context.Context, id string, ar *AssistantRequest, opts ...RequestOption) (*Assistant, error) {
+	var res, err = c.post(ctx, path.Join(routes.Assistants, id), ar, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var a = &Assistant{}
+	if err = res.decode(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// DeleteAssistant deletes an assistant.
+func (c *Client) DeleteAssistant(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.Assistants, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,274 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/fabiustech/openai/audio"
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/routes"
+)
+
+// TranscriptionRequest contains all relevant fields for requests to the audio/transcriptions endpoint.
+type TranscriptionRequest struct {
+	// File is the audio file to transcribe, in one of these formats: mp3, mp4, mpeg, mpga, m4a, wav, or webm.
+	File io.Reader
+	// Filename is the name of the audio file (including its extension), which OpenAI uses to determine the format
+	// of File.
+	Filename string
+	// Model specifies the ID of the model to use. Only models.Whisper1 is currently available.
+	Model models.Audio
+	// Prompt is an optional text to guide the model's style or continue a previous audio segment. The prompt should
+	// match the audio language.
+	Prompt string
+	// Temperature specifies the sampling temperature, between 0 and 1. Higher values like 0.8 will make the output
+	// more random, while lower values like 0.2 will make it more focused and deterministic. If set to 0, the model
+	// will use log probability to automatically increase the temperature until certain thresholds are hit.
+	// Defaults to 0.
+	Temperature *float64
+	// Language is the language of the input audio. Supplying the input language in ISO-639-1 format (e.g. "en") will
+	// improve accuracy and latency.
+	Language string
+	// ResponseFormat specifies the format of the transcription. Use audio.TranscriptionFormatVerboseJSON to also get
+	// the timestamps of the transcribed segments and words.
+	// Defaults to audio.TranscriptionFormatJSON.
+	ResponseFormat audio.TranscriptionFormat
+	// TimestampGranularities specifies which timestamps are returned. Requires
+	// audio.TranscriptionFormatVerboseJSON.
+	// Defaults to audio.TimestampGranularitySegment.
+	TimestampGranularities []audio.TimestampGranularity
+}
+
+// TranscriptionResponse is the response from the audio/transcriptions endpoint. Only Text is set unless the request's
+// ResponseFormat is audio.TranscriptionFormatVerboseJSON. If it is a plain text format (e.g.
+// audio.TranscriptionFormatSRT), Text contains the response as is. Subtitles can also be generated from the Segments of
+// a verbose transcription with SRT and VTT.
+type TranscriptionResponse struct {
+	ResponseMeta
+
+	// Text is the transcribed text.
+	Text string `json:"text"`
+	// Language is the language of the audio.
+	Language string `json:"language,omitempty"`
+	// Duration is the duration of the audio, in seconds.
+	Duration float64 `json:"duration,omitempty"`
+	// Segments are the segments of the transcribed text, with their timestamps.
+	Segments []*TranscriptionSegment `json:"segments,omitempty"`
+	// Words are the transcribed words, with their timestamps. Only set if audio.TimestampGranularityWord was
+	// requested.
+	Words []*TranscriptionWord `json:"words,omitempty"`
+}
+
+// TranscriptionSegment is a segment of a verbose transcription. Times are in seconds from the start of the audio.
+type TranscriptionSegment struct {
+	ID    int     `json:"id"`
+	Seek  int     `json:"seek"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+	// Tokens are the IDs of the tokens of the text.
+	Tokens      []int   `json:"tokens"`
+	Temperature float64 `json:"temperature"`
+	// AvgLogprob is the average log probability of the tokens of the segment. Segments below -1 are likely to be
+	// poorly transcribed.
+	AvgLogprob float64 `json:"avg_logprob"`
+	// CompressionRatio is the compression ratio of the segment. Segments above 2.4 are likely to be repetitive
+	// hallucinations.
+	CompressionRatio float64 `json:"compression_ratio"`
+	// NoSpeechProb is the probability that the segment contains no speech. Segments above 0.6 with an AvgLogprob below
+	// -1 are likely silent.
+	NoSpeechProb float64 `json:"no_speech_prob"`
+}
+
+// Confidence returns the geometric mean of the probabilities of the tokens of |s|, between 0 and 1.
+func (s *TranscriptionSegment) Confidence() float64 {
+	return math.Exp(s.AvgLogprob)
+}
+
+// TranscriptionWord is a word of a verbose transcription. Times are in seconds from the start of the audio.
+type TranscriptionWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// validate returns an error if |tr| requests timestamps without the verbose JSON format.
+func (tr *TranscriptionRequest) validate() error {
+	if len(tr.TimestampGranularities) > 0 && tr.ResponseFormat != audio.TranscriptionFormatVerboseJSON {
+		return errors.New("openai: timestamp granularities require the verbose_json response format")
+	}
+
+	return nil
+}
+
+// writeForm writes the fields of |tr| to |w|.
+func (tr *TranscriptionRequest) writeForm(w *multipart.Writer) error {
+	if err := writeFormFile(w, "file", tr.Filename, tr.File); err != nil {
+		return err
+	}
+
+	if err := w.WriteField("model", tr.Model.String()); err != nil {
+		return err
+	}
+
+	if tr.Prompt != "" {
+		if err := w.WriteField("prompt", tr.Prompt); err != nil {
+			return err
+		}
+	}
+
+	if tr.Temperature != nil {
+		if err := w.WriteField("temperature", strconv.FormatFloat(*tr.Temperature, 'f', -1, 64)); err != nil {
+			return err
+		}
+	}
+
+	if tr.Language != "" {
+		if err := w.WriteField("language", tr.Language); err != nil {
+			return err
+		}
+	}
+
+	if tr.ResponseFormat != audio.TranscriptionFormatInvalid {
+		if err := w.WriteField("response_format", tr.ResponseFormat.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range tr.TimestampGranularities {
+		if err := w.WriteField("timestamp_granularities[]", g.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateTranscription transcribes audio into the input language.
+func (c *Client) CreateTranscription(ctx context.Context, tr *TranscriptionRequest, opts ...RequestOption) (*TranscriptionResponse, error) {
+	if err := tr.validate(); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.postForm(ctx, routes.AudioTranscriptions, tr.writeForm,
+		append([]RequestOption{withModel(tr.Model)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp = &TranscriptionResponse{}
+	if tr.ResponseFormat.Text() {
+		resp.Text = string(res.body)
+		res.setMeta(resp)
+		return resp, nil
+	}
+	if err = res.decode(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SpeechRequest contains all relevant fields for requests to the audio/speech endpoint.
+type SpeechRequest struct {
+	// Model specifies the ID of the model to use. Must be one of models.TTS1 or models.TTS1HD.
+	Model models.Speech `json:"model"`
+	// Input is the text to generate audio for. The maximum length is 4096 characters.
+	Input string `json:"input"`
+	// Voice is the voice to use when generating the audio.
+	Voice audio.Voice `json:"voice"`
+	// ResponseFormat specifies the format to return the audio in. Must be one of audio.FormatMP3, audio.FormatOpus,
+	// audio.FormatAAC, audio.FormatFLAC, audio.FormatWAV, or audio.FormatPCM.
+	// Defaults to audio.FormatMP3.
+	ResponseFormat audio.Format `json:"response_format,omitempty"`
+	// Speed specifies the speed of the generated audio. Must be between 0.25 and 4.0.
+	// Defaults to 1.0.
+	Speed *float64 `json:"speed,omitempty"`
+}
+
+// model returns the model of the request (see WithModelRoute).
+func (sr *SpeechRequest) model() string {
+	return sr.Model.String()
+}
+
+const (
+	// maxSpeechInput is the maximum length of the input of a speech request, in characters.
+	maxSpeechInput = 4096
+	// minSpeechSpeed and maxSpeechSpeed bound the speed of a speech request.
+	minSpeechSpeed, maxSpeechSpeed = 0.25, 4.0
+)
+
+// validate returns an error if |sr| would be rejected by the audio/speech endpoint.
+func (sr *SpeechRequest) validate() error {
+	if sr.Model == models.UnknownSpeech {
+		return errors.New("openai: invalid speech model")
+	}
+	if sr.Input == "" {
+		return errors.New("openai: speech input is required")
+	}
+	if n := utf8.RuneCountInString(sr.Input); n > maxSpeechInput {
+		return fmt.Errorf("openai: speech input is %d characters, more than the maximum of %d", n, maxSpeechInput)
+	}
+	if sr.Voice == audio.VoiceInvalid {
+		return errors.New("openai: invalid speech voice")
+	}
+	if sr.ResponseFormat == audio.FormatPCM16 {
+		return errors.New("openai: speech is returned as pcm, not pcm16")
+	}
+	if sr.Speed != nil && (*sr.Speed < minSpeechSpeed || *sr.Speed > maxSpeechSpeed) {
+		return fmt.Errorf("openai: speed must be between %v and %v", minSpeechSpeed, maxSpeechSpeed)
+	}
+
+	return nil
+}
+
+// CreateSpeech generates audio from the input text. The audio is sent with chunked transfer encoding as it is
+// synthesized, and is returned as an io.ReadCloser as soon as the response headers are received, so that playback can
+// begin before synthesis completes; reads block until more audio is available. It is the caller's responsibility to
+// close it. Use StreamSpeech to receive the audio chunk by chunk.
+func (c *Client) CreateSpeech(ctx context.Context, sr *SpeechRequest, opts ...RequestOption) (io.ReadCloser, error) {
+	if err := sr.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.postStream(ctx, routes.AudioSpeech, sr, opts...)
+}
+
+// speechChunkSize is the maximum size of the chunks of audio passed to the callback of StreamSpeech.
+const speechChunkSize = 32 << 10
+
+// StreamSpeech generates audio from the input text with |api| (see CreateSpeech), calling |fn| with each chunk of audio
+// as it is received, e.g. to write it to an audio device. The chunk is only valid until |fn| returns. Chunks follow the
+// network rather than the audio format: a chunk may end mid-frame, and formats with a header (e.g. wav) only have it
+// in the first chunk. StreamSpeech returns once the audio has
+// been received in full, or with the first error returned by |fn| or encountered while reading.
+func StreamSpeech(ctx context.Context, api AudioAPI, sr *SpeechRequest, fn func(chunk []byte) error, opts ...RequestOption) error {
+	var rc, err = api.CreateSpeech(ctx, sr, opts...)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	var buf = make([]byte, speechChunkSize)
+	for {
+		var n int
+		n, err = rc.Read(buf)
+		if n > 0 {
+			if ferr := fn(buf[:n]); ferr != nil {
+				return ferr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package audio
+
+// Format represents the enum values for the formats in which
+// generated speech is returned.
+type Format int
+
+const (
+	// FormatInvalid represents an invalid Format option.
+	FormatInvalid Format = iota
+	// FormatMP3 specifies that the API will return MP3 encoded audio.
+	FormatMP3
+	// FormatOpus specifies that the API will return Opus encoded audio, which is suited for internet streaming and
+	// communication with low latency.
+	FormatOpus
+	// FormatAAC specifies that the API will return AAC encoded audio, which is suited for digital audio compression
+	// and preferred by YouTube, Android, and iOS.
+	FormatAAC
+	// FormatFLAC specifies that the API will return FLAC encoded audio, which is suited for lossless audio
+	// compression.
+	FormatFLAC
+	// FormatWAV specifies uncompressed WAV encoded audio, which is suited for low-latency applications.
+	FormatWAV
+	// FormatPCM16 specifies raw 16-bit PCM audio at 24kHz (little-endian), as returned by chat completions.
+	FormatPCM16
+	// FormatPCM specifies raw 16-bit PCM audio at 24kHz (little-endian) without a header, as returned by the
+	// audio/speech endpoint. It is the simplest format to play as it is streamed.
+	FormatPCM
+)
+
+// String implements the fmt.Stringer interface.
+func (f Format) String() string {
+	return formatToString[f]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |f| to FormatInvalid.
+func (f *Format) UnmarshalText(b []byte) error {
+	if val, ok := stringToFormat[(string(b))]; ok {
+		*f = val
+		return nil
+	}
+
+	*f = FormatInvalid
+
+	return nil
+}
+
+var formatToString = map[Format]string{
+	FormatMP3:   "mp3",
+	FormatOpus:  "opus",
+	FormatAAC:   "aac",
+	FormatFLAC:  "flac",
+	FormatWAV:   "wav",
+	FormatPCM16: "pcm16",
+	FormatPCM:   "pcm",
+}
+
+var stringToFormat = map[string]Format{
+	"mp3":   FormatMP3,
+	"opus":  FormatOpus,
+	"aac":   FormatAAC,
+	"flac":  FormatFLAC,
+	"wav":   FormatWAV,
+	"pcm16": FormatPCM16,
+	"pcm":   FormatPCM,
+}
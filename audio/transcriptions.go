@@ -0,0 +1,112 @@
+package audio
+
+// TranscriptionFormat represents the enum values for the formats in which
+// transcriptions are returned.
+type TranscriptionFormat int
+
+const (
+	// TranscriptionFormatInvalid represents an invalid TranscriptionFormat option.
+	TranscriptionFormatInvalid TranscriptionFormat = iota
+	// TranscriptionFormatJSON specifies that the API will return the transcribed text as JSON.
+	TranscriptionFormatJSON
+	// TranscriptionFormatVerboseJSON specifies that the API will return the transcribed text as JSON, along with the
+	// language and duration of the audio, and the timestamps of its segments (and optionally words).
+	TranscriptionFormatVerboseJSON
+	// TranscriptionFormatText specifies that the API will return the transcribed text as plain text.
+	TranscriptionFormatText
+	// TranscriptionFormatSRT specifies that the API will return the transcribed text as SubRip subtitles.
+	TranscriptionFormatSRT
+	// TranscriptionFormatVTT specifies that the API will return the transcribed text as WebVTT subtitles.
+	TranscriptionFormatVTT
+)
+
+// Text returns true if transcriptions in the format |f| are returned as plain text rather than JSON.
+func (f TranscriptionFormat) Text() bool {
+	return f == TranscriptionFormatText || f == TranscriptionFormatSRT || f == TranscriptionFormatVTT
+}
+
+// String implements the fmt.Stringer interface.
+func (f TranscriptionFormat) String() string {
+	return transcriptionFormatToString[f]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f TranscriptionFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |f| to TranscriptionFormatInvalid.
+func (f *TranscriptionFormat) UnmarshalText(b []byte) error {
+	if val, ok := stringToTranscriptionFormat[(string(b))]; ok {
+		*f = val
+		return nil
+	}
+
+	*f = TranscriptionFormatInvalid
+
+	return nil
+}
+
+var transcriptionFormatToString = map[TranscriptionFormat]string{
+	TranscriptionFormatJSON:        "json",
+	TranscriptionFormatVerboseJSON: "verbose_json",
+	TranscriptionFormatText:        "text",
+	TranscriptionFormatSRT:         "srt",
+	TranscriptionFormatVTT:         "vtt",
+}
+
+var stringToTranscriptionFormat = map[string]TranscriptionFormat{
+	"json":         TranscriptionFormatJSON,
+	"verbose_json": TranscriptionFormatVerboseJSON,
+	"text":         TranscriptionFormatText,
+	"srt":          TranscriptionFormatSRT,
+	"vtt":          TranscriptionFormatVTT,
+}
+
+// TimestampGranularity represents the enum values for the granularities of
+// the timestamps of verbose transcriptions.
+type TimestampGranularity int
+
+const (
+	// TimestampGranularityInvalid represents an invalid TimestampGranularity option.
+	TimestampGranularityInvalid TimestampGranularity = iota
+	// TimestampGranularitySegment specifies that the timestamps of each segment are returned. It is the default.
+	TimestampGranularitySegment
+	// TimestampGranularityWord specifies that the timestamps of each word are returned, which incurs additional
+	// latency.
+	TimestampGranularityWord
+)
+
+// String implements the fmt.Stringer interface.
+func (g TimestampGranularity) String() string {
+	return granularityToString[g]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (g TimestampGranularity) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |g| to TimestampGranularityInvalid.
+func (g *TimestampGranularity) UnmarshalText(b []byte) error {
+	if val, ok := stringToGranularity[(string(b))]; ok {
+		*g = val
+		return nil
+	}
+
+	*g = TimestampGranularityInvalid
+
+	return nil
+}
+
+var granularityToString = map[TimestampGranularity]string{
+	TimestampGranularitySegment: "segment",
+	TimestampGranularityWord:    "word",
+}
+
+var stringToGranularity = map[string]TimestampGranularity{
+	"segment": TimestampGranularitySegment,
+	"word":    TimestampGranularityWord,
+}
@@ -0,0 +1,76 @@
+// Package audio contains the enum values which represent the various
+// voices and formats used by the OpenAI audio endpoints.
+package audio
+
+// Voice represents the enum values for the voices available when generating speech.
+type Voice int
+
+const (
+	// VoiceInvalid represents an invalid Voice option.
+	VoiceInvalid Voice = iota
+	// VoiceAlloy is the "alloy" voice.
+	VoiceAlloy
+	// VoiceEcho is the "echo" voice.
+	VoiceEcho
+	// VoiceFable is the "fable" voice.
+	VoiceFable
+	// VoiceOnyx is the "onyx" voice.
+	VoiceOnyx
+	// VoiceNova is the "nova" voice.
+	VoiceNova
+	// VoiceShimmer is the "shimmer" voice.
+	VoiceShimmer
+	// VoiceAsh is the "ash" voice.
+	VoiceAsh
+	// VoiceCoral is the "coral" voice.
+	VoiceCoral
+	// VoiceSage is the "sage" voice.
+	VoiceSage
+)
+
+// String implements the fmt.Stringer interface.
+func (v Voice) String() string {
+	return voiceToString[v]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Voice) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |v| to VoiceInvalid.
+func (v *Voice) UnmarshalText(b []byte) error {
+	if val, ok := stringToVoice[(string(b))]; ok {
+		*v = val
+		return nil
+	}
+
+	*v = VoiceInvalid
+
+	return nil
+}
+
+var voiceToString = map[Voice]string{
+	VoiceAlloy:   "alloy",
+	VoiceEcho:    "echo",
+	VoiceFable:   "fable",
+	VoiceOnyx:    "onyx",
+	VoiceNova:    "nova",
+	VoiceShimmer: "shimmer",
+	VoiceAsh:     "ash",
+	VoiceCoral:   "coral",
+	VoiceSage:    "sage",
+}
+
+var stringToVoice = map[string]Voice{
+	"alloy":   VoiceAlloy,
+	"echo":    VoiceEcho,
+	"fable":   VoiceFable,
+	"onyx":    VoiceOnyx,
+	"nova":    VoiceNova,
+	"shimmer": VoiceShimmer,
+	"ash":     VoiceAsh,
+	"coral":   VoiceCoral,
+	"sage":    VoiceSage,
+}
@@ -0,0 +1,270 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxAudioFileSize is the maximum size of an audio file accepted by the transcriptions endpoint.
+	maxAudioFileSize = 25 << 20
+	// defaultTranscriptionConcurrency is the number of chunks transcribed at once by CreateChunkedTranscription, by
+	// default.
+	defaultTranscriptionConcurrency = 4
+)
+
+// AudioChunk is a part of a longer audio file, which is transcribed on its own.
+type AudioChunk struct {
+	// File is the audio of the chunk.
+	File io.Reader
+	// Filename is the name of the chunk, whose extension determines its format.
+	Filename string
+	// Offset is the time, in seconds, at which the chunk starts within the original audio. It is added to the
+	// timestamps of the transcription of the chunk.
+	Offset float64
+}
+
+// AudioSplitter splits audio into chunks which are each small enough to be transcribed (see
+// CreateChunkedTranscription).
+type AudioSplitter interface {
+	// Split splits the audio read from |r|, whose name is |filename|, into chunks, in order.
+	Split(r io.Reader, filename string) ([]*AudioChunk, error)
+}
+
+// AudioSplitterFunc is an adapter which allows the use of an ordinary function as an AudioSplitter.
+type AudioSplitterFunc func(r io.Reader, filename string) ([]*AudioChunk, error)
+
+// Split implements the AudioSplitter interface by calling f(r, filename).
+func (f AudioSplitterFunc) Split(r io.Reader, filename string) ([]*AudioChunk, error) {
+	return f(r, filename)
+}
+
+// WAVSplitter is an AudioSplitter which splits uncompressed PCM WAV audio into chunks of equal duration, each a valid
+// WAV file. Compressed formats (e.g. mp3) cannot be split at exact times without being decoded; use an AudioSplitter
+// which wraps a tool such as ffmpeg for them. The chunks are held in memory.
+type WAVSplitter struct {
+	// MaxDuration is the maximum duration of a chunk. Shorter chunks are transcribed faster, but each loses the
+	// context of the audio before it.
+	// Defaults to the longest duration whose chunks fit within the 25 MB limit of the transcriptions endpoint.
+	MaxDuration time.Duration
+}
+
+// wavHeaderSize is the size of the header written before the data of each chunk, excluding the format chunk.
+const wavHeaderSize = 20
+
+// Split implements the AudioSplitter interface.
+func (s *WAVSplitter) Split(r io.Reader, filename string) ([]*AudioChunk, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("openai: reading WAV header: %w", err)
+	}
+	if string(header[:4]) != "RIFF" || string(header[8:]) != "WAVE" {
+		return nil, errors.New("openai: not a WAV file")
+	}
+
+	// Find the format and data chunks, skipping any others (e.g. LIST).
+	var format []byte
+	var dataSize uint32
+	for {
+		var ch [8]byte
+		if _, err := io.ReadFull(r, ch[:]); err != nil {
+			return nil, fmt.Errorf("openai: reading WAV chunk: %w", err)
+		}
+		var id, size = string(ch[:4]), binary.LittleEndian.Uint32(ch[4:])
+		if id == "data" {
+			dataSize = size
+			break
+		}
+
+		// Chunks are padded to an even size.
+		var body = make([]byte, size+size%2)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, fmt.Errorf("openai: reading WAV chunk %q: %w", id, err)
+		}
+		if id == "fmt " {
+			format = body[:size]
+		}
+	}
+	if len(format) < 16 {
+		return nil, errors.New("openai: WAV file has no format chunk")
+	}
+	if tag := binary.LittleEndian.Uint16(format); tag != 1 && tag != 0xFFFE {
+		return nil, fmt.Errorf("openai: WAV file is not PCM encoded (format %d)", tag)
+	}
+
+	var byteRate, blockAlign = int(binary.LittleEndian.Uint32(format[8:])), int(binary.LittleEndian.Uint16(format[12:]))
+	if byteRate == 0 || blockAlign == 0 {
+		return nil, errors.New("openai: WAV file has an invalid format chunk")
+	}
+
+	var chunkSize = maxAudioFileSize - wavHeaderSize - 8 - len(format)
+	if s.MaxDuration > 0 {
+		if n := int(s.MaxDuration.Seconds() * float64(byteRate)); n < chunkSize {
+			chunkSize = n
+		}
+	}
+	if chunkSize -= chunkSize % blockAlign; chunkSize <= 0 {
+		return nil, errors.New("openai: WAVSplitter.MaxDuration is shorter than a sample")
+	}
+
+	// Streamed WAV files may not know the size of their data, in which case it is read until EOF.
+	var data = r
+	if dataSize != 0 && dataSize != 0xFFFFFFFF {
+		data = io.LimitReader(r, int64(dataSize))
+	}
+
+	var ext = filepath.Ext(filename)
+	var base = strings.TrimSuffix(filename, ext)
+
+	var chunks []*AudioChunk
+	for read := 0; ; {
+		var buf = make([]byte, chunkSize)
+		var n, err = io.ReadFull(data, buf)
+		if n > 0 {
+			chunks = append(chunks, &AudioChunk{
+				File:     bytes.NewReader(wavFile(format, buf[:n])),
+				Filename: fmt.Sprintf("%s.%d%s", base, len(chunks), ext),
+				Offset:   float64(read) / float64(byteRate),
+			})
+			read += n
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(chunks) == 0 {
+		return nil, errors.New("openai: WAV file has no audio")
+	}
+
+	return chunks, nil
+}
+
+// wavFile returns a WAV file containing the format chunk |format| and the audio |data|.
+func wavFile(format, data []byte) []byte {
+	var b = make([]byte, 0, wavHeaderSize+8+len(format)+len(data))
+	b = append(b, "RIFF"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(4+8+len(format)+8+len(data)))
+	b = append(b, "WAVEfmt "...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(format)))
+	b = append(b, format...)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+
+	return append(b, data...)
+}
+
+// CreateChunkedTranscription transcribes audio longer than the 25 MB limit of the transcriptions endpoint. The File of
+// |tr| is split into chunks by |s| (a *WAVSplitter if nil), which are transcribed by up to |concurrency| (4 if 0)
+// concurrent requests, each with the other parameters of |tr|. Their transcriptions are stitched together in order:
+// their text is joined, and the timestamps of their segments and words are offset by the start of their chunk.
+//
+// ResponseFormat must be one of the JSON formats; subtitles can be generated from a verbose transcription with SRT or
+// VTT. If any chunk cannot be transcribed, the first error is returned and the remaining requests are canceled.
+func (c *Client) CreateChunkedTranscription(ctx context.Context, tr *TranscriptionRequest, s AudioSplitter, concurrency int, opts ...RequestOption) (*TranscriptionResponse, error) {
+	if tr.ResponseFormat.Text() {
+		return nil, fmt.Errorf("openai: chunked transcriptions do not support the %s response format", tr.ResponseFormat)
+	}
+	if s == nil {
+		s = &WAVSplitter{}
+	}
+	if concurrency <= 0 {
+		concurrency = defaultTranscriptionConcurrency
+	}
+
+	var chunks, err = s.Split(tr.File, tr.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 0 {
+		return nil, errors.New("openai: audio splitter returned no chunks")
+	}
+
+	var cctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	var results = make([]*TranscriptionResponse, len(chunks))
+	var errs = make([]error, len(chunks))
+	var sem = make(semaphore, concurrency)
+
+	var wg sync.WaitGroup
+	for i, ch := range chunks {
+		wg.Add(1)
+		go func(i int, ch *AudioChunk) {
+			defer wg.Done()
+
+			if errs[i] = sem.acquire(cctx); errs[i] != nil {
+				return
+			}
+			defer sem.release()
+
+			var req = *tr
+			req.File, req.Filename = ch.File, ch.Filename
+			if results[i], errs[i] = c.CreateTranscription(cctx, &req, opts...); errs[i] != nil {
+				cancel()
+			}
+		}(i, ch)
+	}
+	wg.Wait()
+
+	// Prefer the error which caused the others to be canceled.
+	for _, err = range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+	for _, err = range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stitchTranscriptions(chunks, results), nil
+}
+
+// stitchTranscriptions returns the transcription of the audio split into |chunks|, whose transcriptions are
+// |results|.
+func stitchTranscriptions(chunks []*AudioChunk, results []*TranscriptionResponse) *TranscriptionResponse {
+	var stitched = &TranscriptionResponse{Language: results[0].Language}
+
+	var text = make([]string, 0, len(results))
+	for i, res := range results {
+		var offset = chunks[i].Offset
+		if t := strings.TrimSpace(res.Text); t != "" {
+			text = append(text, t)
+		}
+
+		for _, seg := range res.Segments {
+			var s = *seg
+			s.ID = len(stitched.Segments)
+			s.Start += offset
+			s.End += offset
+			stitched.Segments = append(stitched.Segments, &s)
+		}
+		for _, word := range res.Words {
+			var w = *word
+			w.Start += offset
+			w.End += offset
+			stitched.Words = append(stitched.Words, &w)
+		}
+
+		if res.Duration > 0 {
+			stitched.Duration = offset + res.Duration
+		}
+	}
+	stitched.Text = strings.Join(text, " ")
+	// The metadata of the last request is kept, as there is no single request to refer to.
+	stitched.ResponseMeta = results[len(results)-1].ResponseMeta
+
+	return stitched
+}
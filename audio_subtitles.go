@@ -0,0 +1,41 @@
+package openai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SRT formats the Segments of |tr|, a verbose transcription (see audio.TranscriptionFormatVerboseJSON), as SubRip
+// subtitles, with one cue per segment.
+func (tr *TranscriptionResponse) SRT() string {
+	var b strings.Builder
+	for i, s := range tr.Segments {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1,
+			subtitleTimestamp(s.Start, ","), subtitleTimestamp(s.End, ","), strings.TrimSpace(s.Text))
+	}
+
+	return b.String()
+}
+
+// VTT formats the Segments of |tr|, a verbose transcription (see audio.TranscriptionFormatVerboseJSON), as WebVTT
+// subtitles, with one cue per segment.
+func (tr *TranscriptionResponse) VTT() string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, s := range tr.Segments {
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+			subtitleTimestamp(s.Start, "."), subtitleTimestamp(s.End, "."), strings.TrimSpace(s.Text))
+	}
+
+	return b.String()
+}
+
+// subtitleTimestamp formats |seconds| as hours, minutes, seconds, and milliseconds, with the milliseconds separated by
+// |sep|: "," for SRT, and "." for WebVTT.
+func subtitleTimestamp(seconds float64, sep string) string {
+	var d = time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, sep, d%time.Second/time.Millisecond)
+}
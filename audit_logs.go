@@ -0,0 +1,255 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fabiustech/openai/auditlogs"
+	"github.com/fabiustech/openai/routes"
+)
+
+// AuditLogsRequest contains all relevant fields for requests to the organization/audit_logs endpoint. All fields are
+// optional filters.
+type AuditLogsRequest struct {
+	// EffectiveAfter limits the results to events which took effect at or after this time.
+	EffectiveAfter *time.Time
+	// EffectiveBefore limits the results to events which took effect before this time.
+	EffectiveBefore *time.Time
+	// ProjectIDs limits the results to events for these projects.
+	ProjectIDs []string
+	// EventTypes limits the results to events of these types.
+	EventTypes []auditlogs.Event
+	// ActorIDs limits the results to events performed by these actors (user, service account, or API key IDs).
+	ActorIDs []string
+	// ActorEmails limits the results to events performed by users with these emails.
+	ActorEmails []string
+	// ResourceIDs limits the results to events targeting these resources.
+	ResourceIDs []string
+	// Limit specifies the number of events to return, between 1 and 100.
+	// Defaults to 20.
+	Limit int
+	// After is a cursor for pagination. Set to the LastID of the previous page to fetch the next page.
+	After string
+	// Before is a cursor for pagination. Set to the FirstID of the previous page to fetch the previous page.
+	Before string
+}
+
+// values returns |ar| encoded as query parameters.
+func (ar *AuditLogsRequest) values() url.Values {
+	var v = url.Values{}
+
+	if ar.EffectiveAfter != nil {
+		v.Set("effective_at[gte]", strconv.FormatInt(ar.EffectiveAfter.Unix(), 10))
+	}
+
+	if ar.EffectiveBefore != nil {
+		v.Set("effective_at[lt]", strconv.FormatInt(ar.EffectiveBefore.Unix(), 10))
+	}
+
+	for _, id := range ar.ProjectIDs {
+		v.Add("project_ids[]", id)
+	}
+
+	for _, e := range ar.EventTypes {
+		v.Add("event_types[]", e.String())
+	}
+
+	for _, id := range ar.ActorIDs {
+		v.Add("actor_ids[]", id)
+	}
+
+	for _, e := range ar.ActorEmails {
+		v.Add("actor_emails[]", e)
+	}
+
+	for _, id := range ar.ResourceIDs {
+		v.Add("resource_ids[]", id)
+	}
+
+	if ar.Limit > 0 {
+		v.Set("limit", strconv.Itoa(ar.Limit))
+	}
+
+	if ar.After != "" {
+		v.Set("after", ar.After)
+	}
+
+	if ar.Before != "" {
+		v.Set("before", ar.Before)
+	}
+
+	return v
+}
+
+// AuditLog represents a single user action or configuration change within the organization. Exactly one of the
+// event payload fields is set, depending on Type (some event types, such as auditlogs.EventLoginSucceeded, carry no
+// payload).
+type AuditLog struct {
+	ID   string          `json:"id"`
+	Type auditlogs.Event `json:"type"`
+	// EffectiveAt is the Unix timestamp (in seconds) of the event.
+	EffectiveAt uint64 `json:"effective_at"`
+	// Project is the project that the action was scoped to. Absent for actions not scoped to projects.
+	Project *AuditLogProject `json:"project,omitempty"`
+	Actor   *AuditLogActor   `json:"actor"`
+
+	APIKeyCreated         *AuditLogAPIKeyEvent       `json:"api_key.created,omitempty"`
+	APIKeyUpdated         *AuditLogAPIKeyEvent       `json:"api_key.updated,omitempty"`
+	APIKeyDeleted         *AuditLogAPIKeyEvent       `json:"api_key.deleted,omitempty"`
+	InviteSent            *AuditLogInviteEvent       `json:"invite.sent,omitempty"`
+	InviteAccepted        *AuditLogInviteEvent       `json:"invite.accepted,omitempty"`
+	InviteDeleted         *AuditLogInviteEvent       `json:"invite.deleted,omitempty"`
+	LoginFailed           *AuditLogFailureEvent      `json:"login.failed,omitempty"`
+	LogoutFailed          *AuditLogFailureEvent      `json:"logout.failed,omitempty"`
+	OrganizationUpdated   *AuditLogOrganizationEvent `json:"organization.updated,omitempty"`
+	ProjectCreated        *AuditLogProjectEvent      `json:"project.created,omitempty"`
+	ProjectUpdated        *AuditLogProjectEvent      `json:"project.updated,omitempty"`
+	ProjectArchived       *AuditLogProjectEvent      `json:"project.archived,omitempty"`
+	ServiceAccountCreated *AuditLogRoleEvent         `json:"service_account.created,omitempty"`
+	ServiceAccountUpdated *AuditLogRoleEvent         `json:"service_account.updated,omitempty"`
+	ServiceAccountDeleted *AuditLogRoleEvent         `json:"service_account.deleted,omitempty"`
+	UserAdded             *AuditLogRoleEvent         `json:"user.added,omitempty"`
+	UserUpdated           *AuditLogRoleEvent         `json:"user.updated,omitempty"`
+	UserDeleted           *AuditLogRoleEvent         `json:"user.deleted,omitempty"`
+}
+
+// AuditLogProject is the project that an audited action was scoped to.
+type AuditLogProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuditLogActor is the actor who performed an audited action.
+type AuditLogActor struct {
+	// Type is one of "session" or "api_key".
+	Type    string                `json:"type"`
+	Session *AuditLogActorSession `json:"session,omitempty"`
+	APIKey  *AuditLogActorAPIKey  `json:"api_key,omitempty"`
+}
+
+// AuditLogActorSession is the session in which an audited action was performed.
+type AuditLogActorSession struct {
+	User      *AuditLogActorUser `json:"user"`
+	IPAddress string             `json:"ip_address"`
+}
+
+// AuditLogActorAPIKey is the API key used to perform an audited action.
+type AuditLogActorAPIKey struct {
+	ID string `json:"id"`
+	// Type is one of "user" or "service_account".
+	Type           string                       `json:"type"`
+	User           *AuditLogActorUser           `json:"user,omitempty"`
+	ServiceAccount *AuditLogActorServiceAccount `json:"service_account,omitempty"`
+}
+
+// AuditLogActorUser is the user who performed an audited action.
+type AuditLogActorUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// AuditLogActorServiceAccount is the service account which performed an audited action.
+type AuditLogActorServiceAccount struct {
+	ID string `json:"id"`
+}
+
+// AuditLogAPIKeyEvent is the payload of the api_key.* events.
+type AuditLogAPIKeyEvent struct {
+	// ID is the tracking ID of the API key.
+	ID string `json:"id"`
+	// Data contains the scopes of a created API key.
+	Data *AuditLogAPIKeyScopes `json:"data,omitempty"`
+	// ChangesRequested contains the scopes requested for an updated API key.
+	ChangesRequested *AuditLogAPIKeyScopes `json:"changes_requested,omitempty"`
+}
+
+// AuditLogAPIKeyScopes contains the scopes of an API key.
+type AuditLogAPIKeyScopes struct {
+	// Scopes is a list of scopes allowed for the API key, e.g. ["api.model.request"].
+	Scopes []string `json:"scopes"`
+}
+
+// AuditLogInviteEvent is the payload of the invite.* events.
+type AuditLogInviteEvent struct {
+	// ID is the ID of the invite.
+	ID string `json:"id"`
+	// Data contains the email and role of a sent invite.
+	Data *struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	} `json:"data,omitempty"`
+}
+
+// AuditLogFailureEvent is the payload of the login.failed and logout.failed events.
+type AuditLogFailureEvent struct {
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// AuditLogOrganizationEvent is the payload of the organization.updated event.
+type AuditLogOrganizationEvent struct {
+	// ID is the organization ID.
+	ID string `json:"id"`
+	// ChangesRequested contains the organization fields which were updated.
+	ChangesRequested *struct {
+		Title       *string         `json:"title,omitempty"`
+		Description *string         `json:"description,omitempty"`
+		Name        *string         `json:"name,omitempty"`
+		Settings    json.RawMessage `json:"settings,omitempty"`
+	} `json:"changes_requested,omitempty"`
+}
+
+// AuditLogProjectEvent is the payload of the project.* events.
+type AuditLogProjectEvent struct {
+	// ID is the project ID.
+	ID string `json:"id"`
+	// Data contains the name and title of a created project.
+	Data *struct {
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	} `json:"data,omitempty"`
+	// ChangesRequested contains the project fields which were updated.
+	ChangesRequested *struct {
+		Title string `json:"title"`
+	} `json:"changes_requested,omitempty"`
+}
+
+// AuditLogRoleEvent is the payload of the service_account.* and user.* events.
+type AuditLogRoleEvent struct {
+	// ID is the service account or user ID.
+	ID string `json:"id"`
+	// Data contains the role of a created service account or added user.
+	Data *AuditLogRole `json:"data,omitempty"`
+	// ChangesRequested contains the new role of an updated service account or user.
+	ChangesRequested *AuditLogRole `json:"changes_requested,omitempty"`
+}
+
+// AuditLogRole contains a role within the organization or a project.
+type AuditLogRole struct {
+	Role string `json:"role"`
+}
+
+// ListAuditLogs lists the user actions and configuration changes within the organization, most recent first. Use
+// AuditLogsRequest.After with the returned LastID to fetch subsequent pages while HasMore is true. |ar| may be nil.
+// This endpoint requires an admin API key (see WithAdminKey).
+func (c *Client) ListAuditLogs(ctx context.Context, ar *AuditLogsRequest, opts ...RequestOption) (*List[*AuditLog], error) {
+	var route = routes.OrganizationAuditLogs
+	if ar != nil {
+		route = withQuery(route, ar.values())
+	}
+
+	var res, err = c.get(ctx, route, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*AuditLog]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
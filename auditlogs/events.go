@@ -0,0 +1,120 @@
+// Package auditlogs contains the enum values which represent the various
+// event types recorded by the OpenAI audit logs endpoint.
+package auditlogs
+
+// Event represents the enum values for the types of audit log events.
+type Event int
+
+const (
+	// EventInvalid represents an invalid Event option.
+	EventInvalid Event = iota
+	// EventAPIKeyCreated is recorded when an API key was created.
+	EventAPIKeyCreated
+	// EventAPIKeyUpdated is recorded when an API key was updated.
+	EventAPIKeyUpdated
+	// EventAPIKeyDeleted is recorded when an API key was deleted.
+	EventAPIKeyDeleted
+	// EventInviteSent is recorded when an invite was sent.
+	EventInviteSent
+	// EventInviteAccepted is recorded when an invite was accepted.
+	EventInviteAccepted
+	// EventInviteDeleted is recorded when an invite was deleted.
+	EventInviteDeleted
+	// EventLoginSucceeded is recorded when a user logged in.
+	EventLoginSucceeded
+	// EventLoginFailed is recorded when a user failed to log in.
+	EventLoginFailed
+	// EventLogoutSucceeded is recorded when a user logged out.
+	EventLogoutSucceeded
+	// EventLogoutFailed is recorded when a user failed to log out.
+	EventLogoutFailed
+	// EventOrganizationUpdated is recorded when the organization was updated.
+	EventOrganizationUpdated
+	// EventProjectCreated is recorded when a project was created.
+	EventProjectCreated
+	// EventProjectUpdated is recorded when a project was updated.
+	EventProjectUpdated
+	// EventProjectArchived is recorded when a project was archived.
+	EventProjectArchived
+	// EventServiceAccountCreated is recorded when a service account was created.
+	EventServiceAccountCreated
+	// EventServiceAccountUpdated is recorded when a service account was updated.
+	EventServiceAccountUpdated
+	// EventServiceAccountDeleted is recorded when a service account was deleted.
+	EventServiceAccountDeleted
+	// EventUserAdded is recorded when a user was added to the organization or a project.
+	EventUserAdded
+	// EventUserUpdated is recorded when a user's role was updated.
+	EventUserUpdated
+	// EventUserDeleted is recorded when a user was removed from the organization or a project.
+	EventUserDeleted
+)
+
+// String implements the fmt.Stringer interface.
+func (e Event) String() string {
+	return eventToString[e]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e Event) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to EventInvalid.
+func (e *Event) UnmarshalText(b []byte) error {
+	if val, ok := stringToEvent[(string(b))]; ok {
+		*e = val
+		return nil
+	}
+
+	*e = EventInvalid
+
+	return nil
+}
+
+var eventToString = map[Event]string{
+	EventAPIKeyCreated:         "api_key.created",
+	EventAPIKeyUpdated:         "api_key.updated",
+	EventAPIKeyDeleted:         "api_key.deleted",
+	EventInviteSent:            "invite.sent",
+	EventInviteAccepted:        "invite.accepted",
+	EventInviteDeleted:         "invite.deleted",
+	EventLoginSucceeded:        "login.succeeded",
+	EventLoginFailed:           "login.failed",
+	EventLogoutSucceeded:       "logout.succeeded",
+	EventLogoutFailed:          "logout.failed",
+	EventOrganizationUpdated:   "organization.updated",
+	EventProjectCreated:        "project.created",
+	EventProjectUpdated:        "project.updated",
+	EventProjectArchived:       "project.archived",
+	EventServiceAccountCreated: "service_account.created",
+	EventServiceAccountUpdated: "service_account.updated",
+	EventServiceAccountDeleted: "service_account.deleted",
+	EventUserAdded:             "user.added",
+	EventUserUpdated:           "user.updated",
+	EventUserDeleted:           "user.deleted",
+}
+
+var stringToEvent = map[string]Event{
+	"api_key.created":         EventAPIKeyCreated,
+	"api_key.updated":         EventAPIKeyUpdated,
+	"api_key.deleted":         EventAPIKeyDeleted,
+	"invite.sent":             EventInviteSent,
+	"invite.accepted":         EventInviteAccepted,
+	"invite.deleted":          EventInviteDeleted,
+	"login.succeeded":         EventLoginSucceeded,
+	"login.failed":            EventLoginFailed,
+	"logout.succeeded":        EventLogoutSucceeded,
+	"logout.failed":           EventLogoutFailed,
+	"organization.updated":    EventOrganizationUpdated,
+	"project.created":         EventProjectCreated,
+	"project.updated":         EventProjectUpdated,
+	"project.archived":        EventProjectArchived,
+	"service_account.created": EventServiceAccountCreated,
+	"service_account.updated": EventServiceAccountUpdated,
+	"service_account.deleted": EventServiceAccountDeleted,
+	"user.added":              EventUserAdded,
+	"user.updated":            EventUserUpdated,
+	"user.deleted":            EventUserDeleted,
+}
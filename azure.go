@@ -0,0 +1,117 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/fabiustech/openai/routes"
+)
+
+// DefaultAzureAPIVersion is the Azure OpenAI Service API version used if AzureConfig.APIVersion is empty.
+const DefaultAzureAPIVersion = "2024-06-01"
+
+// AzureConfig configures a Client for the Azure OpenAI Service (see NewAzureClient).
+type AzureConfig struct {
+	// Endpoint is the endpoint of the Azure OpenAI resource (e.g. "https://my-resource.openai.azure.com").
+	Endpoint string
+	// APIVersion is the version of the Azure OpenAI Service API, sent as the api-version query parameter.
+	// Defaults to DefaultAzureAPIVersion.
+	APIVersion string
+	// Deployments maps model names (e.g. "gpt-4o") to the IDs of the deployments which serve them. Models without an
+	// entry are assumed to be deployed under their own name.
+	Deployments map[string]string
+}
+
+// azureDeploymentRoutes are the routes which Azure serves per deployment, rather than per resource.
+var azureDeploymentRoutes = map[string]bool{
+	routes.AudioSpeech:         true,
+	routes.AudioTranscriptions: true,
+	routes.ChatCompletions:     true,
+	routes.Completions:         true,
+	routes.Embeddings:          true,
+	routes.ImageGenerations:    true,
+}
+
+// azureClient contains the parsed AzureConfig of a Client.
+type azureClient struct {
+	endpoint    *url.URL
+	apiVersion  string
+	deployments map[string]string
+}
+
+// NewAzureClient creates a new client for the Azure OpenAI Service, authenticated with the resource's |apiKey|. The
+// Client accepts the same typed requests as one returned by NewClient: routes are rewritten to the Azure URL shape, and
+// the model of each request is mapped to its deployment.
+func NewAzureClient(apiKey string, cfg *AzureConfig, opts ...Option) (*Client, error) {
+	var u, err = url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("openai: invalid Azure endpoint: %q", cfg.Endpoint)
+	}
+
+	var c = NewClient(apiKey, opts...)
+	c.azure = &azureClient{
+		endpoint:    u,
+		apiVersion:  cfg.APIVersion,
+		deployments: cfg.Deployments,
+	}
+
+	if c.azure.apiVersion == "" {
+		c.azure.apiVersion = DefaultAzureAPIVersion
+	}
+
+	return c, nil
+}
+
+// url returns the Azure URL for |route|. Requests to deployment routes are sent to the deployment serving |model|.
+func (a *azureClient) url(route, model string) (string, error) {
+	var p, q, _ = strings.Cut(route, "?")
+
+	var v, err = url.ParseQuery(q)
+	if err != nil {
+		return "", err
+	}
+	v.Set("api-version", a.apiVersion)
+
+	var u = *a.endpoint
+	if azureDeploymentRoutes[p] {
+		if model == "" {
+			return "", fmt.Errorf("openai: a model is required to route %q to an Azure deployment", p)
+		}
+
+		var deployment = model
+		if d, ok := a.deployments[model]; ok {
+			deployment = d
+		}
+		u.Path = path.Join(u.Path, "openai/deployments", deployment, p)
+	} else {
+		u.Path = path.Join(u.Path, "openai", p)
+	}
+	u.RawQuery = v.Encode()
+
+	return u.String(), nil
+}
+
+// withModel records the model of a request whose body is not JSON (e.g. a multipart form), so that it can be routed
+// to an Azure deployment.
+func withModel(model fmt.Stringer) RequestOption {
+	return func(rc *requestConfig) {
+		rc.model = model.String()
+	}
+}
+
+// jsonModel returns the "model" field of the JSON encoded request body |b|, if any.
+func jsonModel(b []byte) string {
+	var v struct {
+		Model string `json:"model"`
+	}
+	_ = json.Unmarshal(b, &v)
+
+	return v.Model
+}
@@ -0,0 +1,460 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/fabiustech/openai/files"
+	"github.com/fabiustech/openai/jsonl"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+const (
+	// defaultCompletionWindow is the only completion window batches support.
+	defaultCompletionWindow = "24h"
+	// maxBatchRequests is the maximum number of requests in a batch.
+	maxBatchRequests = 50000
+
+	defaultBatchPollInterval    = 5 * time.Second
+	defaultBatchMaxPollInterval = time.Minute
+)
+
+// batchURLs are the URLs of the endpoints which batches support.
+var batchURLs = map[string]bool{
+	"/v1/" + routes.ChatCompletions: true,
+	"/v1/" + routes.Completions:     true,
+	"/v1/" + routes.Embeddings:      true,
+	"/v1/" + routes.Moderations:     true,
+}
+
+// BatchRequestLine is a line of the input file of a batch: a request to an endpoint, whose body is of type T (e.g.
+// *ChatCompletionRequest[models.Chat]). Use it with the jsonl package to write batch input files.
+type BatchRequestLine[T any] struct {
+	// CustomID identifies the request, and its result in the output file of the batch. It must be unique within the
+	// batch.
+	CustomID string `json:"custom_id"`
+	// Method is the HTTP method of the request. Only POST is supported.
+	Method string `json:"method"`
+	// URL is the path of the endpoint (e.g. "/v1/chat/completions").
+	URL string `json:"url"`
+	// Body is the body of the request.
+	Body T `json:"body"`
+}
+
+var _ jsonl.Validator = (*BatchRequestLine[any])(nil)
+
+// Validate returns an error if |l| is missing a required field or targets an endpoint which batches do not support.
+// If the body can be validated (e.g. a *ChatCompletionRequest), it is validated as well.
+func (l *BatchRequestLine[T]) Validate() error {
+	if l.CustomID == "" {
+		return errors.New("openai: batch request has no custom_id")
+	}
+
+	if l.Method != http.MethodPost {
+		return fmt.Errorf("openai: batch request %q has method %q; only POST is supported", l.CustomID, l.Method)
+	}
+
+	if !batchURLs[l.URL] {
+		return fmt.Errorf("openai: batch request %q has unsupported url %q", l.CustomID, l.URL)
+	}
+
+	if reflect.ValueOf(&l.Body).Elem().IsZero() {
+		return fmt.Errorf("openai: batch request %q has no body", l.CustomID)
+	}
+
+	if v, ok := any(l.Body).(interface{ validate() error }); ok {
+		if err := v.validate(); err != nil {
+			return fmt.Errorf("openai: batch request %q: %w", l.CustomID, err)
+		}
+	}
+
+	return nil
+}
+
+// BatchResponseLine is a line of the output or error file of a batch: the result of the request with the same
+// CustomID, whose response body is of type T (e.g. *ChatCompletionResponse[models.Chat]). Use it with the jsonl
+// package to read batch output files.
+type BatchResponseLine[T any] struct {
+	// ID is the ID of the batch request.
+	ID string `json:"id"`
+	// CustomID is the CustomID of the request.
+	CustomID string `json:"custom_id"`
+	// Response is the response to the request. It is nil if the request could not be sent.
+	Response *BatchLineResponse[T] `json:"response"`
+	// Error is set if the request could not be sent.
+	Error *BatchLineError `json:"error"`
+}
+
+var _ jsonl.Validator = (*BatchResponseLine[any])(nil)
+
+// Validate returns an error if |l| is missing its custom_id, or has neither a response nor an error.
+func (l *BatchResponseLine[T]) Validate() error {
+	if l.CustomID == "" {
+		return errors.New("openai: batch response has no custom_id")
+	}
+
+	if l.Response == nil && l.Error == nil {
+		return fmt.Errorf("openai: batch response %q has neither a response nor an error", l.CustomID)
+	}
+
+	return nil
+}
+
+// BatchLineResponse is the response to a request of a batch.
+type BatchLineResponse[T any] struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"status_code"`
+	// RequestID is the unique ID assigned to the request by OpenAI.
+	RequestID string `json:"request_id"`
+	// Body is the body of the response. If StatusCode is not 200, it contains an error rather than a T.
+	Body T `json:"body"`
+}
+
+// BatchLineError describes why a request of a batch could not be sent.
+type BatchLineError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchRequest contains all relevant fields for requests to the batches endpoint.
+type BatchRequest struct {
+	// InputFileID is the ID of an uploaded file, with the purpose files.PurposeBatch, which contains the requests of
+	// the batch as BatchRequestLine.
+	InputFileID string `json:"input_file_id"`
+	// Endpoint is the endpoint all requests of the batch are sent to (e.g. "/v1/chat/completions").
+	Endpoint string `json:"endpoint"`
+	// CompletionWindow is the time frame within which the batch is processed. Only "24h" is supported.
+	// Defaults to "24h".
+	CompletionWindow string `json:"completion_window"`
+	// Metadata is a set of up to 16 key-value pairs attached to the batch.
+	// Defaults to null.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// validate returns an error if |br| has no input file, or targets an endpoint which batches do not support.
+func (br *BatchRequest) validate() error {
+	if br.InputFileID == "" {
+		return errors.New("openai: batch has no input file")
+	}
+
+	if !batchURLs[br.Endpoint] {
+		return fmt.Errorf("openai: batches do not support the endpoint %q", br.Endpoint)
+	}
+
+	return nil
+}
+
+// BatchRequestCounts contains the number of requests of a batch in each state.
+type BatchRequestCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// BatchInputError is an error in the input file of a batch, which prevented it from being processed.
+type BatchInputError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param"`
+	// Line is the line of the input file which caused the error, if any.
+	Line *int `json:"line"`
+}
+
+// Batch represents a batch of requests, which are processed asynchronously at a lower cost.
+type Batch struct {
+	ResponseMeta
+
+	ID               string                  `json:"id"`
+	Object           objects.Object          `json:"object"`
+	Endpoint         string                  `json:"endpoint"`
+	Errors           *List[*BatchInputError] `json:"errors"`
+	InputFileID      string                  `json:"input_file_id"`
+	CompletionWindow string                  `json:"completion_window"`
+	// Status is one of "validating", "failed", "in_progress", "finalizing", "completed", "expired", "cancelling", or
+	// "cancelled".
+	Status string `json:"status"`
+	// OutputFileID is the ID of the file which contains the responses of the requests which succeeded.
+	OutputFileID string `json:"output_file_id"`
+	// ErrorFileID is the ID of the file which contains the responses of the requests which failed.
+	ErrorFileID   string              `json:"error_file_id"`
+	CreatedAt     uint64              `json:"created_at"`
+	InProgressAt  *uint64             `json:"in_progress_at"`
+	ExpiresAt     *uint64             `json:"expires_at"`
+	FinalizingAt  *uint64             `json:"finalizing_at"`
+	CompletedAt   *uint64             `json:"completed_at"`
+	FailedAt      *uint64             `json:"failed_at"`
+	ExpiredAt     *uint64             `json:"expired_at"`
+	CancellingAt  *uint64             `json:"cancelling_at"`
+	CancelledAt   *uint64             `json:"cancelled_at"`
+	RequestCounts *BatchRequestCounts `json:"request_counts"`
+	Metadata      map[string]string   `json:"metadata"`
+}
+
+// Done returns true if |b| has reached a terminal status: "completed", "failed", "expired", or "cancelled".
+func (b *Batch) Done() bool {
+	switch b.Status {
+	case "completed", "failed", "expired", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateBatch creates and executes a batch from an uploaded file of requests.
+func (c *Client) CreateBatch(ctx context.Context, br *BatchRequest, opts ...RequestOption) (*Batch, error) {
+	if err := br.validate(); err != nil {
+		return nil, err
+	}
+
+	var req = *br
+	if req.CompletionWindow == "" {
+		req.CompletionWindow = defaultCompletionWindow
+	}
+
+	var res, err = c.post(ctx, routes.Batches, &req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b = &Batch{}
+	if err = res.decode(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// RetrieveBatch retrieves a batch.
+func (c *Client) RetrieveBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error) {
+	var res, err = c.get(ctx, path.Join(routes.Batches, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b = &Batch{}
+	if err = res.decode(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// CancelBatch cancels an in-progress batch. The batch is "cancelling" for up to 10 minutes before it is "cancelled",
+// at which point the responses of the requests which completed are available in its output file.
+func (c *Client) CancelBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error) {
+	var res, err = c.post(ctx, path.Join(routes.Batches, id, "cancel"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b = &Batch{}
+	if err = res.decode(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ListBatches lists your organization's batches. Paginate the results with WithListOptions.
+func (c *Client) ListBatches(ctx context.Context, opts ...RequestOption) (*List[*Batch], error) {
+	var res, err = c.get(ctx, routes.Batches, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*Batch]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// BatchInput is a request of a batch submitted with SubmitBatch.
+type BatchInput[T any] struct {
+	// CustomID identifies the request, and its result in the output file of the batch. It must be unique within the
+	// batch.
+	CustomID string
+	// Body is the body of the request (e.g. a *ChatCompletionRequest[models.Chat]).
+	Body T
+}
+
+// SubmitBatch writes |inputs| to an input file, uploads it, and creates a batch which sends each request to
+// |endpoint| (e.g. "/v1/chat/completions"), in one call:
+//
+//	var batch, err = openai.SubmitBatch(ctx, client, "/v1/embeddings", []*openai.BatchInput[*openai.EmbeddingRequest]{
+//		{CustomID: "doc-1", Body: &openai.EmbeddingRequest{Input: []string{"..."}, Model: models.AdaEmbeddingV2}},
+//	}, nil)
+//
+// Every request is validated before anything is uploaded. If the batch cannot be created, the uploaded file is
+// deleted. |metadata| may be nil.
+func SubmitBatch[T any](ctx context.Context, api interface {
+	FilesAPI
+	BatchesAPI
+}, endpoint string, inputs []*BatchInput[T], metadata map[string]string, opts ...RequestOption) (*Batch, error) {
+	switch {
+	case len(inputs) == 0:
+		return nil, errors.New("openai: batch has no requests")
+	case len(inputs) > maxBatchRequests:
+		return nil, fmt.Errorf("openai: batch has %d requests, more than the maximum of %d", len(inputs), maxBatchRequests)
+	}
+
+	var buf bytes.Buffer
+	var w = jsonl.NewWriter[*BatchRequestLine[T]](&buf)
+	var ids = make(map[string]bool, len(inputs))
+	for _, in := range inputs {
+		if ids[in.CustomID] {
+			return nil, fmt.Errorf("openai: batch has more than one request with custom_id %q", in.CustomID)
+		}
+		ids[in.CustomID] = true
+
+		var err = w.Write(&BatchRequestLine[T]{CustomID: in.CustomID, Method: http.MethodPost, URL: endpoint, Body: in.Body})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var f, err = api.UploadFile(ctx, &FileRequest{File: &buf, Filename: "batch.jsonl", Purpose: files.PurposeBatch}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b *Batch
+	if b, err = api.CreateBatch(ctx, &BatchRequest{InputFileID: f.ID, Endpoint: endpoint, Metadata: metadata}, opts...); err != nil {
+		// Best effort; the file is useless without the batch.
+		_ = api.DeleteFile(ctx, f.ID, opts...)
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// BatchWaitOptions configures WaitForBatch.
+type BatchWaitOptions struct {
+	// Interval is the delay before the batch is polled again for the first time. It doubles after each poll, up to
+	// MaxInterval.
+	// Defaults to 5s.
+	Interval time.Duration
+	// MaxInterval is the maximum delay between polls.
+	// Defaults to 1m.
+	MaxInterval time.Duration
+	// Progress, if set, is called with the batch after each poll, so that its RequestCounts (the number of requests
+	// which have completed and failed) can be reported.
+	Progress func(b *Batch)
+}
+
+// WaitForBatch polls the batch |id| until it reaches a terminal status (see Batch.Done), and returns it. A batch which
+// failed, expired, or was cancelled is not an error; check its Status. The delay between polls grows exponentially, as
+// configured by |wo|, which may be nil. WaitForBatch returns early with an error if |ctx| is done, or if the batch
+// cannot be retrieved.
+func WaitForBatch(ctx context.Context, api BatchesAPI, id string, wo *BatchWaitOptions, opts ...RequestOption) (*Batch, error) {
+	var o BatchWaitOptions
+	if wo != nil {
+		o = *wo
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultBatchPollInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultBatchMaxPollInterval
+	}
+
+	for interval := o.Interval; ; interval *= 2 {
+		var b, err = api.RetrieveBatch(ctx, id, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if o.Progress != nil {
+			o.Progress(b)
+		}
+		if b.Done() {
+			return b, nil
+		}
+
+		if interval > o.MaxInterval {
+			interval = o.MaxInterval
+		}
+		if err = sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// BatchResults contains the results of the requests of a batch, keyed by their CustomID.
+type BatchResults[T any] struct {
+	// Responses contains the response body of each request which succeeded.
+	Responses map[string]T
+	// Errors contains the error of each request which failed. StatusCode is 0 if the request could not be sent.
+	Errors map[string]*APIError
+}
+
+// GetBatchResults downloads the output and error files of |b| and decodes the result of each request, whose response
+// body is of type T (e.g. *ChatCompletionResponse[models.Chat] or *EmbeddingResponse):
+//
+//	var results, err = openai.GetBatchResults[*openai.EmbeddingResponse](ctx, client, batch)
+//
+// Requests which failed are reported in Errors rather than as an error, so that the responses of the others can be
+// used. Requests which were not processed (e.g. because the batch expired or was cancelled) are in neither. The files
+// are decoded as they are downloaded, so they are never fully loaded into memory.
+func GetBatchResults[T any](ctx context.Context, api FilesAPI, b *Batch, opts ...RequestOption) (*BatchResults[T], error) {
+	if b.OutputFileID == "" && b.ErrorFileID == "" {
+		return nil, fmt.Errorf("openai: batch %s (%s) has no output or error file", b.ID, b.Status)
+	}
+
+	var results = &BatchResults[T]{Responses: map[string]T{}, Errors: map[string]*APIError{}}
+	for _, id := range []string{b.OutputFileID, b.ErrorFileID} {
+		if id == "" {
+			continue
+		}
+		if err := results.read(ctx, api, id, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// read decodes the results in the file |id| into |r|.
+func (r *BatchResults[T]) read(ctx context.Context, api FilesAPI, id string, opts ...RequestOption) error {
+	var pr, pw = io.Pipe()
+	go func() {
+		pw.CloseWithError(api.GetFileContent(ctx, id, pw, opts...))
+	}()
+	defer pr.Close()
+
+	// Bodies are decoded once their status code is known, as those of failed requests contain an error.
+	var jr = jsonl.NewReader[*BatchResponseLine[json.RawMessage]](pr)
+	for {
+		var l, err = jr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("openai: reading batch file %s: %w", id, err)
+		}
+
+		switch res := l.Response; {
+		case l.Error != nil:
+			r.Errors[l.CustomID] = &APIError{Code: l.Error.Code, Message: l.Error.Message}
+		case res.StatusCode != http.StatusOK:
+			var e = newAPIError(res.StatusCode, res.Body)
+			e.RequestID = res.RequestID
+			r.Errors[l.CustomID] = e
+		default:
+			var v T
+			if err = json.Unmarshal(res.Body, &v); err != nil {
+				return fmt.Errorf("openai: reading batch file %s: %w", id, &jsonl.LineError{Line: jr.Line(), Err: err})
+			}
+			r.Responses[l.CustomID] = v
+		}
+	}
+}
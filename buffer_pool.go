@@ -0,0 +1,45 @@
+package openai
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool, so that an occasional large
+// response does not pin its memory indefinitely.
+const maxPooledBufferSize = 4 << 20
+
+// bufferPool holds the buffers used to read responses and stream events.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool. Return it with putBuffer once its contents are no longer used.
+func getBuffer() *bytes.Buffer {
+	var b = bufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+
+	return b
+}
+
+// putBuffer returns |b| to the pool.
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	bufferPool.Put(b)
+}
+
+// readAll is like io.ReadAll, but reads into a pooled buffer, so that the only allocation is the returned slice.
+func readAll(r io.Reader) ([]byte, error) {
+	var buf = getBuffer()
+	defer putBuffer(buf)
+
+	var _, err = buf.ReadFrom(r)
+
+	return append([]byte(nil), buf.Bytes()...), err
+}
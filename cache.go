@@ -0,0 +1,250 @@
+package openai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fabiustech/openai/routes"
+)
+
+// CacheStore stores responses keyed by a hash of their request (see WithCache). Implementations must be safe for
+// concurrent use.
+type CacheStore interface {
+	// Get returns the value of |key|, and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set sets the value of |key| to |value|, expiring after |ttl|. A |ttl| of 0 means the value does not expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// lruEntry is a value stored in an LRUCache.
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// LRUCache is an in-memory CacheStore which holds a bounded number of entries, evicting the least recently used.
+type LRUCache struct {
+	max int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRUCache returns an *LRUCache which holds up to |maxEntries| entries, or an unbounded number if |maxEntries| is 0.
+func NewLRUCache(maxEntries int) *LRUCache {
+	return &LRUCache{
+		max:     maxEntries,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get implements the CacheStore interface.
+func (l *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var el, ok = l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var e = el.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		l.order.Remove(el)
+		delete(l.entries, key)
+		return nil, false, nil
+	}
+	l.order.MoveToFront(el)
+
+	return e.value, true, nil
+}
+
+// Set implements the CacheStore interface.
+func (l *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var e = &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := l.entries[key]; ok {
+		el.Value = e
+		l.order.MoveToFront(el)
+		return nil
+	}
+
+	l.entries[key] = l.order.PushFront(e)
+	if l.max > 0 && l.order.Len() > l.max {
+		var oldest = l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+
+	return nil
+}
+
+// Len returns the number of entries in |l|, including any which have expired but not yet been evicted.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order.Len()
+}
+
+// RedisClient is the subset of a Redis client used by RedisStore. It can be implemented with any Redis library; e.g.
+// with github.com/redis/go-redis:
+//
+//	func (r *redisAdapter) Get(ctx context.Context, key string) (string, bool, error) {
+//		var v, err = r.client.Get(ctx, key).Result()
+//		if errors.Is(err, redis.Nil) {
+//			return "", false, nil
+//		}
+//		return v, err == nil, err
+//	}
+//
+//	func (r *redisAdapter) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+//		return r.client.Set(ctx, key, value, ttl).Err()
+//	}
+type RedisClient interface {
+	// Get returns the value of |key|, and whether it exists.
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set sets |key| to |value| with an expiry of |ttl| (0 for none).
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+// RedisStore is a CacheStore backed by Redis, which allows cached responses to be shared by many processes.
+type RedisStore struct {
+	// Client is the Redis client.
+	Client RedisClient
+	// Prefix is prepended to all keys.
+	// Defaults to "openai:".
+	Prefix *string
+}
+
+// key returns the Redis key of |key|.
+func (r *RedisStore) key(key string) string {
+	if r.Prefix != nil {
+		return *r.Prefix + key
+	}
+
+	return "openai:" + key
+}
+
+// Get implements the CacheStore interface.
+func (r *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var v, ok, err = r.Client.Get(ctx, r.key(key))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return []byte(v), true, nil
+}
+
+// Set implements the CacheStore interface.
+func (r *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.Client.Set(ctx, r.key(key), string(value), ttl)
+}
+
+// cacheRoutes are the routes whose responses are cached, mapped to whether they are only cached when the request has
+// a temperature of 0 (i.e. when the response is deterministic).
+var cacheRoutes = map[string]bool{
+	routes.ChatCompletions: true,
+	routes.Completions:     true,
+	routes.Embeddings:      false,
+}
+
+// responseCache returns cached responses to identical requests.
+type responseCache struct {
+	store CacheStore
+	ttl   time.Duration
+}
+
+// read returns the cached response to |req| to |path| if there is one, and otherwise sends it with |next| and caches
+// the response. Requests which cannot be cached are sent as is, as are requests for which the store fails.
+func (rc *responseCache) read(c *Client, path string, req *http.Request, cfg *requestConfig, next readFunc) (*response, error) {
+	var key, ok = c.cacheKey(path, req)
+	if !ok {
+		return next(req, cfg)
+	}
+
+	var ctx = req.Context()
+	if b, hit, err := rc.store.Get(ctx, key); err == nil && hit {
+		return &response{body: b, cached: true, codec: c.codec}, nil
+	}
+
+	var res, err = next(req, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !res.cached {
+		_ = rc.store.Set(ctx, key, res.body, rc.ttl)
+	}
+
+	return res, nil
+}
+
+// cacheKey returns the hash of |req| to |path|, and whether it may be cached. The hash covers the route, the
+// organization and project, and the JSON body, which is encoded deterministically (with struct fields in order, and
+// map keys sorted).
+func (c *Client) cacheKey(path string, req *http.Request) (string, bool) {
+	var deterministic, ok = cacheRoutes[path]
+	if !ok {
+		return "", false
+	}
+
+	var b, err = requestBody(req)
+	if err != nil {
+		return "", false
+	}
+
+	if deterministic {
+		var v struct {
+			Temperature *float64 `json:"temperature"`
+		}
+		if json.Unmarshal(b, &v) != nil || v.Temperature == nil || *v.Temperature != 0 {
+			return "", false
+		}
+	}
+
+	var h = sha256.New()
+	_, _ = io.WriteString(h, path+"\n")
+	if c.orgID != nil {
+		_, _ = io.WriteString(h, *c.orgID)
+	}
+	_, _ = io.WriteString(h, "\n")
+	if c.projectID != nil {
+		_, _ = io.WriteString(h, *c.projectID)
+	}
+	_, _ = io.WriteString(h, "\n")
+	_, _ = h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// requestBody returns the body of |req|, without consuming it.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, errors.New("openai: request body cannot be read")
+	}
+
+	var body, err = req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
@@ -0,0 +1,465 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fabiustech/openai/content"
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/reasoning"
+	"github.com/fabiustech/openai/roles"
+	"github.com/fabiustech/openai/routes"
+	"github.com/fabiustech/openai/tiers"
+)
+
+// ChatMessage is a message in a chat conversation.
+type ChatMessage struct {
+	// Role is the role of the author of the message.
+	Role roles.Role `json:"role"`
+	// Content is the content of the message. It may be empty for assistant messages which contain ToolCalls.
+	Content string `json:"content,omitempty"`
+	// Parts is the content of multi-part messages, such as user messages containing images. If set, it is sent
+	// instead of Content.
+	Parts []*ContentPart `json:"-"`
+	// Refusal is the refusal message generated by the model, if it refused to respond in the requested
+	// ResponseFormat. Only set on assistant messages.
+	Refusal string `json:"refusal,omitempty"`
+	// Name is an optional name for the participant, which allows the model to differentiate between participants of
+	// the same role.
+	Name string `json:"name,omitempty"`
+	// ToolCalls are the tool calls generated by the model. Only set on assistant messages. Unless parallel tool calls
+	// are disabled, the model may call several tools (or the same tool several times) in a single message; the result
+	// of each must be returned in its own tool message.
+	ToolCalls []*ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID is the ID of the tool call that this message responds to. Must be set on tool messages.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	// Audio is the audio response generated by the model, if audio output was requested. Only set on assistant
+	// messages.
+	Audio *MessageAudio `json:"audio,omitempty"`
+}
+
+// SystemMessage returns a *ChatMessage with the system role and |content|.
+func SystemMessage(content string) *ChatMessage {
+	return &ChatMessage{Role: roles.System, Content: content}
+}
+
+// UserMessage returns a *ChatMessage with the user role and |content|.
+func UserMessage(content string) *ChatMessage {
+	return &ChatMessage{Role: roles.User, Content: content}
+}
+
+// AssistantMessage returns a *ChatMessage with the assistant role and |content|.
+func AssistantMessage(content string) *ChatMessage {
+	return &ChatMessage{Role: roles.Assistant, Content: content}
+}
+
+// DeveloperMessage returns a *ChatMessage with the developer role and |content|. System messages sent to reasoning
+// models are converted to developer messages automatically.
+func DeveloperMessage(content string) *ChatMessage {
+	return &ChatMessage{Role: roles.Developer, Content: content}
+}
+
+// ToolMessage returns a *ChatMessage which returns |content|, the result of the tool call with |toolCallID|, to the
+// model.
+func ToolMessage(toolCallID, content string) *ChatMessage {
+	return &ChatMessage{Role: roles.Tool, Content: content, ToolCallID: toolCallID}
+}
+
+// ChatCompletionRequest contains all relevant fields for requests to the chat completions endpoint.
+type ChatCompletionRequest[T models.Chat | models.FineTunedModel] struct {
+	// Model specifies the ID of the model to use.
+	Model T `json:"model"`
+	// Messages is the conversation so far.
+	Messages []*ChatMessage `json:"messages"`
+	// MaxTokens specifies the maximum number of tokens to generate in the completion. The token count of the messages
+	// plus max_tokens cannot exceed the model's context length. It is not supported by reasoning models, for which it
+	// is sent as MaxCompletionTokens instead.
+	// Defaults to the model's maximum.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// MaxCompletionTokens specifies an upper bound for the number of tokens that can be generated for a completion,
+	// including visible output tokens and, for reasoning models, reasoning tokens. It supersedes MaxTokens; only one
+	// of them may be set.
+	// Defaults to the model's maximum.
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+	// ReasoningEffort constrains the effort which reasoning models spend reasoning. Reducing it can result in faster
+	// responses and fewer reasoning tokens (see Usage.ReasoningTokens). Only supported by reasoning models.
+	// Defaults to reasoning.EffortMedium.
+	ReasoningEffort *reasoning.Effort `json:"reasoning_effort,omitempty"`
+	// Temperature specifies what sampling temperature to use, between 0 and 2. Higher values make the output more
+	// random, while lower values make it more focused and deterministic. OpenAI generally recommends altering this or
+	// top_p but not both.
+	// Defaults to 1.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// TopP specifies an alternative to sampling with temperature, called nucleus sampling, where the model considers
+	// the results of the tokens with top_p probability mass. OpenAI generally recommends altering this or temperature
+	// but not both.
+	// Defaults to 1.
+	TopP *float64 `json:"top_p,omitempty"`
+	// N specifies how many chat completion choices to generate for each input message.
+	// Defaults to 1.
+	N int `json:"n,omitempty"`
+	// Seed makes a best effort to sample deterministically: repeated requests with the same seed and parameters
+	// should return the same result. Determinism is not guaranteed; compare the SystemFingerprint of the responses to
+	// detect changes in the backend which may affect it.
+	// Defaults to null.
+	Seed *int64 `json:"seed,omitempty"`
+	// Stop specifies up to 4 sequences where the API will stop generating further tokens. Requests with more than 4
+	// sequences are rejected before being sent.
+	Stop StopSequences `json:"stop,omitempty"`
+	// PresencePenalty can be a number between -2.0 and 2.0. Positive values penalize new tokens based on whether they
+	// appear in the text so far, increasing the model's likelihood to talk about new topics.
+	// Defaults to 0.
+	PresencePenalty float32 `json:"presence_penalty,omitempty"`
+	// FrequencyPenalty can be a number between -2.0 and 2.0. Positive values penalize new tokens based on their
+	// existing frequency in the text so far, decreasing the model's likelihood to repeat the same line verbatim.
+	// Defaults to 0.
+	FrequencyPenalty float32 `json:"frequency_penalty,omitempty"`
+	// LogitBias modifies the likelihood of specified tokens appearing in the completion. Maps token IDs to a bias value
+	// from -100 to 100.
+	// Defaults to null.
+	LogitBias map[string]int `json:"logit_bias,omitempty"`
+	// LogProbs specifies whether to return the log probabilities of the output tokens in the LogProbs of each choice.
+	// Defaults to false.
+	LogProbs bool `json:"logprobs,omitempty"`
+	// TopLogProbs specifies the number of most likely tokens to return at each token position, between 0 and 20, each
+	// with its log probability. LogProbs must be set if this is used.
+	// Defaults to 0.
+	TopLogProbs int `json:"top_logprobs,omitempty"`
+	// Tools is a list of tools the model may call. A max of 128 functions are supported.
+	Tools []*Tool `json:"tools,omitempty"`
+	// ToolChoice controls which (if any) tool is called by the model.
+	// Defaults to ToolChoiceNone if there are no Tools, and ToolChoiceAuto otherwise.
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+	// ParallelToolCalls specifies whether the model may call several tools at once, in which case a single message
+	// contains multiple ToolCalls. Only valid if Tools are set.
+	// Defaults to true.
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+	// ResponseFormat specifies the format of the content generated by the model. Use ResponseFormatFor to constrain it
+	// to the JSON encoding of a Go type (Structured Outputs).
+	// Defaults to ResponseFormatText.
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	// Modalities specifies the types of output the model generates: content.ModalityText, and for models which
+	// support it (e.g. models.GPT4oAudioPreview), content.ModalityAudio, which also requires Audio.
+	// Defaults to text only.
+	Modalities []content.Modality `json:"modalities,omitempty"`
+	// Audio specifies the voice and format of the audio output. It must be set if content.ModalityAudio is requested.
+	Audio *AudioOutput `json:"audio,omitempty"`
+	// Prediction is the predicted output of the model, e.g. the current version of a file whose contents are being
+	// regenerated with minor changes. Tokens of the response which match the prediction are returned much faster.
+	// Tokens of the prediction which are not part of the response are still billed as completion tokens (see
+	// Usage.CompletionTokensDetails).
+	Prediction *Prediction `json:"prediction,omitempty"`
+	// ServiceTier specifies the latency tier to use for processing the request. The tier actually used is returned as
+	// the ServiceTier of the response.
+	// Defaults to tiers.Auto.
+	ServiceTier tiers.Tier `json:"service_tier,omitempty"`
+	// Store specifies whether to store the output of the request, for use in model distillation or evals. Stored
+	// completions can be retrieved with RetrieveChatCompletion and ListChatCompletions.
+	// Defaults to false.
+	Store bool `json:"store,omitempty"`
+	// Metadata is a set of 16 key-value pairs that can be attached to a stored completion, to filter them when
+	// listing. Keys can be a maximum of 64 characters long and values can be a maximum of 512 characters long.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// StreamOptions specifies options for streamed responses. It may only be set when streaming (see
+	// CreateChatCompletionStream).
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	// User is a unique identifier representing your end-user, which can help OpenAI to monitor and detect abuse.
+	User string `json:"user,omitempty"`
+}
+
+// model returns the model of the request (see WithModelRoute).
+func (cr *ChatCompletionRequest[T]) model() string {
+	return fmt.Sprint(cr.Model)
+}
+
+// Prediction is the predicted output of a chat completion (see Predicted Outputs).
+type Prediction struct {
+	// Type is the type of the prediction. It is always "content".
+	Type string `json:"type"`
+	// Content is the content which should be matched when generating a response.
+	Content string `json:"content"`
+}
+
+// PredictedContent returns a *Prediction of |content|.
+func PredictedContent(content string) *Prediction {
+	return &Prediction{Type: "content", Content: content}
+}
+
+// validate returns an error if |cr| contains parameter values which would be rejected by the API.
+func (cr *ChatCompletionRequest[T]) validate() error {
+	if err := cr.validateParams(); err != nil {
+		return err
+	}
+
+	return cr.validateModel()
+}
+
+// validateParams returns an error if |cr| contains parameter values which would be rejected for any model.
+func (cr *ChatCompletionRequest[T]) validateParams() error {
+	if len(cr.Messages) == 0 {
+		return errors.New("openai: chat completion request has no messages")
+	}
+
+	if cr.MaxTokens > 0 && cr.MaxCompletionTokens > 0 {
+		return errors.New("openai: only one of max_tokens and max_completion_tokens may be set")
+	}
+	for i, m := range cr.Messages {
+		if m.Role == roles.Tool && m.ToolCallID == "" {
+			return fmt.Errorf("openai: tool message %d has no tool call ID", i)
+		}
+	}
+
+	for _, t := range cr.Tools {
+		if err := t.validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(cr.Tools) == 0 && (cr.ToolChoice != nil || cr.ParallelToolCalls != nil) {
+		return errors.New("openai: tool_choice and parallel_tool_calls are only allowed when tools are specified")
+	}
+
+	if name := cr.ToolChoice.Function(); name != "" && !hasFunction(cr.Tools, name) {
+		return fmt.Errorf("openai: tool choice names unknown function %q", name)
+	}
+
+	var audioOutput bool
+	for _, m := range cr.Modalities {
+		audioOutput = audioOutput || m == content.ModalityAudio
+	}
+	if audioOutput != (cr.Audio != nil) {
+		return errors.New("openai: audio output requires both the audio modality and audio parameters")
+	}
+
+	if cr.Prediction != nil && (cr.N > 1 || cr.LogProbs || len(cr.Tools) > 0 || audioOutput) {
+		return errors.New("openai: prediction is not supported with n > 1, logprobs, tools, or audio output")
+	}
+
+	if len(cr.Metadata) > 16 {
+		return fmt.Errorf("openai: metadata may contain at most 16 key-value pairs, got %d", len(cr.Metadata))
+	}
+	for k, v := range cr.Metadata {
+		if len(k) > 64 || len(v) > 512 {
+			return fmt.Errorf("openai: metadata key %q exceeds 64 characters or its value exceeds 512 characters", k)
+		}
+	}
+
+	if cr.TopLogProbs < 0 || cr.TopLogProbs > 20 {
+		return fmt.Errorf("openai: top_logprobs must be between 0 and 20, got %d", cr.TopLogProbs)
+	}
+	if cr.TopLogProbs > 0 && !cr.LogProbs {
+		return errors.New("openai: top_logprobs requires logprobs")
+	}
+
+	if err := cr.ResponseFormat.validate(cr.Messages); err != nil {
+		return err
+	}
+
+	return cr.Stop.validate()
+}
+
+// hasFunction reports whether |tools| contains a function named |name|.
+func hasFunction(tools []*Tool, name string) bool {
+	for _, t := range tools {
+		if t.Function != nil && t.Function.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChatCompletionChoice is one of the chat completions generated by the model.
+type ChatCompletionChoice struct {
+	// Index is the index of the choice in the list of choices.
+	Index int `json:"index"`
+	// Message is the message generated by the model.
+	Message *ChatMessage `json:"message"`
+	// FinishReason is the reason the model stopped generating tokens: "stop", "length", "tool_calls", or
+	// "content_filter".
+	FinishReason string `json:"finish_reason"`
+	// LogProbs contains the log probabilities of the generated tokens, if requested.
+	LogProbs *ChatLogProbs `json:"logprobs,omitempty"`
+}
+
+// ChatLogProbs contains the log probabilities of the tokens of a generated chat message.
+type ChatLogProbs struct {
+	// Content contains the log probabilities of the tokens of the content of the message.
+	Content []*TokenLogProb `json:"content"`
+	// Refusal contains the log probabilities of the tokens of the refusal of the message, if any.
+	Refusal []*TokenLogProb `json:"refusal,omitempty"`
+}
+
+// TopLogProb is one of the most likely tokens at a position, with its log probability.
+type TopLogProb struct {
+	// Token is the token.
+	Token string `json:"token"`
+	// LogProb is the log probability of the token. It is -9999.0 for tokens which are very unlikely.
+	LogProb float64 `json:"logprob"`
+	// Bytes is the UTF-8 encoding of the token. It is useful when characters are represented by multiple tokens, and
+	// their bytes must be combined to produce the text. It is null if the token has no byte representation.
+	Bytes []int `json:"bytes"`
+}
+
+// Probability returns the (linear) probability of the token, between 0 and 1.
+func (lp *TopLogProb) Probability() float64 {
+	return math.Exp(lp.LogProb)
+}
+
+// TokenLogProb is a generated token with its log probability, and the most likely tokens at its position.
+type TokenLogProb struct {
+	TopLogProb
+	// TopLogProbs are the most likely tokens at the position of the token, with their log probabilities. There are
+	// (up to) TopLogProbs of them.
+	TopLogProbs []*TopLogProb `json:"top_logprobs"`
+}
+
+// ChatCompletionResponse is the response from the chat completions endpoint.
+type ChatCompletionResponse[T models.Chat | models.FineTunedModel] struct {
+	ResponseMeta
+
+	ID      string                  `json:"id"`
+	Object  objects.Object          `json:"object"`
+	Created uint64                  `json:"created"`
+	Model   T                       `json:"model"`
+	Choices []*ChatCompletionChoice `json:"choices"`
+	Usage   *Usage                  `json:"usage"`
+	// SystemFingerprint represents the backend configuration that the model runs with. It changes when OpenAI makes
+	// changes to the backend which may impact determinism (see Seed).
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+	// ServiceTier is the service tier used to process the request.
+	ServiceTier tiers.Tier `json:"service_tier,omitempty"`
+	// Metadata is the metadata of stored completions.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateChatCompletion creates a model response for the given chat conversation.
+func (c *Client) CreateChatCompletion(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+	return createChatCompletion[models.Chat](ctx, c, cr, opts...)
+}
+
+// CreateFineTunedChatCompletion creates a model response for the given chat conversation, using a fine-tuned model.
+func (c *Client) CreateFineTunedChatCompletion(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error) {
+	return createChatCompletion[models.FineTunedModel](ctx, c, cr, opts...)
+}
+
+// validateModel returns an error if |cr| sets parameters which are not supported by its model.
+func (cr *ChatCompletionRequest[T]) validateModel() error {
+	if reasoningModel(fmt.Sprint(cr.Model)) {
+		return cr.validateReasoning()
+	}
+	if cr.ReasoningEffort != nil {
+		return fmt.Errorf("openai: reasoning_effort is only supported by reasoning models, not %v", cr.Model)
+	}
+
+	return nil
+}
+
+// validateReasoning returns an error if |cr| sets sampling parameters which are not supported by reasoning models.
+func (cr *ChatCompletionRequest[T]) validateReasoning() error {
+	var unsupported string
+	switch {
+	case cr.Temperature != nil && *cr.Temperature != 1:
+		unsupported = "temperature"
+	case cr.TopP != nil && *cr.TopP != 1:
+		unsupported = "top_p"
+	case cr.PresencePenalty != 0:
+		unsupported = "presence_penalty"
+	case cr.FrequencyPenalty != 0:
+		unsupported = "frequency_penalty"
+	case cr.LogProbs || cr.TopLogProbs != 0:
+		unsupported = "logprobs"
+	case len(cr.LogitBias) > 0:
+		unsupported = "logit_bias"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("openai: reasoning model %v does not support %s", cr.Model, unsupported)
+}
+
+// maxCompletionTokens returns the maximum number of tokens which |cr| may generate, or 0 for the model's maximum.
+func (cr *ChatCompletionRequest[T]) maxCompletionTokens() int {
+	if cr.MaxCompletionTokens > 0 {
+		return cr.MaxCompletionTokens
+	}
+
+	return cr.MaxTokens
+}
+
+// forModel returns |cr|, or a copy of it adapted to its model. Reasoning models reject max_tokens, so it is sent as
+// max_completion_tokens instead, and expect instructions in developer rather than system messages.
+func (cr *ChatCompletionRequest[T]) forModel() *ChatCompletionRequest[T] {
+	if !reasoningModel(fmt.Sprint(cr.Model)) {
+		return cr
+	}
+
+	var r = *cr
+	r.MaxCompletionTokens, r.MaxTokens = r.maxCompletionTokens(), 0
+
+	var copied bool
+	for i, m := range cr.Messages {
+		if m.Role != roles.System {
+			continue
+		}
+		if !copied {
+			r.Messages = append([]*ChatMessage(nil), cr.Messages...)
+			copied = true
+		}
+
+		var dm = *m
+		dm.Role = roles.Developer
+		r.Messages[i] = &dm
+	}
+
+	return &r
+}
+
+// reasoningModel reports whether |model| is a reasoning (o-series) model, including fine-tuned ones.
+func reasoningModel(model string) bool {
+	model = strings.TrimPrefix(model, "ft:")
+	for _, prefix := range []string{"o1", "o3", "o4"} {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") || strings.HasPrefix(model, prefix+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createChatCompletion creates a chat completion for |cr|.
+func createChatCompletion[T models.Chat | models.FineTunedModel](ctx context.Context, c *Client, cr *ChatCompletionRequest[T], opts ...RequestOption) (*ChatCompletionResponse[T], error) {
+	// In compatibility mode, the model need not be one of OpenAI's.
+	var validate = cr.validate
+	if c.compat {
+		validate = cr.validateParams
+	}
+	if err := validate(); err != nil {
+		return nil, err
+	}
+	if cr.StreamOptions != nil {
+		return nil, errors.New("openai: stream_options is only allowed when streaming")
+	}
+	if err := c.validateMessages(fmt.Sprint(cr.Model), cr.Messages, cr.maxCompletionTokens()); err != nil {
+		return nil, err
+	}
+	if !c.compat {
+		cr = cr.forModel()
+	}
+
+	var res, err = c.post(ctx, routes.ChatCompletions, cr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp = &ChatCompletionResponse[T]{}
+	if err = res.decode(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,240 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fabiustech/openai/audio"
+	"github.com/fabiustech/openai/content"
+	"github.com/fabiustech/openai/roles"
+)
+
+// ContentPart is a part of the content of a multi-part chat message, such as text, an image, or audio. Use TextPart,
+// ImagePart, ImageFilePart, ImageReaderPart, InputAudioPart, or InputAudioFilePart to construct a ContentPart.
+type ContentPart struct {
+	// Type is the type of the part.
+	Type content.Type `json:"type"`
+	// Text is the text of text parts.
+	Text string `json:"text,omitempty"`
+	// ImageURL is the image of image parts.
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+	// InputAudio is the audio of audio parts.
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+}
+
+// ImageURL is an image in a chat message.
+type ImageURL struct {
+	// URL is either the URL of the image, or the base64 encoded image data as a data URI (e.g.
+	// "data:image/jpeg;base64,...").
+	URL string `json:"url"`
+	// Detail specifies the fidelity with which the model processes the image.
+	// Defaults to content.DetailAuto.
+	Detail content.Detail `json:"detail,omitempty"`
+}
+
+// InputAudio is audio in a chat message.
+type InputAudio struct {
+	// Data is the base64 encoded audio data.
+	Data string `json:"data"`
+	// Format is the format of the audio data: audio.FormatWAV or audio.FormatMP3.
+	Format audio.Format `json:"format"`
+}
+
+// MessageAudio is the audio response generated by the model, when audio output is requested with the audio modality.
+type MessageAudio struct {
+	// ID is the ID of the audio response. To continue the conversation, the assistant message may be sent back with
+	// only the ID of its audio.
+	ID string `json:"id"`
+	// Data is the base64 encoded audio data, in the requested format.
+	Data string `json:"data,omitempty"`
+	// ExpiresAt is the Unix timestamp (in seconds) after which the audio response can no longer be referenced by its
+	// ID in multi-turn conversations.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// Transcript is the transcript of the audio.
+	Transcript string `json:"transcript,omitempty"`
+}
+
+// Reader returns an io.Reader which decodes the audio data.
+func (a *MessageAudio) Reader() io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, strings.NewReader(a.Data))
+}
+
+// Bytes returns the decoded audio data.
+func (a *MessageAudio) Bytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(a.Data)
+}
+
+// AudioOutput specifies the audio output generated by the model, when audio output is requested with the audio
+// modality.
+type AudioOutput struct {
+	// Voice is the voice the model uses to respond.
+	Voice audio.Voice `json:"voice"`
+	// Format is the format of the audio data: one of audio.FormatWAV, audio.FormatMP3, audio.FormatFLAC,
+	// audio.FormatOpus, or audio.FormatPCM16.
+	Format audio.Format `json:"format"`
+}
+
+// TextPart returns a *ContentPart containing |text|.
+func TextPart(text string) *ContentPart {
+	return &ContentPart{Type: content.TypeText, Text: text}
+}
+
+// ImagePart returns a *ContentPart containing the image at |url|, which may also be a data URI, to be processed with
+// |detail|.
+func ImagePart(url string, detail content.Detail) *ContentPart {
+	return &ContentPart{Type: content.TypeImageURL, ImageURL: &ImageURL{URL: url, Detail: detail}}
+}
+
+// ImageFilePart returns a *ContentPart containing the image file at |path|, embedded as a data URI, to be processed
+// with |detail|.
+func ImageFilePart(path string, detail content.Detail) (*ContentPart, error) {
+	var f, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ImageReaderPart(f, detail)
+}
+
+// imageTypes are the image formats supported in chat messages.
+var imageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// ImageReaderPart returns a *ContentPart containing the image read from |r|, embedded as a data URI, to be processed
+// with |detail|. The format of the image (PNG, JPEG, GIF, or WEBP) is detected from its contents.
+func ImageReaderPart(r io.Reader, detail content.Detail) (*ContentPart, error) {
+	var b, err = io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var mimeType = http.DetectContentType(b)
+	if !imageTypes[mimeType] {
+		return nil, errors.New("openai: unsupported image format " + mimeType)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len("data:;base64,") + len(mimeType) + base64.StdEncoding.EncodedLen(len(b)))
+	sb.WriteString("data:" + mimeType + ";base64,")
+	var enc = base64.NewEncoder(base64.StdEncoding, &sb)
+	_, _ = enc.Write(b)
+	_ = enc.Close()
+
+	return ImagePart(sb.String(), detail), nil
+}
+
+// InputAudioPart returns a *ContentPart containing the audio |data|, encoded in |format| (audio.FormatWAV or
+// audio.FormatMP3).
+func InputAudioPart(data []byte, format audio.Format) *ContentPart {
+	return &ContentPart{
+		Type:       content.TypeInputAudio,
+		InputAudio: &InputAudio{Data: base64.StdEncoding.EncodeToString(data), Format: format},
+	}
+}
+
+// InputAudioFilePart returns a *ContentPart containing the audio file at |path|. The format of the audio is determined
+// by the extension of the file, which must be .wav or .mp3.
+func InputAudioFilePart(path string) (*ContentPart, error) {
+	var format audio.Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav":
+		format = audio.FormatWAV
+	case ".mp3":
+		format = audio.FormatMP3
+	default:
+		return nil, errors.New("openai: unsupported audio format " + filepath.Ext(path))
+	}
+
+	var b, err = os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputAudioPart(b, format), nil
+}
+
+// UserMessageParts returns a *ChatMessage with the user role and multi-part content made of |parts|, e.g. to send
+// images or audio alongside text.
+func UserMessageParts(parts ...*ContentPart) *ChatMessage {
+	return &ChatMessage{Role: roles.User, Parts: parts}
+}
+
+// Text returns the text content of the message: its Content, or the concatenated text of its Parts.
+func (m *ChatMessage) Text() string {
+	if len(m.Parts) == 0 {
+		return m.Content
+	}
+
+	var sb strings.Builder
+	for _, p := range m.Parts {
+		sb.WriteString(p.Text)
+	}
+
+	return sb.String()
+}
+
+// textOnly reports whether the message has no content other than text.
+func (m *ChatMessage) textOnly() bool {
+	for _, p := range m.Parts {
+		if p.Type != content.TypeText {
+			return false
+		}
+	}
+
+	return true
+}
+
+// chatMessage is a ChatMessage without custom JSON encoding.
+type chatMessage ChatMessage
+
+// MarshalJSON implements the json.Marshaler interface. The content of the message is encoded as a string, or as an
+// array of parts if it has Parts.
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	if len(m.Parts) == 0 {
+		return json.Marshal((*chatMessage)(&m))
+	}
+
+	return json.Marshal(&struct {
+		*chatMessage
+		Content []*ContentPart `json:"content"`
+	}{
+		chatMessage: (*chatMessage)(&m),
+		Content:     m.Parts,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Content encoded as an array of parts is decoded into
+// Parts.
+func (m *ChatMessage) UnmarshalJSON(b []byte) error {
+	var v = struct {
+		*chatMessage
+		Content json.RawMessage `json:"content"`
+	}{
+		chatMessage: (*chatMessage)(m),
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	m.Content, m.Parts = "", nil
+	switch c := bytes.TrimSpace(v.Content); {
+	case len(c) == 0 || bytes.Equal(c, []byte("null")):
+		return nil
+	case c[0] == '[':
+		return json.Unmarshal(c, &m.Parts)
+	default:
+		return json.Unmarshal(c, &m.Content)
+	}
+}
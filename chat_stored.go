@@ -0,0 +1,108 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/routes"
+)
+
+// ChatCompletionsListRequest contains all relevant fields for requests to list stored chat completions. All fields
+// are optional.
+type ChatCompletionsListRequest struct {
+	// Model limits the results to chat completions generated by this model.
+	Model string
+	// Metadata limits the results to chat completions whose metadata contains all of these key-value pairs.
+	Metadata map[string]string
+	// Limit specifies the number of chat completions to return.
+	// Defaults to 20.
+	Limit int
+	// After is a cursor for pagination. Set to the LastID of the previous page to fetch the next page.
+	After string
+	// Order specifies the sort order of the results by creation time: "asc" or "desc".
+	// Defaults to "asc".
+	Order string
+}
+
+// values returns |lr| encoded as query parameters.
+func (lr *ChatCompletionsListRequest) values() url.Values {
+	var v = url.Values{}
+
+	if lr.Model != "" {
+		v.Set("model", lr.Model)
+	}
+
+	for k, val := range lr.Metadata {
+		v.Set(fmt.Sprintf("metadata[%s]", k), val)
+	}
+
+	if lr.Limit > 0 {
+		v.Set("limit", strconv.Itoa(lr.Limit))
+	}
+
+	if lr.After != "" {
+		v.Set("after", lr.After)
+	}
+
+	if lr.Order != "" {
+		v.Set("order", lr.Order)
+	}
+
+	return v
+}
+
+// RetrieveChatCompletion retrieves a chat completion which was stored (see ChatCompletionRequest.Store).
+func (c *Client) RetrieveChatCompletion(ctx context.Context, id string, opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+	var res, err = c.get(ctx, path.Join(routes.ChatCompletions, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp = &ChatCompletionResponse[models.Chat]{}
+	if err = res.decode(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListChatCompletions lists the stored chat completions (see ChatCompletionRequest.Store). Use
+// ChatCompletionsListRequest.After with the returned LastID to fetch subsequent pages while HasMore is true. |lr| may
+// be nil.
+func (c *Client) ListChatCompletions(ctx context.Context, lr *ChatCompletionsListRequest, opts ...RequestOption) (*List[*ChatCompletionResponse[models.Chat]], error) {
+	var route = routes.ChatCompletions
+	if lr != nil {
+		route = withQuery(route, lr.values())
+	}
+
+	var res, err = c.get(ctx, route, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*ChatCompletionResponse[models.Chat]]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// DeleteChatCompletion deletes a stored chat completion.
+func (c *Client) DeleteChatCompletion(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.ChatCompletions, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp = &DeletionResponse{}
+	if err = res.decode(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,297 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/roles"
+	"github.com/fabiustech/openai/routes"
+	"github.com/fabiustech/openai/tiers"
+	"github.com/fabiustech/openai/tools"
+)
+
+// ChatCompletionChunkChoice is the delta of one of the chat completions being streamed by the model.
+type ChatCompletionChunkChoice struct {
+	// Index is the index of the choice in the list of choices.
+	Index int `json:"index"`
+	// Delta is the fragment of the message generated since the previous chunk. Its Role is only set on the first
+	// chunk of each choice, and the arguments of its ToolCalls are fragments to be appended to those of the call with
+	// the same Index.
+	Delta *ChatMessage `json:"delta"`
+	// FinishReason is the reason the model stopped generating tokens. It is only set on the last chunk of each choice.
+	FinishReason string `json:"finish_reason"`
+	// LogProbs contains the log probabilities of the tokens of Delta, if requested.
+	LogProbs *ChatLogProbs `json:"logprobs,omitempty"`
+}
+
+// ChatCompletionChunk is a chunk of a chat completion response streamed by the chat completions endpoint.
+type ChatCompletionChunk[T models.Chat | models.FineTunedModel] struct {
+	ID      string                       `json:"id"`
+	Object  objects.Object               `json:"object"`
+	Created uint64                       `json:"created"`
+	Model   T                            `json:"model"`
+	Choices []*ChatCompletionChunkChoice `json:"choices"`
+	Usage   *Usage                       `json:"usage"`
+	// SystemFingerprint represents the backend configuration that the model runs with.
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+	// ServiceTier is the service tier used to process the request.
+	ServiceTier tiers.Tier `json:"service_tier,omitempty"`
+}
+
+// Text returns the content generated for the first choice since the previous chunk.
+func (c *ChatCompletionChunk[T]) Text() string {
+	for _, choice := range c.Choices {
+		if choice.Index == 0 && choice.Delta != nil {
+			return choice.Delta.Content
+		}
+	}
+
+	return ""
+}
+
+// StreamOptions specifies options for streamed responses.
+type StreamOptions struct {
+	// IncludeUsage requests an additional chunk before the end of the stream, with the token usage of the entire
+	// request and no choices. The usage is also available from Stream.Usage once received, and is reported to the
+	// Client's UsageTracker and MetricsRecorder, if any.
+	// Defaults to false.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// streamUsage returns the token usage of the chunk, which is only set on the final chunk of streams which include it.
+func (c *ChatCompletionChunk[T]) streamUsage() *Usage {
+	return c.Usage
+}
+
+// chatCompletionStreamRequest is a ChatCompletionRequest with streaming enabled.
+type chatCompletionStreamRequest[T models.Chat | models.FineTunedModel] struct {
+	*ChatCompletionRequest[T]
+	Stream bool `json:"stream"`
+}
+
+// CreateChatCompletionStream creates a model response for the given chat conversation, and streams it back in chunks
+// as it is generated. It is the caller's responsibility to close the returned *Stream. Use an Accumulator to build the
+// complete response from the chunks.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*Stream[*ChatCompletionChunk[models.Chat]], error) {
+	return createChatCompletionStream[models.Chat](ctx, c, cr, opts...)
+}
+
+// CreateFineTunedChatCompletionStream creates a model response for the given chat conversation, using a fine-tuned
+// model, and streams it back in chunks as it is generated. It is the caller's responsibility to close the returned
+// *Stream.
+func (c *Client) CreateFineTunedChatCompletionStream(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*Stream[*ChatCompletionChunk[models.FineTunedModel]], error) {
+	return createChatCompletionStream[models.FineTunedModel](ctx, c, cr, opts...)
+}
+
+// createChatCompletionStream creates a streamed chat completion for |cr|.
+func createChatCompletionStream[T models.Chat | models.FineTunedModel](ctx context.Context, c *Client, cr *ChatCompletionRequest[T], opts ...RequestOption) (*Stream[*ChatCompletionChunk[T]], error) {
+	// In compatibility mode, the model need not be one of OpenAI's.
+	var validate = cr.validate
+	if c.compat {
+		validate = cr.validateParams
+	}
+	if err := validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validateMessages(fmt.Sprint(cr.Model), cr.Messages, cr.maxCompletionTokens()); err != nil {
+		return nil, err
+	}
+	if !c.compat {
+		cr = cr.forModel()
+	}
+
+	var rc, onUsage, err = c.postUsageStream(ctx, routes.ChatCompletions, &chatCompletionStreamRequest[T]{
+		ChatCompletionRequest: cr,
+		Stream:                true,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var s = newStream[*ChatCompletionChunk[T]](rc)
+	s.codec = c.codec
+	s.onUsage = onUsage
+
+	return s, nil
+}
+
+// Accumulator incrementally builds a complete chat completion response from the chunks of a stream, so that callers
+// which display the chunks as they arrive still end up with the full response:
+//
+//	var acc openai.Accumulator[models.Chat]
+//	for {
+//		var chunk, err = stream.Recv()
+//		if errors.Is(err, io.EOF) {
+//			break
+//		}
+//		...
+//		acc.Add(chunk)
+//	}
+//	var resp = acc.Response()
+//
+// The zero value is ready to use. An Accumulator is not safe for concurrent use.
+type Accumulator[T models.Chat | models.FineTunedModel] struct {
+	resp    ChatCompletionResponse[T]
+	choices []*accumulatedChoice
+}
+
+// accumulatedChoice is a choice being built by an Accumulator.
+type accumulatedChoice struct {
+	choice  *ChatCompletionChoice
+	content strings.Builder
+	refusal strings.Builder
+	// arguments are the arguments of the tool calls of the choice, by index.
+	arguments []*strings.Builder
+	// completed is the number of tool calls of the choice which are complete.
+	completed int
+}
+
+// Add adds |chunk| to the response, and returns the tool calls whose arguments were completed by it. Tool call
+// arguments are streamed in fragments, and the calls of each choice are streamed one after the other; a call is
+// complete once the next one starts, or the choice finishes. This allows the tools to be executed while the rest of
+// the response is still being streamed:
+//
+//	for _, call := range acc.Add(chunk) {
+//		go execute(call)
+//	}
+func (a *Accumulator[T]) Add(chunk *ChatCompletionChunk[T]) []*ToolCall {
+	if chunk == nil {
+		return nil
+	}
+
+	if a.resp.ID == "" {
+		a.resp.ID = chunk.ID
+		a.resp.Object = objects.ChatCompletion
+		a.resp.Created = chunk.Created
+		a.resp.Model = chunk.Model
+	}
+	if chunk.SystemFingerprint != "" {
+		a.resp.SystemFingerprint = chunk.SystemFingerprint
+	}
+	if chunk.ServiceTier != tiers.Invalid {
+		a.resp.ServiceTier = chunk.ServiceTier
+	}
+	if chunk.Usage != nil {
+		a.resp.Usage = chunk.Usage
+	}
+
+	var completed []*ToolCall
+	for _, cc := range chunk.Choices {
+		var ac = a.choice(cc.Index)
+		if cc.LogProbs != nil {
+			if ac.choice.LogProbs == nil {
+				ac.choice.LogProbs = &ChatLogProbs{}
+			}
+			ac.choice.LogProbs.Content = append(ac.choice.LogProbs.Content, cc.LogProbs.Content...)
+			ac.choice.LogProbs.Refusal = append(ac.choice.LogProbs.Refusal, cc.LogProbs.Refusal...)
+		}
+		if cc.Delta != nil {
+			var msg = ac.choice.Message
+			if cc.Delta.Role != roles.Invalid {
+				msg.Role = cc.Delta.Role
+			}
+			ac.content.WriteString(cc.Delta.Content)
+			ac.refusal.WriteString(cc.Delta.Refusal)
+
+			for _, tc := range cc.Delta.ToolCalls {
+				ac.addToolCall(tc)
+			}
+		}
+
+		// All but the last call are complete, as are all calls once the choice has finished.
+		var n = len(ac.choice.Message.ToolCalls) - 1
+		if cc.FinishReason != "" {
+			ac.choice.FinishReason = cc.FinishReason
+			n++
+		}
+		for ; ac.completed < n; ac.completed++ {
+			completed = append(completed, ac.toolCall(ac.completed))
+		}
+	}
+
+	return completed
+}
+
+// choice returns the choice with index |i|, adding it (and any preceding choices) if necessary.
+func (a *Accumulator[T]) choice(i int) *accumulatedChoice {
+	for len(a.choices) <= i {
+		a.choices = append(a.choices, &accumulatedChoice{
+			choice: &ChatCompletionChoice{Index: len(a.choices), Message: &ChatMessage{}},
+		})
+	}
+
+	return a.choices[i]
+}
+
+// addToolCall adds the fragment |tc| to the tool call with the same index. Fragments without an index are treated as
+// complete tool calls.
+func (ac *accumulatedChoice) addToolCall(tc *ToolCall) {
+	var msg = ac.choice.Message
+
+	var i = len(msg.ToolCalls)
+	if tc.Index != nil {
+		i = *tc.Index
+	}
+	for len(msg.ToolCalls) <= i {
+		msg.ToolCalls = append(msg.ToolCalls, &ToolCall{Function: &FunctionCall{}})
+		ac.arguments = append(ac.arguments, &strings.Builder{})
+	}
+
+	var call = msg.ToolCalls[i]
+	if tc.ID != "" {
+		call.ID = tc.ID
+	}
+	if tc.Type != tools.TypeInvalid {
+		call.Type = tc.Type
+	}
+	if tc.Function != nil {
+		if tc.Function.Name != "" {
+			call.Function.Name = tc.Function.Name
+		}
+		ac.arguments[i].WriteString(tc.Function.Arguments)
+	}
+}
+
+// toolCall returns a copy of the tool call of the choice with index |i|, with the arguments received so far.
+func (ac *accumulatedChoice) toolCall(i int) *ToolCall {
+	var tc = ac.choice.Message.ToolCalls[i]
+
+	return &ToolCall{
+		ID:       tc.ID,
+		Type:     tc.Type,
+		Function: &FunctionCall{Name: tc.Function.Name, Arguments: ac.arguments[i].String()},
+	}
+}
+
+// Response returns the response built from the chunks added so far. It may be called at any point, e.g. to recover
+// the partial response of an interrupted stream.
+func (a *Accumulator[T]) Response() *ChatCompletionResponse[T] {
+	var resp = a.resp
+	resp.Choices = make([]*ChatCompletionChoice, len(a.choices))
+
+	for i, ac := range a.choices {
+		var choice = *ac.choice
+		var msg = *choice.Message
+		msg.Content = ac.content.String()
+		msg.Refusal = ac.refusal.String()
+
+		if len(msg.ToolCalls) > 0 {
+			msg.ToolCalls = make([]*ToolCall, len(ac.choice.Message.ToolCalls))
+			for j := range msg.ToolCalls {
+				msg.ToolCalls[j] = ac.toolCall(j)
+			}
+		}
+
+		choice.Message = &msg
+		if choice.LogProbs != nil {
+			var lp = *choice.LogProbs
+			choice.LogProbs = &lp
+		}
+		resp.Choices[i] = &choice
+	}
+
+	return &resp
+}
@@ -0,0 +1,158 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fabiustech/openai/jsonschema"
+	"github.com/fabiustech/openai/tools"
+)
+
+// Tool represents a tool which the model may call during a chat completion. Currently, only functions are supported.
+type Tool struct {
+	// Type is the type of tool.
+	Type tools.Type `json:"type"`
+	// Function describes the function which the model may call. Must be set if Type is tools.TypeFunction.
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// FunctionTool returns a *Tool which allows the model to call the function described by |f|.
+func FunctionTool(f *FunctionDefinition) *Tool {
+	return &Tool{Type: tools.TypeFunction, Function: f}
+}
+
+// NewFunctionTool returns a *Tool for the function named |name|, described by |description|, whose parameters are
+// described by the JSON Schema of T (see the jsonschema package). Decode the arguments of calls with
+// DecodeArguments.
+func NewFunctionTool[T any](name, description string) (*Tool, error) {
+	var schema, err = jsonschema.For[T](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return FunctionTool(&FunctionDefinition{Name: name, Description: description, Parameters: schema}), nil
+}
+
+// validate returns an error if |t| would be rejected by the API.
+func (t *Tool) validate() error {
+	if t.Type != tools.TypeFunction {
+		return fmt.Errorf("openai: unsupported chat tool type %q", t.Type)
+	}
+	if t.Function == nil || t.Function.Name == "" {
+		return errors.New("openai: function tool has no function name")
+	}
+
+	return nil
+}
+
+// ToolChoice controls which (if any) tool is called by the model. It is encoded as either a string ("none", "auto", or
+// "required") or an object naming a function; use ToolChoiceNone, ToolChoiceAuto, ToolChoiceRequired, or
+// ToolChoiceFunction (respectively) to construct a ToolChoice of the desired form.
+type ToolChoice struct {
+	mode     string
+	function string
+}
+
+var (
+	// ToolChoiceNone means the model will not call any tool and instead generates a message.
+	ToolChoiceNone = &ToolChoice{mode: "none"}
+	// ToolChoiceAuto means the model can pick between generating a message or calling one or more tools.
+	ToolChoiceAuto = &ToolChoice{mode: "auto"}
+	// ToolChoiceRequired means the model must call one or more tools.
+	ToolChoiceRequired = &ToolChoice{mode: "required"}
+)
+
+// ToolChoiceFunction returns a *ToolChoice which forces the model to call the function named |name|.
+func ToolChoiceFunction(name string) *ToolChoice {
+	return &ToolChoice{function: name}
+}
+
+// Function returns the name of the function the model is forced to call, or "" if |tc| is not a function choice.
+func (tc *ToolChoice) Function() string {
+	if tc == nil {
+		return ""
+	}
+
+	return tc.function
+}
+
+// toolChoiceFunction is the object form of a ToolChoice.
+type toolChoiceFunction struct {
+	Type     tools.Type `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tc *ToolChoice) MarshalJSON() ([]byte, error) {
+	if tc.function == "" {
+		return json.Marshal(tc.mode)
+	}
+
+	var v = toolChoiceFunction{Type: tools.TypeFunction}
+	v.Function.Name = tc.function
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (tc *ToolChoice) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*tc = ToolChoice{mode: s}
+		return nil
+	}
+
+	var v toolChoiceFunction
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("openai: invalid tool choice: %s", b)
+	}
+	*tc = ToolChoice{function: v.Function.Name}
+
+	return nil
+}
+
+// ToolCall is a call to a tool generated by the model.
+type ToolCall struct {
+	// Index is the index of the tool call within the message. It is only set on the fragments of tool calls in
+	// streamed chunks, which are reassembled by an Accumulator.
+	Index *int `json:"index,omitempty"`
+	// ID is the ID of the tool call. It must be set as the ToolCallID of the message which returns the result.
+	ID string `json:"id"`
+	// Type is the type of the tool.
+	Type tools.Type `json:"type"`
+	// Function is the function that the model called.
+	Function *FunctionCall `json:"function"`
+}
+
+// FunctionCall is a call to a function generated by the model.
+type FunctionCall struct {
+	// Name is the name of the function to call.
+	Name string `json:"name"`
+	// Arguments are the arguments to call the function with, as generated by the model in JSON format. Note that the
+	// model does not always generate valid JSON, and may hallucinate parameters not defined by the function's schema.
+	// Validate the arguments before calling the function.
+	Arguments string `json:"arguments"`
+}
+
+// DecodeArguments unmarshals the JSON encoded Arguments of |f| into |v|.
+func (f *FunctionCall) DecodeArguments(v any) error {
+	return json.Unmarshal([]byte(f.Arguments), v)
+}
+
+// DecodeArguments returns the JSON encoded arguments of |call| decoded as a T. Unlike FunctionCall.DecodeArguments,
+// it returns an error if the arguments contain fields which T does not, as the model may hallucinate parameters.
+func DecodeArguments[T any](call *FunctionCall) (T, error) {
+	var v T
+
+	var dec = json.NewDecoder(strings.NewReader(call.Arguments))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err != nil {
+		return v, fmt.Errorf("openai: invalid arguments for function %s: %w", call.Name, err)
+	}
+
+	return v, nil
+}
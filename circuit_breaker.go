@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without sending the request when the Client's circuit breaker is open (see
+// WithCircuitBreaker).
+var ErrCircuitOpen = errors.New("openai: circuit breaker is open")
+
+// CircuitBreakerConfig configures the Client's circuit breaker (see WithCircuitBreaker).
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures (5xx responses, timeouts, or connection errors) after
+	// which the circuit opens.
+	// Defaults to 5.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a single probe request is allowed through. If the probe
+	// succeeds, the circuit closes; otherwise, it opens for another Cooldown.
+	// Defaults to 30s.
+	Cooldown time.Duration
+}
+
+// circuitState is the state of a circuitBreaker.
+type circuitState int
+
+const (
+	// circuitClosed allows all requests.
+	circuitClosed circuitState = iota
+	// circuitOpen rejects all requests until the cooldown has elapsed.
+	circuitOpen
+	// circuitHalfOpen allows a single probe request.
+	circuitHalfOpen
+)
+
+// circuitBreaker fast-fails requests after repeated failures, protecting callers from waiting on a degraded API.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// newCircuitBreaker returns a closed *circuitBreaker configured by |cfg|.
+func newCircuitBreaker(cfg *CircuitBreakerConfig) *circuitBreaker {
+	var cb = &circuitBreaker{
+		threshold: defaultFailureThreshold,
+		cooldown:  defaultCooldown,
+	}
+
+	if cfg != nil && cfg.FailureThreshold > 0 {
+		cb.threshold = cfg.FailureThreshold
+	}
+	if cfg != nil && cfg.Cooldown > 0 {
+		cb.cooldown = cfg.Cooldown
+	}
+
+	return cb
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent. Once the cooldown has elapsed, the first caller is
+// allowed through as a probe.
+func (cb *circuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return ErrCircuitOpen
+		}
+		cb.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		// A probe is already in flight.
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record records the outcome of a request which was allowed through.
+func (cb *circuitBreaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		// The request says nothing about the health of the API, but a canceled probe must allow another.
+		if cb.state == circuitHalfOpen {
+			cb.state = circuitOpen
+		}
+		return
+	}
+
+	if !circuitFailure(err) {
+		cb.state = circuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == circuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+	}
+}
+
+// circuitFailure returns true if |err| indicates that the API is degraded: a 5xx response, a timeout, or a connection
+// error. Client errors (e.g. invalid requests) do not count against the API.
+func circuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return errors.Is(apiErr, ErrServer)
+	}
+
+	// Any other error is a timeout or was returned by the transport (e.g. a connection reset).
+	return true
+}
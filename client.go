@@ -3,13 +3,15 @@ package openai
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/fabiustech/openai/routes"
 )
@@ -22,8 +24,60 @@ const (
 
 // Client is OpenAI API client.
 type Client struct {
-	token string
-	orgID *string
+	token     string
+	orgID     *string
+	projectID *string
+	adminKey  string
+	tokens    *cachedTokenProvider
+	// keys spreads requests across several API keys (see WithAPIKeys).
+	keys *keyPool
+
+	// retry and retryBudget configure the retry of failed requests (see WithRetry and WithRetryPolicy).
+	retry       RetryPolicy
+	retryBudget time.Duration
+	limiter     *rateLimiter
+	inFlight    semaphore
+	breaker     *circuitBreaker
+
+	// azure is set if the Client targets the Azure OpenAI Service (see NewAzureClient).
+	azure *azureClient
+
+	metrics MetricsRecorder
+	logger  requestLogger
+	debug   *debugWriter
+	// pricing computes the cost of responses (see WithPricing).
+	pricing Pricing
+	usage   *UsageTracker
+	// contextWindows validates requests against the context window of their model (see WithContextValidation).
+	contextWindows ContextWindows
+	// semanticCache returns cached responses to similar prompts (see WithSemanticCache).
+	semanticCache *semanticCache
+	// cache returns cached responses to identical requests (see WithCache).
+	cache *responseCache
+
+	// header and headerFunc add custom headers to every request (see WithHeader and WithHeaderFunc).
+	header     http.Header
+	headerFunc func(ctx context.Context) http.Header
+
+	// baseURL replaces the default URL of the API (see WithBaseURL).
+	baseURL    *url.URL
+	baseURLErr error
+	// failover sends requests to the first healthy of several base URLs (see WithBaseURLs).
+	failover *failover
+	// routes send the requests for some models to other Clients (see WithModelRoute).
+	routes []*modelRoute
+	// codec encodes and decodes JSON bodies (see WithCodec). If nil, StdCodec is used.
+	codec Codec
+	// compression compresses request bodies and accepts compressed responses (see WithCompression).
+	compression *compression
+	// compat relaxes the requirements of the OpenAI API for self-hosted servers (see WithCompatibilityMode).
+	compat bool
+
+	// httpClient sends requests. If nil, http.DefaultClient is used.
+	httpClient *http.Client
+	// tlsConfig is the TLS configuration of the transport (see WithTLSConfig), kept so that it is also applied to an
+	// *http.Client set afterwards.
+	tlsConfig *tls.Config
 
 	// scheme and host are only used for testing.
 	// TODO: Figure out a better approach.
@@ -31,172 +85,537 @@ type Client struct {
 }
 
 // NewClient creates new OpenAI API client.
-func NewClient(token string) *Client {
-	return &Client{
+func NewClient(token string, opts ...Option) *Client {
+	var c = &Client{
 		token:  token,
 		scheme: scheme,
 		host:   host,
 	}
-}
 
-// NewClientWithOrg creates new OpenAI API client for specified Organization ID.
-func NewClientWithOrg(token, org string) *Client {
-	return &Client{
-		token:  token,
-		orgID:  &org,
-		scheme: scheme,
-		host:   host,
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
-func (c *Client) newRequest(ctx context.Context, method string, url string, body io.Reader) (*http.Request, error) {
-	var req, err = http.NewRequestWithContext(ctx, method, url, body)
+// NewClientWithOrg creates new OpenAI API client for specified Organization ID. It is equivalent to calling NewClient
+// with WithOrganization(org).
+func NewClientWithOrg(token, org string, opts ...Option) *Client {
+	return NewClient(token, append([]Option{WithOrganization(org)}, opts...)...)
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, route string, body io.Reader, rc *requestConfig) (*http.Request, error) {
+	if rc.err != nil {
+		return nil, rc.err
+	}
+	route = withQuery(route, rc.query)
+	rc.route = routePath(route)
+
+	var u = c.reqURL(route, rc.baseURL)
+	if c.azure != nil && rc.baseURL == nil {
+		var err error
+		if u, err = c.azure.url(route, rc.model); err != nil {
+			return nil, err
+		}
+	}
+
+	var req, err = http.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json; charset=utf-8")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	switch {
+	case c.adminKey != "" && routes.Admin(route):
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.adminKey))
+	case c.keys != nil:
+		// The key is chosen as each attempt is sent (see sendWithKey).
+		rc.pooledKey = true
+	case c.tokens != nil:
+		var token string
+		if token, err = c.tokens.get(ctx); err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	case c.azure != nil:
+		req.Header.Set("api-key", c.token)
+	case c.compat && c.token == "":
+		// Self-hosted servers rarely require an API key, and some reject an empty bearer token.
+	default:
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
 
 	if c.orgID != nil {
 		req.Header.Set("OpenAI-Organization", *c.orgID)
 	}
 
+	if c.projectID != nil {
+		req.Header.Set("OpenAI-Project", *c.projectID)
+	}
+
+	if v, ok := routes.Beta(route); ok {
+		req.Header.Set("OpenAI-Beta", v)
+	}
+
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+
+	if c.headerFunc != nil {
+		for k, v := range c.headerFunc(ctx) {
+			req.Header[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	for k, v := range rc.header {
+		req.Header[k] = v
+	}
+
 	return req, nil
 }
 
-func (c *Client) post(ctx context.Context, path string, payload any) ([]byte, error) {
-	var b, err = json.Marshal(payload)
+func (c *Client) post(ctx context.Context, path string, payload any, opts ...RequestOption) (*response, error) {
+	var rc = c.newRequestConfig(opts)
+	if t := c.routed(payload, rc); t != c {
+		// Requests are routed at most once, so that routes between Clients cannot loop.
+		return t.postUnrouted(ctx, path, payload, t.newRequestConfig(opts))
+	}
+
+	return c.postUnrouted(ctx, path, payload, rc)
+}
+
+// postUnrouted is like post, but sends the request with |c| regardless of its routes.
+func (c *Client) postUnrouted(ctx context.Context, path string, payload any, rc *requestConfig) (*response, error) {
+	var req, err = c.newJSONRequest(ctx, path, payload, rc)
 	if err != nil {
 		return nil, err
 	}
 
-	var req *http.Request
-	req, err = c.newRequest(ctx, "POST", c.reqURL(path), bytes.NewBuffer(b))
+	return c.readCached(path, req, rc)
+}
+
+// readFunc sends a request and reads its response (see Client.read).
+type readFunc func(req *http.Request, rc *requestConfig) (*response, error)
+
+// readCached is like read, but first looks for a cached response to |req| to |path| (see WithCache and
+// WithSemanticCache). Exact matches are looked for before similar prompts.
+func (c *Client) readCached(path string, req *http.Request, rc *requestConfig) (*response, error) {
+	var read readFunc = c.read
+	if c.semanticCache != nil {
+		read = func(req *http.Request, rc *requestConfig) (*response, error) {
+			return c.semanticCache.read(c, path, req, rc, c.read)
+		}
+	}
+
+	if c.cache != nil {
+		return c.cache.read(c, path, req, rc, read)
+	}
+
+	return read(req, rc)
+}
+
+// postStream sends a JSON encoded POST request to |path| and returns the unread response body. It is the caller's
+// responsibility to close the returned io.ReadCloser.
+func (c *Client) postStream(ctx context.Context, path string, payload any, opts ...RequestOption) (io.ReadCloser, error) {
+	var body, _, err = c.postUsageStream(ctx, path, payload, opts...)
+
+	return body, err
+}
+
+// postUsageStream is like postStream, but also returns a function to call with the token usage reported by the stream,
+// which records it, along with the model which was sent, with the Client which sent the request. The metrics of the
+// request are reported once the usage is received or the body is closed.
+func (c *Client) postUsageStream(ctx context.Context, path string, payload any, opts ...RequestOption) (io.ReadCloser, func(u *Usage), error) {
+	var rc = c.newRequestConfig(opts)
+	if t := c.routed(payload, rc); t != c {
+		return t.postUsageStreamUnrouted(ctx, path, payload, t.newRequestConfig(opts))
+	}
+
+	return c.postUsageStreamUnrouted(ctx, path, payload, rc)
+}
+
+// postUsageStreamUnrouted is like postUsageStream, but sends the request with |c| regardless of its routes.
+func (c *Client) postUsageStreamUnrouted(ctx context.Context, path string, payload any, rc *requestConfig) (io.ReadCloser, func(u *Usage), error) {
+	var req, err = c.newJSONRequest(ctx, path, payload, rc)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
+	var start = time.Now()
 	var resp *http.Response
-	resp, err = http.DefaultClient.Do(req)
+	resp, err = c.do(req, rc)
+	if err != nil {
+		c.recordMetrics(req, rc, start, nil, nil, nil, err)
+		return nil, nil, err
+	}
+	if c.metrics == nil && c.logger == nil && c.usage == nil {
+		return resp.Body, nil, nil
+	}
+
+	var sm = &streamMetrics{c: c, req: req, rc: rc, m: newRequestMetrics(req, rc, start, resp, nil)}
+	var body = &releaseBody{ReadCloser: resp.Body, release: func() { sm.report(nil) }}
+
+	return body, sm.report, nil
+}
+
+// newJSONRequest returns a POST request to |path| with the JSON encoded |payload| as its body.
+func (c *Client) newJSONRequest(ctx context.Context, path string, payload any, rc *requestConfig) (*http.Request, error) {
+	var b, err = codecOrDefault(c.codec).Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if err = interpretResponse(resp); err != nil {
+	if rc.modelOverride != "" {
+		if b, err = replaceJSONModel(b, rc.modelOverride); err != nil {
+			return nil, err
+		}
+		rc.model = rc.modelOverride
+	}
+	if (c.azure != nil || c.metrics != nil || c.logger != nil || c.pricing != nil || c.usage != nil) && rc.model == "" {
+		rc.model = jsonModel(b)
+	}
+	if c.usage != nil {
+		rc.user = jsonUser(b)
+	}
+
+	var req *http.Request
+	req, err = c.newRequest(ctx, "POST", path, bytes.NewBuffer(b), rc)
+	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	return io.ReadAll(resp.Body)
+	return req, nil
 }
 
-func (c *Client) postFile(ctx context.Context, fr *FileRequest) ([]byte, error) {
+// postForm sends a multipart/form-data POST request to |path|. The body of the form is populated by |write|. The
+// whole form, including any files, is encoded in memory before it is sent, so that the request can be retried (and
+// compressed) without reading the files again.
+func (c *Client) postForm(ctx context.Context, path string, write func(w *multipart.Writer) error, opts ...RequestOption) (*response, error) {
 	var b bytes.Buffer
 	var w = multipart.NewWriter(&b)
 
-	if err := w.WriteField("purposes", fr.Purpose); err != nil {
+	if err := write(w); err != nil {
 		return nil, err
 	}
 
-	var fw, err = w.CreateFormFile("file", fr.File.Name())
-	if err != nil {
+	if err := w.Close(); err != nil {
 		return nil, err
 	}
-
-	if _, err = io.Copy(fw, fr.File); err != nil {
+	// Reading a large file into the form may take a while.
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	if err = w.Close(); err != nil {
+	var rc = c.newRequestConfig(opts)
+	var req, err = c.newRequest(ctx, "POST", path, &b, rc)
+	if err != nil {
 		return nil, err
 	}
 
-	var req *http.Request
-	req, err = c.newRequest(ctx, "POST", c.reqURL(routes.Files), &b)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return c.read(req, rc)
+}
+
+// writeFormFile copies the contents of |r| into a new form file named |filename| under the form field |field|.
+func writeFormFile(w *multipart.Writer, field, filename string, r io.Reader) error {
+	var fw, err = w.CreateFormFile(field, filename)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fw, r)
+
+	return err
+}
+
+func (c *Client) get(ctx context.Context, path string, opts ...RequestOption) (*response, error) {
+	var rc = c.newRequestConfig(opts)
+	var req, err = c.newRequest(ctx, "GET", path, nil, rc)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", w.FormDataContentType())
+	return c.read(req, rc)
+}
 
-	var resp *http.Response
-	resp, err = http.DefaultClient.Do(req)
+// getStream sends a GET request to |path| and returns the unread response body. It is the caller's responsibility to
+// close the returned io.ReadCloser.
+func (c *Client) getStream(ctx context.Context, path string, opts ...RequestOption) (io.ReadCloser, error) {
+	var rc = c.newRequestConfig(opts)
+	var req, err = c.newRequest(ctx, "GET", path, nil, rc)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if err = interpretResponse(resp); err != nil {
+	var start = time.Now()
+	var resp *http.Response
+	resp, err = c.do(req, rc)
+	c.recordMetrics(req, rc, start, resp, nil, nil, err)
+	if err != nil {
 		return nil, err
 	}
 
-	return io.ReadAll(resp.Body)
+	return resp.Body, nil
 }
 
-func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
-	var req, err = c.newRequest(ctx, "GET", c.reqURL(path), nil)
+func (c *Client) delete(ctx context.Context, path string, opts ...RequestOption) (*response, error) {
+	var rc = c.newRequestConfig(opts)
+	var req, err = c.newRequest(ctx, "DELETE", path, nil, rc)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp *http.Response
-	resp, err = http.DefaultClient.Do(req)
+	return c.read(req, rc)
+}
+
+// read sends |req| and reads the entire response body.
+func (c *Client) read(req *http.Request, rc *requestConfig) (*response, error) {
+	var start = time.Now()
+	var resp, err = c.do(req, rc)
 	if err != nil {
+		c.recordMetrics(req, rc, start, nil, nil, nil, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err = interpretResponse(resp); err != nil {
+	var b []byte
+	b, err = readAll(resp.Body)
+
+	// The cost is computed once, for both the response and the metrics.
+	var cost *float64
+	if err == nil && c.pricing != nil {
+		cost = c.pricing.responseCost(b, rc.model)
+	}
+	c.recordMetrics(req, rc, start, resp, b, cost, err)
+	if err != nil {
 		return nil, err
 	}
 
-	return io.ReadAll(resp.Body)
+	var r = newResponse(resp, b)
+	r.codec = c.codec
+	r.cost = cost
+
+	return r, nil
 }
 
-func (c *Client) delete(ctx context.Context, path string) ([]byte, error) {
-	var req, err = c.newRequest(ctx, "DELETE", c.reqURL(path), nil)
-	if err != nil {
+// do sends |req| and returns the response if it was successful. Otherwise, the response body is closed and the error
+// returned by the API is returned. Failed requests are retried according to the RetryPolicy of |rc|, if any.
+func (c *Client) do(req *http.Request, rc *requestConfig) (*http.Response, error) {
+	if rc.timeout > 0 {
+		var ctx, cancel = context.WithTimeout(req.Context(), rc.timeout)
+		req = req.WithContext(ctx)
+
+		var resp, err = c.sendWithRetries(req, rc)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		// The deadline must outlive this call, as the caller may still be reading a streamed response.
+		resp.Body = &releaseBody{ReadCloser: resp.Body, release: cancel}
+
+		return resp, nil
+	}
+
+	return c.sendWithRetries(req, rc)
+}
+
+// sendWithRetries sends |req|, retrying it according to the RetryPolicy of |rc|.
+func (c *Client) sendWithRetries(req *http.Request, rc *requestConfig) (*http.Response, error) {
+	var start = time.Now()
+
+	var send = c.send
+	if rc.pooledKey {
+		send = c.sendWithKey
+	}
+	if rc.failover {
+		var next = send
+		send = func(req *http.Request) (*http.Response, error) {
+			return c.failover.send(req, rc.route, next)
+		}
+	}
+	if rc.progress != nil {
+		send = withProgress(send, rc.progress)
+	}
+
+	for attempt := 1; ; attempt++ {
+		var resp, err = send(req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if rc.retry == nil {
+			return nil, err
+		}
+
+		var delay, ok = rc.retry.ShouldRetry(resp, err, attempt)
+		if !ok || !rewind(req) {
+			return nil, err
+		}
+
+		if rc.retryBudget > 0 && time.Since(start)+delay > rc.retryBudget {
+			return nil, err
+		}
+
+		if err = sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send sends |req| once. If the API responds with an error, the response body is closed and the response is returned
+// along with the error.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.roundTrip(req)
+	}
+
+	if err := c.breaker.allow(); err != nil {
 		return nil, err
 	}
 
+	var resp, err = c.roundTrip(req)
+	c.breaker.record(err)
+
+	return resp, err
+}
+
+// roundTrip sends |req| once, subject to the Client's rate and concurrency limits.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.inFlight != nil {
+		if err := c.inFlight.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.debug != nil {
+		c.debug.dumpRequest(req)
+	}
+
 	var resp *http.Response
-	resp, err = http.DefaultClient.Do(req)
+	var err error
+	if c.compression != nil {
+		resp, err = c.compression.do(c.client(), req)
+	} else {
+		resp, err = c.client().Do(req)
+	}
 	if err != nil {
+		if c.inFlight != nil {
+			c.inFlight.release()
+		}
 		return nil, err
 	}
-	defer resp.Body.Close()
+
+	if c.debug != nil {
+		c.debug.dumpResponse(resp)
+	}
+
+	if c.inFlight != nil {
+		resp.Body = &releaseBody{ReadCloser: resp.Body, release: c.inFlight.release}
+	}
 
 	if err = interpretResponse(resp); err != nil {
-		return nil, err
+		resp.Body.Close()
+		return resp, err
 	}
 
-	return io.ReadAll(resp.Body)
+	return resp, nil
 }
 
-func (c *Client) reqURL(route string) string {
+// client returns the *http.Client used to send requests.
+func (c *Client) client() *http.Client {
+	if c.httpClient == nil {
+		return http.DefaultClient
+	}
+
+	return c.httpClient
+}
+
+// transport returns the *http.Transport of the Client's *http.Client, replacing a shared or custom transport with a
+// clone of http.DefaultTransport so that it can be configured without affecting other clients.
+func (c *Client) transport() *http.Transport {
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok && t != http.DefaultTransport {
+		return t
+	}
+
+	var t = http.DefaultTransport.(*http.Transport).Clone()
+	c.httpClient.Transport = t
+
+	return t
+}
+
+// reqURL returns the full URL for |route|, relative to |base| if it is non-nil. |route| may include a query string (see
+// withQuery).
+func (c *Client) reqURL(route string, base *url.URL) string {
+	var p, q, _ = strings.Cut(route, "?")
+	if base != nil {
+		var u = *base
+		u.Path = path.Join(u.Path, p)
+		u.RawQuery = q
+
+		return u.String()
+	}
+
 	var u = &url.URL{
-		Scheme: c.scheme,
-		Host:   c.host,
-		Path:   path.Join(basePath, route),
+		Scheme:   c.scheme,
+		Host:     c.host,
+		Path:     path.Join(basePath, p),
+		RawQuery: q,
 	}
 
 	return u.String()
 }
 
+// withQuery appends the encoded |q| to |route|. If |route| already has a query string, |q| is merged into it,
+// replacing any parameters of the same name.
+func withQuery(route string, q url.Values) string {
+	if len(q) == 0 {
+		return route
+	}
+
+	var p, raw, ok = strings.Cut(route, "?")
+	if !ok {
+		return route + "?" + q.Encode()
+	}
+
+	var v, _ = url.ParseQuery(raw)
+	for k, vals := range q {
+		v[k] = vals
+	}
+
+	return p + "?" + v.Encode()
+}
+
 func interpretResponse(resp *http.Response) error {
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
 		var b, err = io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("error, status code: %d", resp.StatusCode)
+			return &APIError{StatusCode: resp.StatusCode, Message: err.Error(), RequestID: resp.Header.Get(requestIDHeader)}
 		}
-		var er = &errorResponse{}
-		if err = json.Unmarshal(b, er); err != nil || er.Error == nil {
-			return fmt.Errorf("error, status code: %d, msg: %s", resp.StatusCode, string(b))
+
+		var apiErr = newAPIError(resp.StatusCode, b)
+		apiErr.RequestID = resp.Header.Get(requestIDHeader)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return &RateLimitError{
+				APIError:   apiErr,
+				RetryAfter: parseRetryAfter(resp.Header),
+				Limits:     parseRateLimits(resp.Header),
+			}
 		}
 
-		return er.Error
+		return apiErr
 	}
 
 	return nil
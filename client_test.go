@@ -2,24 +2,49 @@ package openai
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/fabiustech/openai/audio"
+	"github.com/fabiustech/openai/auditlogs"
+	"github.com/fabiustech/openai/content"
+	"github.com/fabiustech/openai/embeddings"
+	"github.com/fabiustech/openai/files"
 	"github.com/fabiustech/openai/images"
+	"github.com/fabiustech/openai/jsonl"
+	"github.com/fabiustech/openai/jsonschema"
 	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/moderation"
 	"github.com/fabiustech/openai/objects"
 	"github.com/fabiustech/openai/params"
+	"github.com/fabiustech/openai/reasoning"
+	"github.com/fabiustech/openai/roles"
+	"github.com/fabiustech/openai/routes"
+	"github.com/fabiustech/openai/tiers"
+	"github.com/fabiustech/openai/tools"
 )
 
 /*
@@ -30,7 +55,10 @@ TODO: Cover all endpoints.
 */
 
 const (
-	testToken = "this-is-my-secure-token-do-not-steal!!"
+	testToken    = "this-is-my-secure-token-do-not-steal!!"
+	testAdminKey = "this-is-my-secure-admin-key-do-not-steal!!"
+
+	testRequestID = "req_abc123"
 )
 
 func TestAPI(t *testing.T) {
@@ -98,7 +126,7 @@ func TestCompletions(t *testing.T) {
 	var client, _ = newTestClient(ts.URL)
 
 	var _, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{
-		Prompt:    "Lorem ipsum",
+		Prompt:    TextPrompt("Lorem ipsum"),
 		Model:     models.TextDavinci003,
 		MaxTokens: 5,
 	})
@@ -107,6 +135,58 @@ func TestCompletions(t *testing.T) {
 	}
 }
 
+func TestPromptMarshalling(t *testing.T) {
+	var cases = []struct {
+		prompt *Prompt
+		want   string
+	}{
+		{TextPrompt("Lorem ipsum"), `"Lorem ipsum"`},
+		{TextPrompts("Lorem", "ipsum"), `["Lorem","ipsum"]`},
+		{TokenPrompt(1, 2, 3), `[1,2,3]`},
+		{TokenPrompts([]int{1, 2}, []int{3}), `[[1,2],[3]]`},
+	}
+
+	for _, c := range cases {
+		var b, err = json.Marshal(c.prompt)
+		if err != nil {
+			t.Fatalf("error marshalling prompt: %v", err)
+		}
+		if string(b) != c.want {
+			t.Fatalf("expected prompt to marshal to %s, got %s", c.want, b)
+		}
+
+		var p = &Prompt{}
+		if err = json.Unmarshal(b, p); err != nil {
+			t.Fatalf("error unmarshalling prompt: %v", err)
+		}
+		if b, _ = json.Marshal(p); string(b) != c.want {
+			t.Fatalf("expected prompt to round trip to %s, got %s", c.want, b)
+		}
+	}
+}
+
+func TestStopSequences(t *testing.T) {
+	var b, _ = json.Marshal(Stop("\n"))
+	if string(b) != `"\n"` {
+		t.Fatalf("expected a single stop sequence to marshal as a string, got %s", b)
+	}
+
+	b, _ = json.Marshal(Stop("\n", "."))
+	if string(b) != `["\n","."]` {
+		t.Fatalf("expected multiple stop sequences to marshal as an array, got %s", b)
+	}
+
+	var client = NewClient(testToken)
+	var _, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{
+		Prompt: TextPrompt("Lorem ipsum"),
+		Model:  models.TextDavinci003,
+		Stop:   Stop("a", "b", "c", "d", "e"),
+	})
+	if err == nil {
+		t.Fatalf("expected CreateCompletion to reject more than 4 stop sequences")
+	}
+}
+
 // TestEdits Tests the edits endpoint of the API using the mocked server.
 func TestEdits(t *testing.T) {
 	var ts = OpenAITestServer()
@@ -240,14 +320,14 @@ func handleCompletionEndpoint(w http.ResponseWriter, r *http.Request) {
 		// generate a random string of length completionReq.Length
 		completionStr := strings.Repeat("a", completionReq.MaxTokens)
 		if completionReq.Echo {
-			completionStr = completionReq.Prompt + completionStr
+			completionStr = strings.Join(completionReq.Prompt.Text(), "") + completionStr
 		}
 		res.Choices = append(res.Choices, &CompletionChoice{
 			Text:  completionStr,
 			Index: i,
 		})
 	}
-	inputTokens := numTokens(completionReq.Prompt) * completionReq.N
+	inputTokens := numTokens(strings.Join(completionReq.Prompt.Text(), "")) * completionReq.N
 	completionTokens := completionReq.MaxTokens * completionReq.N
 	res.Usage = &Usage{
 		PromptTokens:     inputTokens,
@@ -299,6 +379,165 @@ func handleImageEndpoint(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+// handleTranscriptionEndpoint Handles the audio transcriptions endpoint by the test server.
+func handleTranscriptionEndpoint(w http.ResponseWriter, r *http.Request) {
+	// Transcriptions only accepts POST requests.
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var f, _, err = r.FormFile("file")
+	if err != nil {
+		http.Error(w, "could not read file", http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+
+	if r.FormValue("model") != models.Whisper1.String() {
+		http.Error(w, "invalid model", http.StatusBadRequest)
+		return
+	}
+
+	var b []byte
+	if b, err = io.ReadAll(f); err != nil {
+		http.Error(w, "could not read file", http.StatusInternalServerError)
+		return
+	}
+
+	// Echo the "audio" back as the transcription.
+	b, _ = json.Marshal(&TranscriptionResponse{Text: string(b)})
+	_, _ = w.Write(b)
+}
+
+// handleSpeechEndpoint Handles the audio speech endpoint by the test server.
+func handleSpeechEndpoint(w http.ResponseWriter, r *http.Request) {
+	// Speech only accepts POST requests.
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var sr = &SpeechRequest{}
+	if err := json.NewDecoder(r.Body).Decode(sr); err != nil {
+		http.Error(w, "could not read request", http.StatusInternalServerError)
+		return
+	}
+
+	// Echo the input back as the "audio".
+	w.Header().Set("Content-Type", "audio/mpeg")
+	_, _ = io.WriteString(w, sr.Input)
+}
+
+// handleFilesEndpoint Handles the files endpoint by the test server.
+func handleFilesEndpoint(w http.ResponseWriter, r *http.Request) {
+	var b []byte
+	switch r.Method {
+	case "GET":
+		b, _ = json.Marshal(&List[*File]{Object: objects.List})
+	case "POST":
+		var _, fh, err = r.FormFile("file")
+		if err != nil {
+			http.Error(w, "could not read file", http.StatusBadRequest)
+			return
+		}
+
+		var f = &File{
+			ID:        "file-abc123",
+			Object:    objects.File,
+			Bytes:     int(fh.Size),
+			CreatedAt: int(time.Now().Unix()),
+			Filename:  fh.Filename,
+		}
+		if err = f.Purpose.UnmarshalText([]byte(r.FormValue("purpose"))); err != nil {
+			http.Error(w, "invalid purpose", http.StatusBadRequest)
+			return
+		}
+		b, _ = json.Marshal(f)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	_, _ = w.Write(b)
+}
+
+// handleFineTuningEventsEndpoint Handles the fine-tuning events endpoint by the test server.
+func handleFineTuningEventsEndpoint(w http.ResponseWriter, r *http.Request) {
+	var events = []*FineTuningJobEvent{
+		{ID: "ftevent-1", Object: objects.FineTuningJobEvent, Level: "info", Message: "Job started"},
+		{ID: "ftevent-2", Object: objects.FineTuningJobEvent, Level: "info", Message: "Job succeeded"},
+	}
+
+	if r.URL.Query().Get("stream") != "true" {
+		var b, _ = json.Marshal(&List[*FineTuningJobEvent]{Object: objects.List, Data: events})
+		_, _ = w.Write(b)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	_, _ = io.WriteString(w, ": heartbeat\n\n")
+	for _, e := range events {
+		var b, _ = json.Marshal(e)
+		fmt.Fprintf(w, "data: %s\n\n", b)
+	}
+	_, _ = io.WriteString(w, "data: [DONE]\n\n")
+}
+
+// handleAssistantsEndpoint Handles the assistants endpoint by the test server.
+func handleAssistantsEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("OpenAI-Beta") != "assistants=v2" {
+		http.Error(w, "missing OpenAI-Beta header", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var ar = &AssistantRequest{}
+	if err := json.NewDecoder(r.Body).Decode(ar); err != nil {
+		http.Error(w, "could not read request", http.StatusInternalServerError)
+		return
+	}
+
+	var b, _ = json.Marshal(&Assistant{
+		ID:           "asst_abc123",
+		Object:       objects.Assistant,
+		CreatedAt:    uint64(time.Now().Unix()),
+		Name:         ar.Name,
+		Model:        ar.Model,
+		Instructions: ar.Instructions,
+		Tools:        ar.Tools,
+	})
+	_, _ = w.Write(b)
+}
+
+// handleUploadPartsEndpoint Handles the upload parts endpoint by the test server.
+func handleUploadPartsEndpoint(w http.ResponseWriter, r *http.Request) {
+	var f, _, err = r.FormFile("data")
+	if err != nil {
+		http.Error(w, "could not read data", http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+
+	var b []byte
+	if b, err = io.ReadAll(f); err != nil {
+		http.Error(w, "could not read data", http.StatusInternalServerError)
+		return
+	}
+
+	// Use the contents of the part as its ID so that tests can verify how the file was split.
+	b, _ = json.Marshal(&UploadPart{
+		ID:       "part_" + string(b),
+		Object:   objects.UploadPart,
+		UploadID: "upload_abc123",
+	})
+	_, _ = w.Write(b)
+}
+
 // getCompletionBody Returns the body of the request to create a completion.
 func getCompletionBody(r *http.Request) (*CompletionRequest[models.Completion], error) {
 	var completion = &CompletionRequest[models.Completion]{}
@@ -351,13 +590,4251 @@ func TestImages(t *testing.T) {
 	}
 }
 
-// OpenAITestServer Creates a mocked OpenAI server which can pretend to handle requests during testing.
-func OpenAITestServer() *httptest.Server {
-	return httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("received request at path %q\n", r.URL.Path)
+func TestTranscriptions(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
 
-		// check auth
-		if r.Header.Get("Authorization") != "Bearer "+testToken {
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateTranscription(context.Background(), &TranscriptionRequest{
+		File:     strings.NewReader("Lorem ipsum"),
+		Filename: "audio.mp3",
+		Model:    models.Whisper1,
+		Language: "en",
+	})
+	if err != nil {
+		t.Fatalf("CreateTranscription error: %v", err)
+	}
+	if resp.Text != "Lorem ipsum" {
+		t.Fatalf("unexpected transcription: %q", resp.Text)
+	}
+}
+
+func TestSpeech(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var rc, err = client.CreateSpeech(context.Background(), &SpeechRequest{
+		Model: models.TTS1,
+		Input: "Lorem ipsum",
+		Voice: audio.VoiceAlloy,
+	})
+	if err != nil {
+		t.Fatalf("CreateSpeech error: %v", err)
+	}
+	defer rc.Close()
+
+	var b []byte
+	if b, err = io.ReadAll(rc); err != nil {
+		t.Fatalf("error reading speech: %v", err)
+	}
+	if string(b) != "Lorem ipsum" {
+		t.Fatalf("unexpected speech: %q", b)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var f, err = client.UploadFile(ctx, &FileRequest{
+		File:     strings.NewReader(`{"prompt": "Lorem", "completion": "ipsum"}`),
+		Filename: "train.jsonl",
+		Purpose:  files.PurposeFineTune,
+	})
+	if err != nil {
+		t.Fatalf("UploadFile error: %v", err)
+	}
+	if f.Filename != "train.jsonl" || f.Purpose != files.PurposeFineTune || f.RequestID != testRequestID {
+		t.Fatalf("unexpected file: %+v", f)
+	}
+
+	if _, err = client.ListFiles(ctx); err != nil {
+		t.Fatalf("ListFiles error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = client.GetFileContent(ctx, f.ID, &buf); err != nil {
+		t.Fatalf("GetFileContent error: %v", err)
+	}
+	if buf.String() != `{"prompt": "Lorem", "completion": "ipsum"}` {
+		t.Fatalf("unexpected file content: %q", buf.String())
+	}
+}
+
+func TestFineTuningEvents(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var l, err = client.ListFineTuningEvents(ctx, "ftjob-abc123")
+	if err != nil {
+		t.Fatalf("ListFineTuningEvents error: %v", err)
+	}
+	if len(l.Data) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(l.Data))
+	}
+
+	var s *Stream[*FineTuningJobEvent]
+	s, err = client.StreamFineTuningEvents(ctx, "ftjob-abc123")
+	if err != nil {
+		t.Fatalf("StreamFineTuningEvents error: %v", err)
+	}
+	defer s.Close()
+
+	var msgs []string
+	for {
+		var e *FineTuningJobEvent
+		e, err = s.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+		msgs = append(msgs, e.Message)
+	}
+
+	if strings.Join(msgs, ",") != "Job started,Job succeeded" {
+		t.Fatalf("unexpected events: %v", msgs)
+	}
+}
+
+func TestAssistants(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var a, err = client.CreateAssistant(context.Background(), &AssistantRequest{
+		Model:        models.GPT4o,
+		Name:         params.Optional("Math Tutor"),
+		Instructions: params.Optional("You are a personal math tutor."),
+		Tools:        []*AssistantTool{{Type: tools.TypeCodeInterpreter}},
+	})
+	if err != nil {
+		t.Fatalf("CreateAssistant error: %v", err)
+	}
+	if a.Model != models.GPT4o || len(a.Tools) != 1 || a.Tools[0].Type != tools.TypeCodeInterpreter {
+		t.Fatalf("unexpected assistant: %+v", a)
+	}
+}
+
+func TestUploadParts(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ids, err = client.UploadParts(context.Background(), "upload_abc123", strings.NewReader("abcdefghij"), &UploadPartsOptions{
+		PartSize: 4,
+	})
+	if err != nil {
+		t.Fatalf("UploadParts error: %v", err)
+	}
+	if strings.Join(ids, ",") != "part_abcd,part_efgh,part_ij" {
+		t.Fatalf("unexpected part IDs: %v", ids)
+	}
+
+	// Failed parts are attempted again after a delay.
+	var attempts []time.Time
+	var failing = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	client, _ = newTestClient(failing.URL)
+	_, err = client.UploadParts(context.Background(), "upload_abc123", strings.NewReader("abcd"), &UploadPartsOptions{
+		MaxAttempts:    3,
+		InitialBackoff: 20 * time.Millisecond,
+	})
+	var upErr *UploadPartsError
+	if !errors.As(err, &upErr) || !strings.HasPrefix(err.Error(), "openai: ") || len(attempts) != 3 {
+		t.Fatalf("expected an openai: *UploadPartsError after 3 attempts, got %d attempts and error: %v", len(attempts), err)
+	}
+	if d := attempts[2].Sub(attempts[0]); d < 30*time.Millisecond {
+		t.Fatalf("expected a backoff between attempts, got %v", d)
+	}
+}
+
+func TestAdminKey(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	if _, err := client.ListProjects(ctx); err == nil {
+		t.Fatalf("expected ListProjects to fail without an admin key")
+	}
+
+	WithAdminKey(testAdminKey)(client)
+
+	if _, err := client.ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects error: %v", err)
+	}
+	// The regular token should still be used for non-administration endpoints.
+	if _, err := client.ListFiles(ctx); err != nil {
+		t.Fatalf("ListFiles error: %v", err)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var _, err = client.RetrieveModel(ctx, "does-not-exist")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "model_not_found" || apiErr.RequestID != testRequestID {
+		t.Fatalf("unexpected error fields: %+v", apiErr)
+	}
+	if !errors.Is(err, ErrInvalidRequest) || !errors.Is(err, ErrNotFound) || errors.Is(err, ErrServer) {
+		t.Fatalf("unexpected errors.Is results for %v", err)
+	}
+
+	_, err = client.RetrieveModel(ctx, "rate-limited")
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || !errors.Is(err, ErrRateLimit) {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.RetryAfter != 2*time.Second || rlErr.Limits.RemainingTokens != 10 || rlErr.Limits.ResetTokens != 6*time.Minute {
+		t.Fatalf("unexpected rate limit error fields: %+v", rlErr)
+	}
+
+	// Non-JSON error bodies should still produce an *APIError.
+	_, err = client.ListModels(ctx)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Type != "" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var attempts int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var b, _ = io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected server error without retries, got: %v", err)
+	}
+
+	attempts = 0
+	WithRetry(&RetryConfig{InitialBackoff: time.Millisecond})(client)
+
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+
+	attempts = -10
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected server error after exhausting retries, got: %v", err)
+	}
+
+	attempts = 0
+	WithRetryPolicy(RetryPolicyFunc(func(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+		return 0, resp != nil && resp.StatusCode == http.StatusServiceUnavailable && attempt < 2
+	}))(client)
+
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); !errors.Is(err, ErrServer) || attempts != 2 {
+		t.Fatalf("expected server error after 2 attempts, got %d attempts and error: %v", attempts, err)
+	}
+
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{&APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{&APIError{StatusCode: http.StatusTooManyRequests, Code: "insufficient_quota"}, false},
+		{&APIError{StatusCode: http.StatusInternalServerError}, true},
+		{&APIError{StatusCode: http.StatusBadGateway}, true},
+		{&APIError{StatusCode: http.StatusServiceUnavailable}, true},
+		{&APIError{StatusCode: http.StatusNotImplemented}, false},
+		{&APIError{StatusCode: http.StatusGatewayTimeout}, false},
+		{&APIError{StatusCode: http.StatusHTTPVersionNotSupported}, false},
+		{&APIError{StatusCode: http.StatusBadRequest}, false},
+		{&url.Error{Op: "Post", Err: syscall.ECONNRESET}, true},
+		{&url.Error{Op: "Post", Err: io.ErrUnexpectedEOF}, true},
+		{&url.Error{Op: "Post", Err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}}, true},
+		{&url.Error{Op: "Post", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}, false},
+		{&url.Error{Op: "Post", Err: x509.UnknownAuthorityError{}}, false},
+		{&url.Error{Op: "parse", Err: errors.New("invalid URL escape")}, false},
+		{context.DeadlineExceeded, false},
+	} {
+		if got := retryable(tc.err); got != tc.want {
+			t.Errorf("retryable(%v) = %t, expected %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimitBucket(t *testing.T) {
+	var b = newBucket(60)
+
+	if d := b.reserve(60); d != 0 {
+		t.Fatalf("expected a full bucket, got delay %v", d)
+	}
+	if d := b.reserve(1); d < 900*time.Millisecond || d > time.Second {
+		t.Fatalf("expected a delay of ~1s, got %v", d)
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := b.wait(ctx, 100); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	var mu sync.Mutex
+	var current, peak int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > peak {
+			peak = current
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithMaxInFlight(2)(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{}); err != nil {
+				t.Errorf("CreateEmbeddings error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", peak)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy bool
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	WithCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 2, Cooldown: 20 * time.Millisecond})(client)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); !errors.Is(err, ErrServer) {
+			t.Fatalf("expected server error, got: %v", err)
+		}
+	}
+
+	healthy = true
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got: %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); err != nil {
+			t.Fatalf("CreateEmbeddings error after cooldown: %v", err)
+		}
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Slow") != "" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		if r.URL.Path != "/v1/embeddings" && r.URL.Path != "/gateway/v1/embeddings" {
+			http.Error(w, "the resource path doesn't exist", http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var _, err = client.CreateEmbeddings(ctx, &EmbeddingRequest{},
+		WithRequestHeader("X-Slow", "1"), WithRequestTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+
+	if _, err = client.CreateEmbeddings(ctx, &EmbeddingRequest{}, WithRequestBaseURL(ts.URL+"/gateway/v1")); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+}
+
+func TestCustomHeaders(t *testing.T) {
+	var client = NewClient(testToken,
+		WithHeader("X-Gateway-Key", "gateway"),
+		WithHeader("X-Trace-ID", "static"),
+		WithHeaderFunc(func(ctx context.Context) http.Header {
+			return http.Header{"x-trace-id": {"from-context"}}
+		}),
+	)
+
+	var req, err = client.newRequest(context.Background(), http.MethodGet, routes.Models, nil,
+		client.newRequestConfig([]RequestOption{WithRequestHeader("X-Gateway-Key", "per-request")}))
+	if err != nil {
+		t.Fatalf("newRequest error: %v", err)
+	}
+
+	if v := req.Header.Get("X-Gateway-Key"); v != "per-request" {
+		t.Fatalf("expected per-request header to take precedence, got %q", v)
+	}
+	if v := req.Header.Get("X-Trace-ID"); v != "from-context" {
+		t.Fatalf("expected header func to take precedence over static headers, got %q", v)
+	}
+}
+
+func TestOrganizationAndProject(t *testing.T) {
+	var client = NewClientWithOrg(testToken, "org-abc123", WithProject("proj_abc123"))
+
+	var req, err = client.newRequest(context.Background(), http.MethodGet, routes.Models, nil, client.newRequestConfig(nil))
+	if err != nil {
+		t.Fatalf("newRequest error: %v", err)
+	}
+
+	if v := req.Header.Get("OpenAI-Organization"); v != "org-abc123" {
+		t.Fatalf("unexpected OpenAI-Organization header: %q", v)
+	}
+	if v := req.Header.Get("OpenAI-Project"); v != "proj_abc123" {
+		t.Fatalf("unexpected OpenAI-Project header: %q", v)
+	}
+}
+
+func TestAzure(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("api-key") != testToken || r.Header.Get("Authorization") != "":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Query().Get("api-version") != DefaultAzureAPIVersion:
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/openai/deployments/my-embeddings/embeddings", r.URL.Path == "/openai/files":
+			_, _ = io.WriteString(w, `{"data": []}`)
+		default:
+			http.Error(w, "the resource path doesn't exist", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	var client, err = NewAzureClient(testToken, &AzureConfig{
+		Endpoint:    ts.URL,
+		Deployments: map[string]string{models.AdaEmbeddingV2.String(): "my-embeddings"},
+	})
+	if err != nil {
+		t.Fatalf("NewAzureClient error: %v", err)
+	}
+	var ctx = context.Background()
+
+	if _, err = client.CreateEmbeddings(ctx, &EmbeddingRequest{Model: models.AdaEmbeddingV2}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	if _, err = client.ListFiles(ctx); err != nil {
+		t.Fatalf("ListFiles error: %v", err)
+	}
+}
+
+func TestTokenProvider(t *testing.T) {
+	var calls int
+	var expiry = time.Now().Add(time.Hour)
+	var client = NewClient("", WithTokenProvider(TokenProviderFunc(func(ctx context.Context) (*Token, error) {
+		calls++
+		return &Token{Value: fmt.Sprintf("token-%d", calls), Expiry: expiry}, nil
+	})))
+
+	var newRequest = func() *http.Request {
+		var req, err = client.newRequest(context.Background(), http.MethodGet, routes.Models, nil, client.newRequestConfig(nil))
+		if err != nil {
+			t.Fatalf("newRequest error: %v", err)
+		}
+		return req
+	}
+
+	for i := 0; i < 2; i++ {
+		if v := newRequest().Header.Get("Authorization"); v != "Bearer token-1" {
+			t.Fatalf("expected cached token, got %q", v)
+		}
+	}
+
+	// Tokens which are about to expire should be refreshed.
+	client.tokens.token.Expiry = time.Now().Add(time.Second)
+	if v := newRequest().Header.Get("Authorization"); v != "Bearer token-2" {
+		t.Fatalf("expected refreshed token, got %q", v)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	var ts = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	client.scheme = "https"
+	var ctx = context.Background()
+
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); err == nil {
+		t.Fatalf("expected an error for an untrusted certificate")
+	}
+
+	var pool = x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})(client)
+
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	// The TLS configuration is kept when an *http.Client is set afterwards, without modifying its transport.
+	var transport = &http.Transport{}
+	WithHTTPClient(&http.Client{Transport: transport})(client)
+	WithHTTPClient(nil)(client)
+	if _, err := client.CreateEmbeddings(ctx, &EmbeddingRequest{}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+	// Cloning a transport may set up its HTTP/2 support, but must not apply the configuration to it.
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.RootCAs != nil {
+		t.Fatalf("the transport of the *http.Client was modified")
+	}
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"usage": {"prompt_tokens": 8, "total_tokens": 8}}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var m *RequestMetrics
+	WithMetricsRecorder(MetricsRecorderFunc(func(ctx context.Context, rm *RequestMetrics) {
+		m = rm
+	}))(client)
+
+	if _, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{Model: models.AdaEmbeddingV2}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	if m == nil || m.Endpoint != routes.Embeddings || m.Model != models.AdaEmbeddingV2.String() ||
+		m.StatusCode != http.StatusOK || m.Usage == nil || m.Usage.TotalTokens != 8 {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestPricing(t *testing.T) {
+	for model, want := range map[string]float64{
+		"gpt-4o":                            2.50,
+		"gpt-4o-2024-08-06":                 2.50,
+		"gpt-4o-2024-05-13":                 5.00,
+		"gpt-4o-mini-2024-07-18":            0.15,
+		"ft:gpt-4o-mini-2024-07-18:org::id": 0.30,
+	} {
+		if p, ok := DefaultPricing.Lookup(model); !ok || p.Input != want {
+			t.Errorf("expected input price %v for %s, got %v", want, model, p.Input)
+		}
+	}
+	if _, ok := DefaultPricing.Lookup("unknown-model"); ok {
+		t.Errorf("expected no price for an unknown model")
+	}
+
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"model": "gpt-4o-mini-2024-07-18", "usage": {"prompt_tokens": 1000, "completion_tokens": 500, "total_tokens": 1500}}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var m *RequestMetrics
+	WithPricing(DefaultPricing)(client)
+	WithMetricsRecorder(MetricsRecorderFunc(func(ctx context.Context, rm *RequestMetrics) {
+		m = rm
+	}))(client)
+
+	var res, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{Model: models.TextDavinci003})
+	if err != nil {
+		t.Fatalf("CreateCompletion error: %v", err)
+	}
+
+	const want = 0.00045
+	if res.Cost == nil || math.Abs(*res.Cost-want) > 1e-12 {
+		t.Fatalf("expected cost %v, got %v", want, res.Cost)
+	}
+	if m == nil || m.Cost == nil || *m.Cost != *res.Cost {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestUsageTracker(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}`)
+	}))
+	defer ts.Close()
+
+	var tracker = NewUsageTracker()
+	var client, _ = newTestClient(ts.URL)
+	WithUsageTracker(tracker)(client)
+	WithPricing(Pricing{"text-davinci-003": {Input: 1e6, Output: 1e6}})(client)
+
+	var wg sync.WaitGroup
+	for _, user := range []string{"alice", "alice", "bob", ""} {
+		wg.Add(1)
+		go func(user string) {
+			defer wg.Done()
+			var req = &CompletionRequest[models.Completion]{Model: models.TextDavinci003, User: user}
+			if _, err := client.CreateCompletion(context.Background(), req); err != nil {
+				t.Errorf("CreateCompletion error: %v", err)
+			}
+		}(user)
+	}
+	wg.Wait()
+
+	if total := tracker.Total(); total.Requests != 4 || total.TotalTokens != 60 || total.Cost != 60 {
+		t.Fatalf("unexpected total: %+v", total)
+	}
+	if m := tracker.ByModel()["text-davinci-003"]; m.Requests != 4 || m.PromptTokens != 40 {
+		t.Fatalf("unexpected model rollup: %+v", m)
+	}
+	var users = tracker.ByUser()
+	if len(users) != 2 || users["alice"].CompletionTokens != 10 || users["bob"].Requests != 1 {
+		t.Fatalf("unexpected user rollups: %+v", users)
+	}
+
+	tracker.Reset()
+	if total := tracker.Total(); total.Requests != 0 {
+		t.Fatalf("expected Reset to clear totals, got: %+v", total)
+	}
+
+	// The zero value is ready to use.
+	var zero UsageTracker
+	zero.Record("gpt-4o", "alice", &Usage{PromptTokens: 1, TotalTokens: 1}, nil)
+	if zero.ByModel()["gpt-4o"].Requests != 1 || zero.ByUser()["alice"].PromptTokens != 1 {
+		t.Fatalf("unexpected rollups of the zero value: %+v, %+v", zero.ByModel(), zero.ByUser())
+	}
+}
+
+func TestContextValidation(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithContextValidation(DefaultContextWindows)(client)
+
+	var _, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{
+		Model:     models.TextDavinci003,
+		Prompt:    TokenPrompt(make([]int, 4000)...),
+		MaxTokens: 100,
+	})
+	var cle *ContextLengthError
+	if !errors.As(err, &cle) || !errors.Is(err, ErrContextLengthExceeded) {
+		t.Fatalf("expected a *ContextLengthError, got: %v", err)
+	}
+	if cle.ContextWindow != 4097 || cle.PromptTokens != 4000 || cle.MaxTokens != 100 {
+		t.Fatalf("unexpected error: %+v", cle)
+	}
+
+	if err = DefaultContextWindows.Validate("ft:gpt-4o-mini-2024-07-18:org::abc", 100000, 28000); err != nil {
+		t.Fatalf("expected a fine-tuned model to use the window of its base model, got: %v", err)
+	}
+	if err = DefaultContextWindows.Validate("unknown-model", 1<<30, 0); err != nil {
+		t.Fatalf("expected unknown models not to be validated, got: %v", err)
+	}
+
+	_, err = client.CreateEmbeddings(context.Background(), &EmbeddingRequest{
+		Model: models.AdaEmbeddingV2,
+		Input: []string{strings.Repeat("a", 40000)},
+	})
+	if !errors.Is(err, ErrContextLengthExceeded) {
+		t.Fatalf("expected ErrContextLengthExceeded for a long embedding input, got: %v", err)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := CosineSimilarity([]float64{1, 0}, []float64{2, 0}); got != 1 {
+		t.Fatalf("expected parallel vectors to have similarity 1, got %v", got)
+	}
+	if got := CosineSimilarity([]float64{1, 0}, []float64{0, 0}); got != 0 {
+		t.Fatalf("expected a zero vector to have similarity 0, got %v", got)
+	}
+	if got := DotProduct([]float64{1, 2, 3}, []float64{4, 5, 6}); got != 32 {
+		t.Fatalf("expected dot product 32, got %v", got)
+	}
+	if got := Norm(Normalize([]float64{3, 4})); math.Abs(got-1) > 1e-12 {
+		t.Fatalf("expected a normalized vector to have length 1, got %v", got)
+	}
+
+	var resp = &EmbeddingResponse{List: &List[*Embedding]{Data: []*Embedding{
+		{Index: 1, Embedding: []float64{0, 1}},
+		{Index: 0, Embedding: []float64{1, 0}},
+		{Index: 2, Embedding: []float64{1, 1}},
+	}}}
+	var vectors = resp.Vectors()
+	if vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Fatalf("expected vectors in input order, got %v", vectors)
+	}
+
+	var top = TopK([]float64{1, 0.1}, vectors, 2)
+	if len(top) != 2 || top[0].Index != 0 || top[1].Index != 2 {
+		t.Fatalf("unexpected neighbors: %+v, %+v", top[0], top[1])
+	}
+}
+
+// embeddingsFunc implements EmbeddingsAPI by calling itself.
+type embeddingsFunc func(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error)
+
+func (f embeddingsFunc) CreateEmbeddings(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error) {
+	return f(ctx, request, opts...)
+}
+
+func TestVectorIndex(t *testing.T) {
+	// Embed each input as a vector of its counts of the words "cat" and "dog".
+	var calls int
+	var api = embeddingsFunc(func(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error) {
+		calls++
+		var data []*Embedding
+		for i, in := range request.Input {
+			data = append(data, &Embedding{Index: i, Embedding: []float64{
+				float64(strings.Count(in, "cat")), float64(strings.Count(in, "dog")),
+			}})
+		}
+		return &EmbeddingResponse{List: &List[*Embedding]{Data: data}}, nil
+	})
+
+	var index = NewVectorIndex(api, models.AdaEmbeddingV2)
+	var ctx = context.Background()
+	var err = index.Add(ctx,
+		&Document{ID: "1", Text: "cat cat", Metadata: map[string]string{"lang": "en"}},
+		&Document{ID: "2", Text: "dog", Metadata: map[string]string{"lang": "en"}},
+		&Document{ID: "3", Text: "cat dog", Metadata: map[string]string{"lang": "fr"}},
+	)
+	if err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	if calls != 1 || index.Len() != 3 {
+		t.Fatalf("expected 3 documents embedded in 1 request, got %d in %d", index.Len(), calls)
+	}
+
+	var results []*SearchResult
+	if results, err = index.Search(ctx, "cat", 2, nil); err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "1" || results[1].ID != "3" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if results, _ = index.Search(ctx, "cat", 5, map[string]string{"lang": "en"}); len(results) != 2 || results[1].ID != "2" {
+		t.Fatalf("unexpected filtered results: %+v", results)
+	}
+
+	index.Delete("1")
+	if results, _ = index.Search(ctx, "cat", 1, nil); results[0].ID != "3" || index.Get("1") != nil {
+		t.Fatalf("expected the deleted document not to be found, got: %+v", results[0])
+	}
+
+	// If a later batch fails, the documents of earlier batches are left unmodified.
+	var failing = embeddingsFunc(func(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error) {
+		if len(request.Input) < maxEmbeddingInputs {
+			return nil, errors.New("embedding failed")
+		}
+		return api(ctx, request, opts...)
+	})
+	var docs = make([]*Document, maxEmbeddingInputs+1)
+	for i := range docs {
+		docs[i] = &Document{ID: strconv.Itoa(i), Text: "cat"}
+	}
+	if err = NewVectorIndex(failing, models.AdaEmbeddingV2).Add(ctx, docs...); err == nil {
+		t.Fatal("expected an error when a batch fails")
+	}
+	if docs[0].Vector != nil {
+		t.Fatalf("expected no vectors to be assigned, got: %v", docs[0].Vector)
+	}
+}
+
+func TestEmbeddingsBase64(t *testing.T) {
+	var packed = make([]byte, 12)
+	for i, f := range []float32{0.5, -1.25, 3} {
+		binary.LittleEndian.PutUint32(packed[i*4:], math.Float32bits(f))
+	}
+
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.EncodingFormat != embeddings.FormatBase64 {
+			t.Errorf("expected encoding_format base64, got %v (error: %v)", req.EncodingFormat, err)
+		}
+		fmt.Fprintf(w, `{"object": "list", "data": [{"object": "embedding", "index": 0, "embedding": %q}]}`,
+			base64.StdEncoding.EncodeToString(packed))
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateEmbeddings(context.Background(), &EmbeddingRequest{
+		Input:          []string{"hello"},
+		Model:          models.AdaEmbeddingV2,
+		EncodingFormat: embeddings.FormatBase64,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	if got := resp.Data[0].Embedding; !reflect.DeepEqual(got, []float64{0.5, -1.25, 3}) {
+		t.Fatalf("unexpected decoded embedding: %v", got)
+	}
+
+	var e Embedding
+	if err = json.Unmarshal([]byte(`{"index": 2, "embedding": [0.1, 0.2]}`), &e); err != nil || e.Index != 2 || len(e.Embedding) != 2 {
+		t.Fatalf("unexpected float embedding %+v, error: %v", e, err)
+	}
+}
+
+func TestSemanticCache(t *testing.T) {
+	var completions int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/v1/embeddings":
+			// Embed prompts mentioning the weather identically.
+			var vector = []float64{0, 1}
+			if strings.Contains(fmt.Sprint(body["input"]), "weather") {
+				vector = []float64{1, 0}
+			}
+			_ = json.NewEncoder(w).Encode(&EmbeddingResponse{List: &List[*Embedding]{Data: []*Embedding{{Embedding: vector}}}})
+		case "/v1/completions":
+			completions++
+			fmt.Fprintf(w, `{"id": "cmpl-%d", "choices": [{"text": "%v"}]}`, completions, body["prompt"])
+		}
+	}))
+	defer ts.Close()
+
+	var store = NewMemorySemanticStore(10)
+	var client, _ = newTestClient(ts.URL)
+	WithSemanticCache(&SemanticCacheConfig{Store: store})(client)
+
+	var completeWith = func(client *Client, prompt string, temperature float64) *CompletionResponse[models.Completion] {
+		t.Helper()
+		var resp, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{
+			Model:       models.TextDavinci003,
+			Prompt:      TextPrompt(prompt),
+			Temperature: &temperature,
+		})
+		if err != nil {
+			t.Fatalf("CreateCompletion error: %v", err)
+		}
+		return resp
+	}
+	var complete = func(prompt string, temperature float64) *CompletionResponse[models.Completion] {
+		t.Helper()
+		return completeWith(client, prompt, temperature)
+	}
+
+	if resp := complete("What's the weather?", 0); resp.Cached || resp.ID != "cmpl-1" {
+		t.Fatalf("expected an uncached response, got: %+v", resp)
+	}
+	if resp := complete("How is the weather today?", 0); !resp.Cached || resp.ID != "cmpl-1" {
+		t.Fatalf("expected the cached response to a similar prompt, got: %+v", resp)
+	}
+	if resp := complete("Tell me a joke", 0); resp.Cached || resp.ID != "cmpl-2" {
+		t.Fatalf("expected an uncached response to a dissimilar prompt, got: %+v", resp)
+	}
+	if resp := complete("What's the weather?", 1); resp.Cached || resp.ID != "cmpl-3" {
+		t.Fatalf("expected an uncached response to a request with different parameters, got: %+v", resp)
+	}
+
+	// Clients of other projects do not share responses, even with the same store.
+	var other, _ = newTestClient(ts.URL)
+	WithProject("proj_other")(other)
+	WithSemanticCache(&SemanticCacheConfig{Store: store})(other)
+	if resp := completeWith(other, "What's the weather?", 0); resp.Cached || resp.ID != "cmpl-4" {
+		t.Fatalf("expected an uncached response for another project, got: %+v", resp)
+	}
+}
+
+func TestCache(t *testing.T) {
+	var requests int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"id": "cmpl-%d"}`, requests)
+	}))
+	defer ts.Close()
+
+	var store = NewLRUCache(1)
+	var client, _ = newTestClient(ts.URL)
+	WithCache(store, time.Hour)(client)
+
+	var complete = func(prompt string, temperature *float64) *CompletionResponse[models.Completion] {
+		t.Helper()
+		var resp, err = client.CreateCompletion(context.Background(), &CompletionRequest[models.Completion]{
+			Model:       models.TextDavinci003,
+			Prompt:      TextPrompt(prompt),
+			Temperature: temperature,
+		})
+		if err != nil {
+			t.Fatalf("CreateCompletion error: %v", err)
+		}
+		return resp
+	}
+
+	var zero = params.Optional(0.0)
+	if resp := complete("a", zero); resp.Cached || resp.ID != "cmpl-1" {
+		t.Fatalf("expected an uncached response, got: %+v", resp)
+	}
+	if resp := complete("a", zero); !resp.Cached || resp.ID != "cmpl-1" {
+		t.Fatalf("expected the cached response, got: %+v", resp)
+	}
+	if resp := complete("a", nil); resp.Cached {
+		t.Fatalf("expected a request with the default temperature not to be cached, got: %+v", resp)
+	}
+
+	// The store holds a single entry, so caching "b" evicts "a".
+	complete("b", zero)
+	if resp := complete("a", zero); resp.Cached {
+		t.Fatalf("expected the evicted response not to be cached, got: %+v", resp)
+	}
+
+	_ = store.Set(context.Background(), "expired", []byte("{}"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok, _ := store.Get(context.Background(), "expired"); ok {
+		t.Fatalf("expected the expired entry not to be returned")
+	}
+}
+
+func TestChatToolCalls(t *testing.T) {
+	var requests []map[string]any
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+
+		if len(requests) == 1 {
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4o", "choices": [{
+				"index": 0, "finish_reason": "tool_calls", "message": {"role": "assistant", "content": null, "tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\": \"Paris\"}"}}
+				]}}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-2", "choices": [{"message": {"role": "assistant", "content": "It's sunny."}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("What's the weather in Paris?")},
+		Tools: []*Tool{FunctionTool(&FunctionDefinition{
+			Name:       "get_weather",
+			Parameters: map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+		})},
+		ToolChoice: ToolChoiceFunction("get_weather"),
+	}
+
+	var resp, err = client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+
+	var choice = requests[0]["tool_choice"].(map[string]any)
+	if choice["type"] != "function" || choice["function"].(map[string]any)["name"] != "get_weather" {
+		t.Fatalf("unexpected tool_choice: %v", choice)
+	}
+
+	var msg = resp.Choices[0].Message
+	if msg.Role != roles.Assistant || len(msg.ToolCalls) != 1 || msg.ToolCalls[0].Type != tools.TypeFunction {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+
+	var args struct {
+		City string `json:"city"`
+	}
+	if err = msg.ToolCalls[0].Function.DecodeArguments(&args); err != nil || args.City != "Paris" {
+		t.Fatalf("unexpected arguments %+v, error: %v", args, err)
+	}
+
+	req.Messages = append(req.Messages, msg, ToolMessage(msg.ToolCalls[0].ID, "sunny"))
+	req.ToolChoice = ToolChoiceAuto
+	req.ParallelToolCalls = params.Optional(false)
+	if resp, err = client.CreateChatCompletion(ctx, req); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "It's sunny." || requests[1]["tool_choice"] != "auto" ||
+		requests[1]["parallel_tool_calls"] != false {
+		t.Fatalf("unexpected response %+v to request %v", resp.Choices[0].Message, requests[1])
+	}
+
+	var messages = requests[1]["messages"].([]any)
+	var tool = messages[2].(map[string]any)
+	if tool["role"] != "tool" || tool["tool_call_id"] != "call_1" || tool["content"] != "sunny" {
+		t.Fatalf("unexpected tool message: %v", tool)
+	}
+
+	for _, bad := range []*ChatCompletionRequest[models.Chat]{
+		{Model: models.GPT4o},
+		{Model: models.GPT4o, Messages: []*ChatMessage{{Role: roles.Tool, Content: "sunny"}}},
+		{Model: models.GPT4o, Messages: req.Messages[:1], ToolChoice: ToolChoiceFunction("unknown")},
+		{Model: models.GPT4o, Messages: req.Messages[:1], ToolChoice: ToolChoiceRequired},
+		{Model: models.GPT4o, Messages: req.Messages[:1], ParallelToolCalls: params.Optional(false)},
+	} {
+		if _, err = client.CreateChatCompletion(ctx, bad); err == nil {
+			t.Errorf("expected an error for invalid request %+v", bad)
+		}
+	}
+}
+
+func TestNewFunctionTool(t *testing.T) {
+	type weather struct {
+		City string `json:"city" description:"The city."`
+	}
+
+	var tool, err = NewFunctionTool[weather]("get_weather", "Gets the weather.")
+	if err != nil {
+		t.Fatalf("NewFunctionTool error: %v", err)
+	}
+
+	var b, _ = json.Marshal(tool)
+	const want = `{"type":"function","function":{"name":"get_weather","description":"Gets the weather.","parameters":` +
+		`{"type":"object","properties":{"city":{"type":"string","description":"The city."}},"required":["city"]}}}`
+	if string(b) != want {
+		t.Fatalf("unexpected tool: %s", b)
+	}
+
+	var w weather
+	if w, err = DecodeArguments[weather](&FunctionCall{Arguments: `{"city": "Paris"}`}); err != nil || w.City != "Paris" {
+		t.Fatalf("unexpected arguments %+v, error: %v", w, err)
+	}
+	if _, err = DecodeArguments[weather](&FunctionCall{Arguments: `{"city": "Paris", "country": "FR"}`}); err == nil {
+		t.Fatalf("expected an error for an unknown argument")
+	}
+}
+
+// chatFunc implements ChatAPI by calling itself for models.Chat requests.
+type chatFunc func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error)
+
+func (f chatFunc) CreateChatCompletion(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+	return f(ctx, cr, opts...)
+}
+
+func (f chatFunc) CreateFineTunedChatCompletion(context.Context, *ChatCompletionRequest[models.FineTunedModel], ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f chatFunc) CreateChatCompletionStream(context.Context, *ChatCompletionRequest[models.Chat], ...RequestOption) (*Stream[*ChatCompletionChunk[models.Chat]], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f chatFunc) CreateFineTunedChatCompletionStream(context.Context, *ChatCompletionRequest[models.FineTunedModel], ...RequestOption) (*Stream[*ChatCompletionChunk[models.FineTunedModel]], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f chatFunc) RetrieveChatCompletion(context.Context, string, ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f chatFunc) ListChatCompletions(context.Context, *ChatCompletionsListRequest, ...RequestOption) (*List[*ChatCompletionResponse[models.Chat]], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f chatFunc) DeleteChatCompletion(context.Context, string, ...RequestOption) (*DeletionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// chatResponse returns a *ChatCompletionResponse containing |msg|.
+func chatResponse(msg *ChatMessage) *ChatCompletionResponse[models.Chat] {
+	return &ChatCompletionResponse[models.Chat]{
+		Choices: []*ChatCompletionChoice{{Message: msg}},
+		Usage:   &Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	}
+}
+
+func TestToolChoice(t *testing.T) {
+	for _, tc := range []struct {
+		choice *ToolChoice
+		want   string
+	}{
+		{ToolChoiceNone, `"none"`},
+		{ToolChoiceAuto, `"auto"`},
+		{ToolChoiceRequired, `"required"`},
+		{ToolChoiceFunction("f"), `{"type":"function","function":{"name":"f"}}`},
+	} {
+		var b, err = json.Marshal(tc.choice)
+		if err != nil || string(b) != tc.want {
+			t.Fatalf("expected %s, got %s (error: %v)", tc.want, b, err)
+		}
+
+		var got = &ToolChoice{}
+		if err = json.Unmarshal(b, got); err != nil || *got != *tc.choice {
+			t.Fatalf("expected %s to round trip, got %+v (error: %v)", b, got, err)
+		}
+	}
+}
+
+func TestRunner(t *testing.T) {
+	var api = chatFunc(func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+		var last = cr.Messages[len(cr.Messages)-1]
+		if last.Role == roles.User {
+			if len(cr.Tools) != 2 {
+				t.Errorf("expected 2 tools, got %d", len(cr.Tools))
+			}
+			return chatResponse(&ChatMessage{Role: roles.Assistant, ToolCalls: []*ToolCall{
+				{ID: "call_1", Type: tools.TypeFunction, Function: &FunctionCall{Name: "add", Arguments: `{"a": 2, "b": 3}`}},
+				{ID: "call_2", Type: tools.TypeFunction, Function: &FunctionCall{Name: "slow", Arguments: `{}`}},
+				{ID: "call_3", Type: tools.TypeFunction, Function: &FunctionCall{Name: "missing", Arguments: `{}`}},
+			}}), nil
+		}
+
+		var results []string
+		for _, m := range cr.Messages[len(cr.Messages)-3:] {
+			results = append(results, m.ToolCallID+"="+m.Content)
+		}
+		return chatResponse(AssistantMessage(strings.Join(results, "; "))), nil
+	})
+
+	type addArgs struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+
+	var runner = NewRunner(api)
+	runner.ToolTimeout = 10 * time.Millisecond
+	var err = RegisterFunc(runner, "add", "Adds two numbers.", func(ctx context.Context, args addArgs) (any, error) {
+		return map[string]int{"sum": args.A + args.B}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterFunc error: %v", err)
+	}
+	runner.Register(&FunctionDefinition{Name: "slow"}, func(ctx context.Context, arguments string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	var req = &ChatCompletionRequest[models.Chat]{Model: models.GPT4o, Messages: []*ChatMessage{UserMessage("2 + 3?")}}
+	var res *RunResult
+	if res, err = runner.Run(context.Background(), req); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+
+	const want = `call_1={"sum":5}; call_2=error: context deadline exceeded; call_3=error: unknown function "missing"`
+	if res.Answer() != want {
+		t.Fatalf("unexpected answer: %s", res.Answer())
+	}
+	if res.Turns != 2 || res.Usage.TotalTokens != 30 || len(res.Messages) != 6 || len(req.Messages) != 1 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	runner.MaxTurns = 1
+	if _, err = runner.Run(context.Background(), req); !errors.Is(err, ErrMaxTurns) {
+		t.Fatalf("expected ErrMaxTurns, got: %v", err)
+	}
+
+	// A "none" tool choice is kept on later turns, even if it is not ToolChoiceNone itself.
+	var choices []*ToolChoice
+	runner = NewRunner(chatFunc(func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+		choices = append(choices, cr.ToolChoice)
+		if len(choices) == 1 {
+			return chatResponse(&ChatMessage{Role: roles.Assistant, ToolCalls: []*ToolCall{
+				{ID: "call_1", Type: tools.TypeFunction, Function: &FunctionCall{Name: "add", Arguments: `{"a": 1, "b": 1}`}},
+			}}), nil
+		}
+		return chatResponse(AssistantMessage("2")), nil
+	}))
+	runner.Register(&FunctionDefinition{Name: "add"}, func(ctx context.Context, arguments string) (string, error) {
+		return "2", nil
+	})
+
+	var none = &ToolChoice{}
+	if err = json.Unmarshal([]byte(`"none"`), none); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	req.ToolChoice = none
+	if _, err = runner.Run(context.Background(), req); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+	if len(choices) != 2 || choices[1] == nil || *choices[1] != *ToolChoiceNone {
+		t.Fatalf("expected the none tool choice to be kept, got: %+v", choices)
+	}
+
+	// Registered tools replace those of the request with the same name, panics are sent to the model, and the usage
+	// details of every turn are summed.
+	var sent [][]*Tool
+	runner = NewRunner(chatFunc(func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+		sent = append(sent, cr.Tools)
+		var resp = chatResponse(AssistantMessage(cr.Messages[len(cr.Messages)-1].Content))
+		if len(sent) == 1 {
+			resp = chatResponse(&ChatMessage{Role: roles.Assistant, ToolCalls: []*ToolCall{
+				{ID: "call_1", Type: tools.TypeFunction, Function: &FunctionCall{Name: "add", Arguments: `{}`}},
+			}})
+		}
+		resp.Usage.CompletionTokensDetails = &CompletionTokensDetails{ReasoningTokens: 2}
+		return resp, nil
+	}))
+	runner.Register(&FunctionDefinition{Name: "add"}, func(ctx context.Context, arguments string) (string, error) {
+		panic("boom")
+	})
+
+	req = &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("1 + 1?")},
+		Tools:    []*Tool{FunctionTool(&FunctionDefinition{Name: "add", Description: "Stale."})},
+	}
+	if res, err = runner.Run(context.Background(), req); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+	if len(sent[0]) != 1 || sent[0][0].Function.Description != "" {
+		t.Fatalf("expected only the registered tool, got %+v", sent[0])
+	}
+	if res.Answer() != "error: tool panicked: boom" {
+		t.Fatalf("unexpected answer: %s", res.Answer())
+	}
+	if res.Usage.TotalTokens != 30 || res.Usage.ReasoningTokens() != 4 {
+		t.Fatalf("unexpected usage: %+v", res.Usage)
+	}
+}
+
+func TestResponseFormat(t *testing.T) {
+	type answer struct {
+		City  string   `json:"city" description:"The name of the city."`
+		Notes []string `json:"notes,omitempty"`
+	}
+
+	var rf, err = ResponseFormatFor[answer]("answer")
+	if err != nil {
+		t.Fatalf("ResponseFormatFor error: %v", err)
+	}
+
+	var b []byte
+	if b, err = json.Marshal(rf); err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var want = `{"type":"json_schema","json_schema":{"name":"answer","schema":{"type":"object","properties":{` +
+		`"city":{"type":"string","description":"The name of the city."},` +
+		`"notes":{"type":["array","null"],"items":{"type":"string"}}},` +
+		`"required":["city","notes"],"additionalProperties":false},"strict":true}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+
+	var got = &ResponseFormat{}
+	if err = json.Unmarshal(b, got); err != nil || got.JSONSchema().Name != "answer" {
+		t.Fatalf("expected %s to round trip, got %+v (error: %v)", b, got.JSONSchema(), err)
+	}
+
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:          models.GPT4o,
+		Messages:       []*ChatMessage{UserMessage("Where is the Eiffel Tower?")},
+		ResponseFormat: rf,
+	}
+	if err = req.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	for _, s := range []*JSONSchema{
+		{Name: "bad name", Schema: rf.JSONSchema().Schema},
+		{Name: "loose", Strict: true, Schema: &jsonschema.Schema{
+			Type: "object", Properties: map[string]*jsonschema.Schema{"city": {Type: "string"}},
+		}},
+		{Name: "partial", Strict: true, Schema: &jsonschema.Schema{
+			Type: "object", Properties: map[string]*jsonschema.Schema{"city": {Type: "string"}}, AdditionalProperties: false,
+		}},
+	} {
+		req.ResponseFormat = ResponseFormatJSONSchema(s)
+		if err = req.validate(); err == nil {
+			t.Fatalf("expected a validation error for %+v", s)
+		}
+	}
+}
+
+func TestRespond(t *testing.T) {
+	type Answer struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+
+	var reply = chatResponse(AssistantMessage(`{"city": "Paris", "country": "France"}`))
+	var api = chatFunc(func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+		if s := cr.ResponseFormat.JSONSchema(); s == nil || s.Name != "Answer" || !s.Strict {
+			t.Errorf("unexpected response format schema %+v", s)
+		}
+		return reply, nil
+	})
+
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Where is the Eiffel Tower?")},
+	}
+	var ctx = context.Background()
+
+	var answer, err = Respond[Answer](ctx, api, req)
+	if err != nil || answer.City != "Paris" || answer.Country != "France" {
+		t.Fatalf("unexpected answer %+v, error: %v", answer, err)
+	}
+	if req.ResponseFormat != nil {
+		t.Fatalf("expected the request not to be modified")
+	}
+
+	reply = chatResponse(&ChatMessage{Role: roles.Assistant, Refusal: "I can't help with that."})
+	if _, err = Respond[Answer](ctx, api, req); !errors.Is(err, ErrRefusal) {
+		t.Fatalf("expected ErrRefusal, got: %v", err)
+	}
+
+	reply = chatResponse(AssistantMessage(`{"city": "Pa`))
+	reply.Choices[0].FinishReason = "length"
+	if _, err = Respond[Answer](ctx, api, req); !errors.Is(err, ErrTruncated) {
+		t.Fatalf("expected ErrTruncated, got: %v", err)
+	}
+}
+
+func TestRespondJSON(t *testing.T) {
+	var replies = []string{`{"city": "Paris"`, `{"city": "Paris"}`}
+	var calls int
+	var api = chatFunc(func(ctx context.Context, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*ChatCompletionResponse[models.Chat], error) {
+		if cr.ResponseFormat != ResponseFormatJSONObject {
+			t.Errorf("expected JSON mode, got %+v", cr.ResponseFormat)
+		}
+		calls++
+		return chatResponse(AssistantMessage(replies[(calls-1)%len(replies)])), nil
+	})
+
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Where is the Eiffel Tower? Answer in JSON.")},
+	}
+	var ctx = context.Background()
+
+	var b, err = RespondJSON(ctx, api, req)
+	if err != nil || string(b) != `{"city": "Paris"}` || calls != 2 {
+		t.Fatalf("unexpected response %s after %d calls, error: %v", b, calls, err)
+	}
+
+	replies = []string{"Paris"}
+	if _, err = RespondJSON(ctx, api, req); !errors.Is(err, ErrInvalidJSON) || calls != 4 {
+		t.Fatalf("expected ErrInvalidJSON after 2 attempts, got: %v after %d calls", err, calls)
+	}
+
+	req.ResponseFormat = ResponseFormatJSONObject
+	req.Messages = []*ChatMessage{UserMessage("Where is the Eiffel Tower?")}
+	if err = req.validate(); err == nil {
+		t.Fatalf("expected a validation error for JSON mode without mentioning JSON")
+	}
+
+	req.Messages = []*ChatMessage{UserMessageParts(TextPart("Where is the Eiffel Tower? Reply in JSON."))}
+	if err = req.validate(); err != nil {
+		t.Fatalf("unexpected validation error for JSON mentioned in multi-part content: %v", err)
+	}
+}
+
+// chatStreamServer returns a test server which streams |chunks| from the chat completions endpoint, and records the
+// decoded bodies of the requests it receives in |requests|.
+func chatStreamServer(requests *[]map[string]any, chunks ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if requests != nil {
+			*requests = append(*requests, body)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, c := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", c)
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	}))
+}
+
+// testChatChunks are the chunks of a streamed chat completion with text content and a fragmented tool call.
+var testChatChunks = []string{
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"role": "assistant", "content": ""}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Let me "}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "check."}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": ""}}]}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "{\"city\": "}}]}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "\"Paris\"}"}}]}}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "system_fingerprint": "fp_1", "choices": [{"index": 0, "delta": {}, "finish_reason": "tool_calls"}]}`,
+	`{"id": "chatcmpl-1", "object": "chat.completion.chunk", "created": 1, "model": "gpt-4o", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}`,
+}
+
+func TestChatCompletionStream(t *testing.T) {
+	var requests []map[string]any
+	var ts = chatStreamServer(&requests, testChatChunks...)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var stream, err = client.CreateChatCompletionStream(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("What's the weather in Paris?")},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+	defer stream.Close()
+
+	var acc Accumulator[models.Chat]
+	var chunks int
+	for {
+		var chunk, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+		chunks++
+		acc.Add(chunk)
+	}
+
+	if len(requests) != 1 || requests[0]["stream"] != true {
+		t.Fatalf("expected a single streamed request, got: %v", requests)
+	}
+	if chunks != len(testChatChunks) {
+		t.Fatalf("expected %d chunks, got %d", len(testChatChunks), chunks)
+	}
+
+	var resp = acc.Response()
+	if resp.ID != "chatcmpl-1" || resp.Object != objects.ChatCompletion || resp.Model != models.GPT4o || resp.SystemFingerprint != "fp_1" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 15 {
+		t.Fatalf("unexpected usage %+v", resp.Usage)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].FinishReason != "tool_calls" {
+		t.Fatalf("unexpected choices %+v", resp.Choices)
+	}
+
+	var msg = resp.Choices[0].Message
+	if msg.Role != roles.Assistant || msg.Content != "Let me check." || len(msg.ToolCalls) != 1 {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+	var call = msg.ToolCalls[0]
+	if call.ID != "call_1" || call.Index != nil || call.Function.Name != "get_weather" || call.Function.Arguments != `{"city": "Paris"}` {
+		t.Fatalf("unexpected tool call %+v: %+v", call, call.Function)
+	}
+}
+
+func TestStreamChannel(t *testing.T) {
+	var ts = chatStreamServer(nil, testChatChunks...)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var stream, err = client.CreateChatCompletionStream(ctx, &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("What's the weather in Paris?")},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+
+	var acc Accumulator[models.Chat]
+	var chunks, errs = stream.Channel(ctx)
+	for chunk := range chunks {
+		acc.Add(chunk)
+	}
+	if err = <-errs; err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if got := acc.Response().Choices[0].Message.Content; got != "Let me check." {
+		t.Fatalf("unexpected content %q", got)
+	}
+
+	// Cancelling the context unblocks a pending read.
+	var pr, pw = io.Pipe()
+	go func() {
+		_, _ = io.WriteString(pw, "data: 1\n\n")
+	}()
+
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	var values, verrs = newStream[int](pr).Channel(ctx)
+	if v := <-values; v != 1 {
+		t.Fatalf("expected 1, got %d", v)
+	}
+	cancel()
+
+	for range values {
+	}
+	if err = <-verrs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestStreamTextReader(t *testing.T) {
+	var ts = chatStreamServer(nil, testChatChunks...)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var stream, err = client.CreateChatCompletionStream(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("What's the weather in Paris?")},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+	defer stream.Close()
+
+	var b strings.Builder
+	if _, err = io.Copy(&b, stream.TextReader()); err != nil {
+		t.Fatalf("Copy error: %v", err)
+	}
+	if b.String() != "Let me check." {
+		t.Fatalf("unexpected text %q", b.String())
+	}
+
+	var events = newStream[*FineTuningJobEvent](io.NopCloser(strings.NewReader(`data: {"message": "Step 1"}` + "\n\n")))
+	if _, err = io.ReadAll(events.TextReader()); err == nil {
+		t.Fatalf("expected an error reading the text of a stream without text")
+	}
+}
+
+func TestStreamIncludeUsage(t *testing.T) {
+	var requests []map[string]any
+	var ts = chatStreamServer(&requests, testChatChunks...)
+	defer ts.Close()
+
+	var tracker = NewUsageTracker()
+	var recorded []*RequestMetrics
+	var client, _ = newTestClient(ts.URL)
+	WithUsageTracker(tracker)(client)
+	WithPricing(Pricing{"gpt-4o": {Input: 1e6, Output: 1e6}})(client)
+	WithMetricsRecorder(MetricsRecorderFunc(func(_ context.Context, m *RequestMetrics) {
+		recorded = append(recorded, m)
+	}))(client)
+
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:         models.GPT4o,
+		Messages:      []*ChatMessage{UserMessage("What's the weather in Paris?")},
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+		User:          "alice",
+	}
+	var ctx = context.Background()
+
+	var stream, err = client.CreateChatCompletionStream(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err = io.ReadAll(stream.TextReader()); err != nil {
+		t.Fatalf("ReadAll error: %v", err)
+	}
+
+	var opts, _ = requests[0]["stream_options"].(map[string]any)
+	if opts["include_usage"] != true {
+		t.Fatalf("expected include_usage to be sent, got: %v", requests[0])
+	}
+	if u := stream.Usage(); u == nil || u.TotalTokens != 15 {
+		t.Fatalf("unexpected stream usage %+v", u)
+	}
+	if got := tracker.ByUser()["alice"]; got.TotalTokens != 15 || got.Cost != 15 {
+		t.Fatalf("unexpected tracked usage %+v", got)
+	}
+	if len(recorded) != 1 || recorded[0].Usage == nil || recorded[0].Usage.TotalTokens != 15 || *recorded[0].Cost != 15 {
+		t.Fatalf("unexpected metrics %+v", recorded)
+	}
+
+	// The usage is recorded for the model which was sent.
+	if stream, err = client.CreateChatCompletionStream(ctx, req, WithRequestModel("my-model")); err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+	if _, err = io.ReadAll(stream.TextReader()); err != nil {
+		t.Fatalf("ReadAll error: %v", err)
+	}
+	stream.Close()
+	if got := tracker.ByModel()["my-model"]; got.TotalTokens != 15 {
+		t.Fatalf("unexpected tracked usage %+v", tracker.ByModel())
+	}
+	if len(recorded) != 2 || recorded[1].Model != "my-model" || recorded[1].Usage == nil {
+		t.Fatalf("unexpected metrics %+v", recorded[1:])
+	}
+
+	if _, err = client.CreateChatCompletion(ctx, req); err == nil {
+		t.Fatalf("expected an error for stream_options without streaming")
+	}
+}
+
+func TestAccumulatorToolCalls(t *testing.T) {
+	var chunks = []string{
+		`{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {"role": "assistant", "tool_calls": [{"index": 0, "id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": ""}}]}}]}`,
+		`{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "{\"city\": \"Paris\"}"}}]}}]}`,
+		`{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 1, "id": "call_2", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\": "}}]}}]}`,
+		`{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 1, "function": {"arguments": "\"Rome\"}"}}]}}]}`,
+		`{"id": "chatcmpl-1", "choices": [{"index": 0, "delta": {}, "finish_reason": "tool_calls"}]}`,
+	}
+
+	var acc Accumulator[models.Chat]
+	var completed []string
+	for i, c := range chunks {
+		var chunk = &ChatCompletionChunk[models.Chat]{}
+		if err := json.Unmarshal([]byte(c), chunk); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+
+		for _, call := range acc.Add(chunk) {
+			if !json.Valid([]byte(call.Function.Arguments)) {
+				t.Fatalf("incomplete arguments %q for %s", call.Function.Arguments, call.ID)
+			}
+			completed = append(completed, fmt.Sprintf("%d:%s=%s", i, call.ID, call.Function.Arguments))
+		}
+	}
+
+	var want = []string{`2:call_1={"city": "Paris"}`, `4:call_2={"city": "Rome"}`}
+	if !reflect.DeepEqual(completed, want) {
+		t.Fatalf("expected completed calls %v, got %v", want, completed)
+	}
+
+	if calls := acc.Response().Choices[0].Message.ToolCalls; len(calls) != 2 || calls[1].Function.Arguments != `{"city": "Rome"}` {
+		t.Fatalf("unexpected tool calls %+v", calls)
+	}
+}
+
+func TestChatLogProbs(t *testing.T) {
+	var requests []map[string]any
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"index": 0, "message": {"role": "assistant", "content": "Yes"},
+			"logprobs": {"content": [{"token": "Yes", "logprob": -0.01, "bytes": [89, 101, 115], "top_logprobs": [
+				{"token": "Yes", "logprob": -0.01, "bytes": [89, 101, 115]},
+				{"token": "No", "logprob": -4.6, "bytes": [78, 111]}
+			]}], "refusal": null}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:       models.GPT4o,
+		Messages:    []*ChatMessage{UserMessage("Is Paris in France?")},
+		LogProbs:    true,
+		TopLogProbs: 2,
+	}
+
+	var resp, err = client.CreateChatCompletion(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if requests[0]["logprobs"] != true || requests[0]["top_logprobs"] != float64(2) {
+		t.Fatalf("unexpected request %v", requests[0])
+	}
+
+	var lp = resp.Choices[0].LogProbs
+	if lp == nil || len(lp.Content) != 1 || len(lp.Content[0].TopLogProbs) != 2 {
+		t.Fatalf("unexpected logprobs %+v", lp)
+	}
+	var tok = lp.Content[0]
+	if tok.Token != "Yes" || string(rune(tok.Bytes[0])) != "Y" || tok.TopLogProbs[1].Token != "No" {
+		t.Fatalf("unexpected token %+v", tok)
+	}
+	if p := tok.Probability(); p < 0.98 || p > 1 {
+		t.Fatalf("unexpected probability %f", p)
+	}
+
+	for _, bad := range []*ChatCompletionRequest[models.Chat]{
+		{Model: models.GPT4o, Messages: req.Messages, TopLogProbs: 2},
+		{Model: models.GPT4o, Messages: req.Messages, LogProbs: true, TopLogProbs: 21},
+	} {
+		if err = bad.validate(); err == nil {
+			t.Fatalf("expected a validation error for %+v", bad)
+		}
+	}
+}
+
+func TestChatSeed(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Seed *int64 `json:"seed"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Seed == nil || *body.Seed != 42 {
+			t.Errorf("unexpected seed %v", body.Seed)
+		}
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "system_fingerprint": "fp_44709d6fcb", "choices": [
+			{"index": 0, "message": {"role": "assistant", "content": "Hello"}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var seed int64 = 42
+	var resp, err = client.CreateChatCompletion(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Hi")},
+		Seed:     &seed,
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if resp.SystemFingerprint != "fp_44709d6fcb" {
+		t.Fatalf("unexpected system fingerprint %q", resp.SystemFingerprint)
+	}
+}
+
+func TestReasoningModels(t *testing.T) {
+	var requests []map[string]any
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "4"}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var messages = []*ChatMessage{UserMessage("What is 2 + 2?")}
+
+	var req = &ChatCompletionRequest[models.Chat]{Model: models.O3Mini, Messages: messages, MaxTokens: 100}
+	if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if _, ok := requests[0]["max_tokens"]; ok || requests[0]["max_completion_tokens"] != float64(100) {
+		t.Fatalf("expected max_tokens to be sent as max_completion_tokens, got: %v", requests[0])
+	}
+	if req.MaxTokens != 100 || req.MaxCompletionTokens != 0 {
+		t.Fatalf("expected the request not to be modified, got: %+v", req)
+	}
+
+	req = &ChatCompletionRequest[models.Chat]{Model: models.GPT4o, Messages: messages, MaxTokens: 100}
+	if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if requests[1]["max_tokens"] != float64(100) {
+		t.Fatalf("expected max_tokens to be sent for non-reasoning models, got: %v", requests[1])
+	}
+
+	var temperature = 0.2
+	for _, bad := range []*ChatCompletionRequest[models.Chat]{
+		{Model: models.O1, Messages: messages, Temperature: &temperature},
+		{Model: models.O1Mini, Messages: messages, LogProbs: true},
+		{Model: models.GPT4o, Messages: messages, MaxTokens: 10, MaxCompletionTokens: 10},
+	} {
+		if _, err := client.CreateChatCompletion(ctx, bad); err == nil {
+			t.Fatalf("expected a validation error for %+v", bad)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected invalid requests not to be sent, got %d requests", len(requests))
+	}
+
+	for model, want := range map[string]bool{
+		"o1": true, "o1-mini": true, "o3-mini-2025-01-31": true, "ft:o1-mini:org::id": true, "gpt-4o": false, "omni": false,
+	} {
+		if got := reasoningModel(model); got != want {
+			t.Errorf("expected reasoningModel(%q) to be %t", model, want)
+		}
+	}
+}
+
+func TestReasoningEffort(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["reasoning_effort"] != "high" {
+			t.Errorf("unexpected reasoning_effort %v", body["reasoning_effort"])
+		}
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "4"}}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 200, "total_tokens": 210,
+				"completion_tokens_details": {"reasoning_tokens": 192}}}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var effort = reasoning.EffortHigh
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:           models.O3Mini,
+		Messages:        []*ChatMessage{UserMessage("What is 2 + 2?")},
+		ReasoningEffort: &effort,
+	}
+
+	var resp, err = client.CreateChatCompletion(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if got := resp.Usage.ReasoningTokens(); got != 192 {
+		t.Fatalf("expected 192 reasoning tokens, got %d", got)
+	}
+	if got := (&Usage{}).ReasoningTokens(); got != 0 {
+		t.Fatalf("expected 0 reasoning tokens without details, got %d", got)
+	}
+
+	req.Model = models.GPT4o
+	if err = req.validate(); err == nil {
+		t.Fatalf("expected a validation error for reasoning_effort with %v", req.Model)
+	}
+}
+
+func TestDeveloperRole(t *testing.T) {
+	var roleSeq []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Role string `json:"role"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		var got []string
+		for _, m := range body.Messages {
+			got = append(got, m.Role)
+		}
+		roleSeq = append(roleSeq, strings.Join(got, ","))
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "Bonjour"}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var messages = []*ChatMessage{SystemMessage("Answer in French."), DeveloperMessage("Be brief."), UserMessage("Hello")}
+
+	for _, model := range []models.Chat{models.GPT4o, models.O1} {
+		var req = &ChatCompletionRequest[models.Chat]{Model: model, Messages: messages}
+		if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+			t.Fatalf("CreateChatCompletion error: %v", err)
+		}
+	}
+
+	var want = []string{"system,developer,user", "developer,developer,user"}
+	if !reflect.DeepEqual(roleSeq, want) {
+		t.Fatalf("expected roles %v, got %v", want, roleSeq)
+	}
+	if messages[0].Role != roles.System {
+		t.Fatalf("expected the messages not to be modified")
+	}
+}
+
+func TestChatContentParts(t *testing.T) {
+	// A 1x1 transparent PNG.
+	var png, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII=")
+	var path = filepath.Join(t.TempDir(), "pixel.png")
+	if err := os.WriteFile(path, png, 0o600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	var image, err = ImageFilePart(path, content.DetailLow)
+	if err != nil {
+		t.Fatalf("ImageFilePart error: %v", err)
+	}
+	if !strings.HasPrefix(image.ImageURL.URL, "data:image/png;base64,iVBORw0KGgo") {
+		t.Fatalf("unexpected image URL %.40s", image.ImageURL.URL)
+	}
+
+	var msg = UserMessageParts(TextPart("What's in this image?"), ImagePart("https://example.com/cat.jpg", content.DetailHigh))
+	var b []byte
+	if b, err = json.Marshal(msg); err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var want = `{"role":"user","content":[{"type":"text","text":"What's in this image?"},` +
+		`{"type":"image_url","image_url":{"url":"https://example.com/cat.jpg","detail":"high"}}]}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+
+	var got = &ChatMessage{}
+	if err = json.Unmarshal(b, got); err != nil || len(got.Parts) != 2 || got.Content != "" {
+		t.Fatalf("expected %s to round trip, got %+v (error: %v)", b, got, err)
+	}
+	if got.Parts[1].ImageURL.Detail != content.DetailHigh || got.Text() != "What's in this image?" || got.textOnly() {
+		t.Fatalf("unexpected parts %+v", got.Parts)
+	}
+
+	if b, err = json.Marshal(UserMessage("Hi")); err != nil || string(b) != `{"role":"user","content":"Hi"}` {
+		t.Fatalf("unexpected text message %s (error: %v)", b, err)
+	}
+	if err = json.Unmarshal([]byte(`{"role":"assistant","content":null}`), got); err != nil || got.Parts != nil || got.Content != "" {
+		t.Fatalf("unexpected message %+v (error: %v)", got, err)
+	}
+
+	if _, err = ImageReaderPart(strings.NewReader("not an image"), content.DetailAuto); err == nil {
+		t.Fatalf("expected an error for an unsupported image format")
+	}
+}
+
+func TestChatAudio(t *testing.T) {
+	var wav = []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Modalities []string       `json:"modalities"`
+			Audio      map[string]any `json:"audio"`
+			Messages   []*ChatMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if strings.Join(body.Modalities, ",") != "text,audio" || body.Audio["voice"] != "alloy" || body.Audio["format"] != "wav" {
+			t.Errorf("unexpected audio output parameters %v, %v", body.Modalities, body.Audio)
+		}
+		var part = body.Messages[0].Parts[0]
+		if part.Type != content.TypeInputAudio || part.InputAudio.Format != audio.FormatWAV ||
+			part.InputAudio.Data != base64.StdEncoding.EncodeToString(wav) {
+			t.Errorf("unexpected input audio part %+v", part)
+		}
+
+		fmt.Fprintf(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": null,
+			"audio": {"id": "audio_1", "data": %q, "expires_at": 1729234747, "transcript": "Hello!"}}}]}`,
+			base64.StdEncoding.EncodeToString(wav))
+	}))
+	defer ts.Close()
+
+	var path = filepath.Join(t.TempDir(), "question.wav")
+	if err := os.WriteFile(path, wav, 0o600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	var part, err = InputAudioFilePart(path)
+	if err != nil {
+		t.Fatalf("InputAudioFilePart error: %v", err)
+	}
+
+	var client, _ = newTestClient(ts.URL)
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:      models.GPT4oAudioPreview,
+		Messages:   []*ChatMessage{UserMessageParts(part)},
+		Modalities: []content.Modality{content.ModalityText, content.ModalityAudio},
+		Audio:      &AudioOutput{Voice: audio.VoiceAlloy, Format: audio.FormatWAV},
+	}
+
+	var resp *ChatCompletionResponse[models.Chat]
+	if resp, err = client.CreateChatCompletion(context.Background(), req); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+
+	var a = resp.Choices[0].Message.Audio
+	if a == nil || a.ID != "audio_1" || a.Transcript != "Hello!" {
+		t.Fatalf("unexpected audio %+v", a)
+	}
+	var b []byte
+	if b, err = io.ReadAll(a.Reader()); err != nil || !bytes.Equal(b, wav) {
+		t.Fatalf("unexpected audio data %q (error: %v)", b, err)
+	}
+
+	req.Audio = nil
+	if err = req.validate(); err == nil {
+		t.Fatalf("expected a validation error for the audio modality without audio parameters")
+	}
+	if _, err = InputAudioFilePart("question.ogg"); err == nil {
+		t.Fatalf("expected an error for an unsupported audio format")
+	}
+}
+
+func TestPrediction(t *testing.T) {
+	var code = "class User {\n  firstName: string;\n  username: string;\n}\n"
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Prediction *Prediction `json:"prediction"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Prediction == nil || body.Prediction.Type != "content" || body.Prediction.Content != code {
+			t.Errorf("unexpected prediction %+v", body.Prediction)
+		}
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "..."}}],
+			"usage": {"prompt_tokens": 50, "completion_tokens": 30, "total_tokens": 80,
+				"completion_tokens_details": {"reasoning_tokens": 0, "accepted_prediction_tokens": 18, "rejected_prediction_tokens": 10}}}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var req = &ChatCompletionRequest[models.Chat]{
+		Model:      models.GPT4o,
+		Messages:   []*ChatMessage{UserMessage("Replace the username property with an email property."), UserMessage(code)},
+		Prediction: PredictedContent(code),
+	}
+
+	var resp, err = client.CreateChatCompletion(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if d := resp.Usage.CompletionTokensDetails; d == nil || d.AcceptedPredictionTokens != 18 || d.RejectedPredictionTokens != 10 {
+		t.Fatalf("unexpected completion tokens details %+v", d)
+	}
+
+	req.N = 2
+	if err = req.validate(); err == nil {
+		t.Fatalf("expected a validation error for a prediction with n > 1")
+	}
+}
+
+func TestServiceTier(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["service_tier"] != "flex" {
+			t.Errorf("unexpected service_tier %v", body["service_tier"])
+		}
+
+		_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "service_tier": "flex", "choices": [
+			{"message": {"role": "assistant", "content": "Hello"}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateChatCompletion(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:       models.O3Mini,
+		Messages:    []*ChatMessage{UserMessage("Hi")},
+		ServiceTier: tiers.Flex,
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	if resp.ServiceTier != tiers.Flex {
+		t.Fatalf("unexpected service tier %v", resp.ServiceTier)
+	}
+
+	var b []byte
+	if b, err = json.Marshal(&ChatCompletionRequest[models.Chat]{Model: models.GPT4o}); err != nil || strings.Contains(string(b), "service_tier") {
+		t.Fatalf("expected service_tier to be omitted by default, got %s (error: %v)", b, err)
+	}
+}
+
+func TestStoredChatCompletions(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/chat/completions":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["store"] != true || !reflect.DeepEqual(body["metadata"], map[string]any{"project": "eval"}) {
+				t.Errorf("unexpected store parameters %v", body)
+			}
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/chat/completions":
+			if got := r.URL.RawQuery; got != "limit=10&metadata%5Bproject%5D=eval&model=gpt-4o" {
+				t.Errorf("unexpected query %s", got)
+			}
+			_, _ = io.WriteString(w, `{"object": "list", "data": [{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4o",
+				"metadata": {"project": "eval"}, "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}],
+				"first_id": "chatcmpl-1", "last_id": "chatcmpl-1", "has_more": false}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/chat/completions/chatcmpl-1":
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "object": "chat.completion", "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/chat/completions/chatcmpl-1":
+			_, _ = io.WriteString(w, `{"object": "chat.completion.deleted", "id": "chatcmpl-1", "deleted": true}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var _, err = client.CreateChatCompletion(ctx, &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Hello")},
+		Store:    true,
+		Metadata: map[string]string{"project": "eval"},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+
+	var l *List[*ChatCompletionResponse[models.Chat]]
+	if l, err = client.ListChatCompletions(ctx, &ChatCompletionsListRequest{
+		Model:    "gpt-4o",
+		Metadata: map[string]string{"project": "eval"},
+		Limit:    10,
+	}); err != nil {
+		t.Fatalf("ListChatCompletions error: %v", err)
+	}
+	if len(l.Data) != 1 || l.Data[0].Metadata["project"] != "eval" || l.LastID != "chatcmpl-1" {
+		t.Fatalf("unexpected list %+v", l)
+	}
+
+	var resp *ChatCompletionResponse[models.Chat]
+	if resp, err = client.RetrieveChatCompletion(ctx, "chatcmpl-1"); err != nil || resp.Choices[0].Message.Content != "Hi" {
+		t.Fatalf("unexpected stored completion %+v, error: %v", resp, err)
+	}
+
+	var del *DeletionResponse
+	if del, err = client.DeleteChatCompletion(ctx, "chatcmpl-1"); err != nil || !del.Deleted || del.Object != objects.ChatCompletionDeleted {
+		t.Fatalf("unexpected deletion %+v, error: %v", del, err)
+	}
+
+	var metadata = map[string]string{}
+	for i := 0; i < 17; i++ {
+		metadata[strconv.Itoa(i)] = "v"
+	}
+	var req = &ChatCompletionRequest[models.Chat]{Model: models.GPT4o, Messages: []*ChatMessage{UserMessage("Hello")}, Metadata: metadata}
+	if err = req.validate(); err == nil {
+		t.Fatalf("expected a validation error for more than 16 metadata pairs")
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	var mu sync.Mutex
+	var used []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		mu.Lock()
+		used = append(used, key)
+		mu.Unlock()
+
+		if key == "key-b" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"error": {"message": "quota exceeded", "type": "insufficient_quota", "code": "insufficient_quota"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "gpt-4o"}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithAPIKeys([]string{"key-a", "key-b", "key-c"}, nil)(client)
+	WithRetry(&RetryConfig{InitialBackoff: time.Millisecond})(client)
+
+	var ctx = context.Background()
+	for i := 0; i < 4; i++ {
+		if _, err := client.RetrieveModel(ctx, "gpt-4o"); err != nil {
+			t.Fatalf("RetrieveModel error: %v", err)
+		}
+	}
+
+	// key-b is demoted after its quota error, and the request is retried with key-c.
+	var want = []string{"key-a", "key-b", "key-c", "key-a", "key-c"}
+	if !reflect.DeepEqual(used, want) {
+		t.Fatalf("expected keys %v, got %v", want, used)
+	}
+
+	var p = newKeyPool([]string{"a", "b"}, &KeyPoolConfig{Selection: LeastLoaded})
+	var a = p.acquire()
+	if b := p.acquire(); b.value != "b" {
+		t.Fatalf("expected the second key, got %s", b.value)
+	}
+	if k := p.acquire(); k.value != "a" {
+		t.Fatalf("expected ties to be broken in round-robin order, got %s", k.value)
+	}
+	p.release(a, nil)
+	p.release(a, nil)
+	if k := p.acquire(); k.value != "a" {
+		t.Fatalf("expected the least loaded key, got %s", k.value)
+	}
+}
+
+func TestBaseURLFailover(t *testing.T) {
+	var mu sync.Mutex
+	var hits []string
+	var handler = func(name string, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["model"] != "gpt-4o" {
+				t.Errorf("unexpected body sent to %s: %v (error: %v)", name, body, err)
+			}
+
+			mu.Lock()
+			hits = append(hits, name+r.URL.Path)
+			mu.Unlock()
+
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}`)
+		}
+	}
+
+	var primary = httptest.NewServer(handler("primary", http.StatusServiceUnavailable))
+	defer primary.Close()
+	var fallback = httptest.NewServer(handler("fallback", http.StatusOK))
+	defer fallback.Close()
+
+	var client = NewClient(testToken, WithBaseURLs([]string{primary.URL + "/openai/v1", fallback.URL + "/v1"}, &FailoverConfig{
+		Cooldown: 50 * time.Millisecond,
+	}))
+	var ctx = context.Background()
+	var req = &ChatCompletionRequest[models.Chat]{Model: models.GPT4o, Messages: []*ChatMessage{UserMessage("Hi")}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+			t.Fatalf("CreateChatCompletion error: %v", err)
+		}
+	}
+
+	// The primary is skipped during its cooldown, and tried again once it has elapsed.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.CreateChatCompletion(ctx, req); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+
+	var want = []string{
+		"primary/openai/v1/chat/completions", "fallback/v1/chat/completions",
+		"fallback/v1/chat/completions",
+		"primary/openai/v1/chat/completions", "fallback/v1/chat/completions",
+	}
+	if !reflect.DeepEqual(hits, want) {
+		t.Fatalf("expected requests %v, got %v", want, hits)
+	}
+
+	// A request for a specific base URL is not failed over.
+	if _, err := client.CreateChatCompletion(ctx, req, WithRequestBaseURL(primary.URL+"/openai/v1")); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected ErrServer, got: %v", err)
+	}
+}
+
+func TestModelRoute(t *testing.T) {
+	var hits []string
+	var handler = func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			hits = append(hits, fmt.Sprintf("%s %s %v", name, r.Header.Get("Authorization"), body["model"]))
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}`)
+		}
+	}
+
+	var openai = httptest.NewServer(handler("openai"))
+	defer openai.Close()
+	var proxy = httptest.NewServer(handler("proxy"))
+	defer proxy.Close()
+
+	var client = NewClient(testToken,
+		WithBaseURL(openai.URL+"/v1"),
+		WithModelRoute("llama-*", NewClient("proxy-key", WithBaseURL(proxy.URL+"/v1"))),
+	)
+	var ctx = context.Background()
+
+	if _, err := client.CreateChatCompletion(ctx, &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Hi")},
+	}); err != nil {
+		t.Fatalf("CreateChatCompletion error: %v", err)
+	}
+	for _, m := range []models.FineTunedModel{"llama-3-70b", "ft:llama-3-8b:org::abc"} {
+		if _, err := client.CreateFineTunedChatCompletion(ctx, &ChatCompletionRequest[models.FineTunedModel]{
+			Model:    m,
+			Messages: []*ChatMessage{UserMessage("Hi")},
+		}); err != nil {
+			t.Fatalf("CreateFineTunedChatCompletion error: %v", err)
+		}
+	}
+
+	var want = []string{
+		"openai Bearer " + testToken + " gpt-4o",
+		"proxy Bearer proxy-key llama-3-70b",
+		"proxy Bearer proxy-key ft:llama-3-8b:org::abc",
+	}
+	if !reflect.DeepEqual(hits, want) {
+		t.Fatalf("expected requests %v, got %v", want, hits)
+	}
+
+	// Routes back to the original Client are not followed, and nil targets are ignored.
+	var target = NewClient("proxy-key", WithBaseURL(proxy.URL+"/v1"), WithModelRoute("llama-*", nil))
+	client = NewClient(testToken, WithBaseURL(openai.URL+"/v1"), WithModelRoute("llama-*", target))
+	WithModelRoute("llama-*", client)(target)
+
+	hits = nil
+	if _, err := client.CreateFineTunedChatCompletion(ctx, &ChatCompletionRequest[models.FineTunedModel]{
+		Model:    "llama-3-70b",
+		Messages: []*ChatMessage{UserMessage("Hi")},
+	}); err != nil {
+		t.Fatalf("CreateFineTunedChatCompletion error: %v", err)
+	}
+	if want = []string{"proxy Bearer proxy-key llama-3-70b"}; !reflect.DeepEqual(hits, want) {
+		t.Fatalf("expected requests %v, got %v", want, hits)
+	}
+	if len(target.routes) != 1 {
+		t.Fatalf("expected the nil target to be ignored, got %d routes", len(target.routes))
+	}
+}
+
+func TestCompatibilityMode(t *testing.T) {
+	var sent []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v, ok := r.Header["Authorization"]; ok {
+			t.Errorf("unexpected Authorization header: %v", v)
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, fmt.Sprint(body["model"]))
+
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			if body["reasoning_effort"] != "high" || body["max_tokens"] != float64(10) {
+				t.Errorf("unexpected request body: %v", body)
+			}
+			_, _ = io.WriteString(w, `{"id": "chatcmpl-1", "model": "qwen3", "system_fingerprint": "b1",
+				"choices": [{"message": {"role": "assistant", "content": "Hi", "reasoning_content": "..."},
+				"finish_reason": "stop", "stop_reason": null}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error": "model \"nomic-embed-text\" not found, try pulling it first"}`)
+		}
+	}))
+	defer ts.Close()
+
+	var client = NewClient("", WithBaseURL(ts.URL+"/v1"), WithCompatibilityMode())
+	var ctx = context.Background()
+
+	// Without compatibility mode, reasoning_effort is rejected for models which are not OpenAI reasoning models.
+	var effort = reasoning.EffortHigh
+	var resp, err = client.CreateFineTunedChatCompletion(ctx, &ChatCompletionRequest[models.FineTunedModel]{
+		Model:           "qwen3",
+		Messages:        []*ChatMessage{UserMessage("Hi")},
+		MaxTokens:       10,
+		ReasoningEffort: &effort,
+	})
+	if err != nil {
+		t.Fatalf("CreateFineTunedChatCompletion error: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "Hi" {
+		t.Fatalf("unexpected response: %+v", resp.Choices[0].Message)
+	}
+
+	_, err = client.CreateEmbeddings(ctx, &EmbeddingRequest{Input: []string{"Hi"}}, WithRequestModel("nomic-embed-text"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != `model "nomic-embed-text" not found, try pulling it first` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"qwen3", "nomic-embed-text"}; !reflect.DeepEqual(sent, want) {
+		t.Fatalf("expected models %v, got %v", want, sent)
+	}
+}
+
+func TestProvider(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			_, _ = fmt.Fprintf(w, `{"id": "chatcmpl-1", "model": %q, "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}`, body["model"])
+		case "/v1/embeddings":
+			if body["model"] != "text-embedding-3-small" {
+				t.Errorf("unexpected model %v", body["model"])
+			}
+			_, _ = io.WriteString(w, `{"data": [{"index": 0, "embedding": [0.5, 0.25]}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var providers = []Provider{client, &ProviderFuncs{
+		ChatFunc: func(_ context.Context, cr *ChatCompletionRequest[models.FineTunedModel], _ ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error) {
+			return &ChatCompletionResponse[models.FineTunedModel]{
+				Model:   cr.Model,
+				Choices: []*ChatCompletionChoice{{Message: AssistantMessage("Hi")}},
+			}, nil
+		},
+	}}
+
+	var ctx = context.Background()
+	for _, p := range providers {
+		var resp, err = p.Chat(ctx, &ChatCompletionRequest[models.FineTunedModel]{
+			Model:    "gpt-4o",
+			Messages: []*ChatMessage{UserMessage("Hi")},
+		})
+		if err != nil {
+			t.Fatalf("%T: Chat error: %v", p, err)
+		}
+		if resp.Model != "gpt-4o" || resp.Choices[0].Message.Content != "Hi" {
+			t.Fatalf("%T: unexpected response: %+v", p, resp)
+		}
+	}
+
+	var vectors, err = client.Embed(ctx, "text-embedding-3-small", []string{"Hi"})
+	if err != nil {
+		t.Fatalf("Embed error: %v", err)
+	}
+	if want := [][]float64{{0.5, 0.25}}; !reflect.DeepEqual(vectors, want) {
+		t.Fatalf("expected vectors %v, got %v", want, vectors)
+	}
+
+	if _, err = providers[1].Embed(ctx, "text-embedding-3-small", []string{"Hi"}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	var encodings []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("unexpected Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			var zr, err = gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("gzip.NewReader error: %v", err)
+			}
+			body = zr
+		}
+		var req EmbeddingRequest
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		var zw = gzip.NewWriter(w)
+		_, _ = fmt.Fprintf(zw, `{"data": [{"index": 0, "embedding": [%d]}]}`, len(req.Input[0]))
+		_ = zw.Close()
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithCompression(nil)(client)
+	var ctx = context.Background()
+
+	for _, input := range []string{"short", strings.Repeat("long ", 500)} {
+		var resp, err = client.CreateEmbeddings(ctx, &EmbeddingRequest{Input: []string{input}})
+		if err != nil {
+			t.Fatalf("CreateEmbeddings error: %v", err)
+		}
+		if got := resp.Vectors()[0][0]; got != float64(len(input)) {
+			t.Fatalf("expected %d, got %v", len(input), got)
+		}
+	}
+
+	if want := []string{"", "gzip"}; !reflect.DeepEqual(encodings, want) {
+		t.Fatalf("expected request encodings %v, got %v", want, encodings)
+	}
+}
+
+func TestTransportConfig(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data": [{"index": 0, "embedding": [1]}]}`)
+	}))
+	defer ts.Close()
+
+	var _, port, _ = net.SplitHostPort(ts.Listener.Addr().String())
+	var client = NewClient(testToken, WithBaseURL("http://localhost:"+port+"/v1"), WithTransportConfig(&TransportConfig{
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     time.Minute,
+		DisableHTTP2:        true,
+		DNSCacheTTL:         time.Minute,
+	}))
+
+	var tr = client.httpClient.Transport.(*http.Transport)
+	if tr == http.DefaultTransport || tr.MaxIdleConnsPerHost != 64 || tr.IdleConnTimeout != time.Minute ||
+		tr.MaxIdleConns != http.DefaultTransport.(*http.Transport).MaxIdleConns || tr.TLSNextProto == nil {
+		t.Fatalf("unexpected transport settings: %+v", tr)
+	}
+
+	if _, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{Input: []string{"Hi"}}); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	// A nil config leaves the client untouched.
+	if client = NewClient(testToken, WithTransportConfig(nil)); client.httpClient != nil {
+		t.Fatalf("unexpected HTTP client: %+v", client.httpClient)
+	}
+}
+
+// countingCodec is a Codec which counts its calls.
+type countingCodec struct {
+	StdCodec
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	return c.StdCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return c.StdCodec.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	var ts = chatStreamServer(nil, testChatChunks...)
+	defer ts.Close()
+
+	var codec = &countingCodec{}
+	var client, _ = newTestClient(ts.URL)
+	WithCodec(codec)(client)
+
+	var s, err = client.CreateChatCompletionStream(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("Hi")},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+	defer s.Close()
+
+	for {
+		if _, err = s.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+	}
+
+	if codec.marshals != 1 || codec.unmarshals != len(testChatChunks) {
+		t.Fatalf("expected 1 marshal and %d unmarshals, got %d and %d", len(testChatChunks), codec.marshals, codec.unmarshals)
+	}
+}
+
+func TestStreamLongEvents(t *testing.T) {
+	var long = strings.Repeat("x", 10000)
+	var body = fmt.Sprintf("data: %q\r\n\r\ndata: \"short\"\n\ndata: [DONE]\n\n", long)
+
+	var s = newStream[string](io.NopCloser(strings.NewReader(body)))
+	for _, want := range []string{long, "short"} {
+		if v, err := s.Recv(); err != nil || v != want {
+			t.Fatalf("expected %.10q, got %.10q (error: %v)", want, v, err)
+		}
+	}
+	if _, err := s.Recv(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestStreamEventParsing(t *testing.T) {
+	var body = ": ping\r\rdata: \"a\r\r" +
+		"event: message\nid: 1\ndata:{\"b\":\ndata: 1}\n\n" +
+		"retry: 10\n\n" +
+		"data\n\n" +
+		"data: \"c\""
+
+	var s = newStream[json.RawMessage](io.NopCloser(strings.NewReader(body)))
+	for _, want := range []string{`"a`, "{\"b\":\n1}", ``, `"c"`} {
+		var v, err = s.Recv()
+		if want == `"a` || want == `` {
+			// Neither is valid JSON, but both must be framed as events.
+			if err == nil {
+				t.Fatalf("expected a decoding error for %q", want)
+			}
+			continue
+		}
+		if err != nil || string(v) != want {
+			t.Fatalf("expected %q, got %q (error: %v)", want, v, err)
+		}
+	}
+	if _, err := s.Recv(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestStreamRecvInto(t *testing.T) {
+	var s = newStream[*ChatCompletionChunk[models.Chat]](io.NopCloser(strings.NewReader(
+		"data: " + testChatChunks[6] + "\n\ndata: " + testChatChunks[7] + "\n\n")))
+
+	var chunk = &ChatCompletionChunk[models.Chat]{}
+	var first = chunk
+	if err := s.RecvInto(&chunk); err != nil {
+		t.Fatalf("RecvInto error: %v", err)
+	}
+	if chunk.SystemFingerprint != "fp_1" || len(chunk.Choices) != 1 {
+		t.Fatalf("unexpected chunk: %+v", chunk)
+	}
+
+	if err := s.RecvInto(&chunk); err != nil {
+		t.Fatalf("RecvInto error: %v", err)
+	}
+	if chunk != first {
+		t.Fatal("expected the chunk to be reused")
+	}
+	if chunk.SystemFingerprint != "" || len(chunk.Choices) != 0 || chunk.Usage == nil || chunk.Usage.TotalTokens != 15 {
+		t.Fatalf("unexpected chunk: %+v", chunk)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper which calls itself.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// benchmarkStreamBody returns a stream of 100 copies of testChatChunks.
+func benchmarkStreamBody() string {
+	var sb strings.Builder
+	for i := 0; i < 100; i++ {
+		for _, c := range testChatChunks {
+			fmt.Fprintf(&sb, "data: %s\n\n", c)
+		}
+	}
+	sb.WriteString("data: [DONE]\n\n")
+
+	return sb.String()
+}
+
+func BenchmarkStreamRecv(b *testing.B) {
+	var body = benchmarkStreamBody()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		var s = newStream[*ChatCompletionChunk[models.Chat]](io.NopCloser(strings.NewReader(body)))
+		for {
+			if _, err := s.Recv(); err == io.EOF {
+				break
+			} else if err != nil {
+				b.Fatalf("Recv error: %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkStreamRecvInto(b *testing.B) {
+	var body = benchmarkStreamBody()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		var s = newStream[*ChatCompletionChunk[models.Chat]](io.NopCloser(strings.NewReader(body)))
+		var chunk = &ChatCompletionChunk[models.Chat]{}
+		for {
+			if err := s.RecvInto(&chunk); err == io.EOF {
+				break
+			} else if err != nil {
+				b.Fatalf("RecvInto error: %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkCreateEmbeddings(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"object": "list", "data": [`)
+	for i := 0; i < 16; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"object": "embedding", "index": %d, "embedding": [`, i)
+		for j := 0; j < 1536; j++ {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("-0.0123456789")
+		}
+		sb.WriteString("]}")
+	}
+	sb.WriteString(`], "model": "text-embedding-3-small", "usage": {"prompt_tokens": 16, "total_tokens": 16}}`)
+	var body = sb.String()
+
+	var client = NewClient(testToken, WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}))
+	var req = &EmbeddingRequest{Input: []string{"Hi"}, Model: models.AdaEmbeddingV2}
+	var ctx = context.Background()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CreateEmbeddings(ctx, req); err != nil {
+			b.Fatalf("CreateEmbeddings error: %v", err)
+		}
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	var queries []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		_, _ = io.WriteString(w, `{"object": "list", "data": [{"id": "file-2"}], "first_id": "file-2", "last_id": "file-2", "has_more": true}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var l, err = client.ListFiles(ctx, WithListOptions(&ListOptions{Limit: 1, Order: OrderAsc, After: "file-1"}))
+	if err != nil {
+		t.Fatalf("ListFiles error: %v", err)
+	}
+	if !l.HasMore || l.LastID != "file-2" {
+		t.Fatalf("unexpected list: %+v", l)
+	}
+
+	// Options are merged with the query parameters of the endpoint.
+	if _, err = client.ListChatCompletions(ctx, &ChatCompletionsListRequest{Model: "gpt-4o", Limit: 5},
+		WithListOptions(&ListOptions{Limit: 10, Before: "chatcmpl-1"})); err != nil {
+		t.Fatalf("ListChatCompletions error: %v", err)
+	}
+
+	var want = []string{
+		"/v1/files?after=file-1&limit=1&order=asc",
+		"/v1/chat/completions?before=chatcmpl-1&limit=10&model=gpt-4o",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Fatalf("expected requests %v, got %v", want, queries)
+	}
+}
+
+// pagedFilesServer serves |n| files, file-1 to file-|n|, with cursor pagination.
+func pagedFilesServer(n int, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+
+		var start = 1
+		if after := r.URL.Query().Get("after"); after != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(after, "file-"))
+			start++
+		}
+		var limit = 20
+		if v := r.URL.Query().Get("limit"); v != "" {
+			limit, _ = strconv.Atoi(v)
+		}
+
+		var l = &List[*File]{}
+		for i := start; i <= n && i < start+limit; i++ {
+			l.Data = append(l.Data, &File{ID: fmt.Sprintf("file-%d", i)})
+		}
+		l.HasMore = start+limit <= n
+		_ = json.NewEncoder(w).Encode(l)
+	}))
+}
+
+func TestAutoPager(t *testing.T) {
+	var queries []string
+	var ts = pagedFilesServer(5, &queries)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var ids []string
+	var pager = NewAutoPager(ctx, client.ListFiles, &ListOptions{Limit: 2}, 0)
+	for pager.Next() {
+		ids = append(ids, pager.Current().ID)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("AutoPager error: %v", err)
+	}
+	if want := []string{"file-1", "file-2", "file-3", "file-4", "file-5"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	// Without a last_id, the cursor is the ID of the last file of each page.
+	if want := []string{"limit=2", "after=file-2&limit=2", "after=file-4&limit=2"}; !reflect.DeepEqual(queries, want) {
+		t.Fatalf("expected queries %v, got %v", want, queries)
+	}
+
+	// The page size is reduced so as not to fetch files beyond the limit.
+	queries, ids = nil, nil
+	pager = NewAutoPager(ctx, client.ListFiles, nil, 3)
+	for pager.Next() {
+		ids = append(ids, pager.Current().ID)
+	}
+	if want := []string{"file-1", "file-2", "file-3"}; !reflect.DeepEqual(ids, want) || len(queries) != 1 || queries[0] != "limit=3" {
+		t.Fatalf("expected %v in one request, got %v with queries %v", want, ids, queries)
+	}
+
+	var cancelled, cancel = context.WithCancel(ctx)
+	cancel()
+	pager = NewAutoPager(cancelled, client.ListFiles, nil, 0)
+	if pager.Next() || !errors.Is(pager.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", pager.Err())
+	}
+}
+
+func TestUploadProgress(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = io.WriteString(w, `{"id": "file-1"}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var data = strings.Repeat("x", 1<<20)
+
+	var calls int
+	var sent, total int64
+	var _, err = client.UploadFile(context.Background(), &FileRequest{
+		File:     strings.NewReader(data),
+		Filename: "train.jsonl",
+		Purpose:  files.PurposeFineTune,
+	}, WithUploadProgress(func(s, t int64) {
+		calls++
+		sent, total = s, t
+	}))
+	if err != nil {
+		t.Fatalf("UploadFile error: %v", err)
+	}
+	if calls < 2 || sent != total || total <= int64(len(data)) {
+		t.Fatalf("unexpected progress: %d calls, %d of %d bytes", calls, sent, total)
+	}
+
+	// Cancelling the context stops the upload.
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	_, err = client.UploadFile(ctx, &FileRequest{
+		File:     strings.NewReader(data),
+		Filename: "train.jsonl",
+		Purpose:  files.PurposeFineTune,
+	}, WithUploadProgress(func(s, t int64) {
+		cancel()
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestJSONLLines(t *testing.T) {
+	var buf bytes.Buffer
+	var err = jsonl.WriteAll(&buf, []*FineTuningExample{{
+		Messages: []*ChatMessage{SystemMessage("Be terse."), UserMessage("Hi"), AssistantMessage("Hello.")},
+	}})
+	if err != nil {
+		t.Fatalf("WriteAll error: %v", err)
+	}
+	if got, want := buf.String(), `{"messages":[{"role":"system","content":"Be terse."},{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello."}]}`+"\n"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	if err = jsonl.NewWriter[*FineTuningExample](io.Discard).Write(&FineTuningExample{
+		Messages: []*ChatMessage{UserMessage("Hi")},
+	}); err == nil {
+		t.Fatal("expected an error for an example without an assistant message")
+	}
+
+	var w = jsonl.NewWriter[*BatchRequestLine[*ChatCompletionRequest[models.Chat]]](io.Discard)
+	if err = w.Write(&BatchRequestLine[*ChatCompletionRequest[models.Chat]]{
+		CustomID: "req-1",
+		Method:   http.MethodPost,
+		URL:      "/v1/chat/completions",
+		Body:     &ChatCompletionRequest[models.Chat]{Model: models.GPT4o, Messages: []*ChatMessage{UserMessage("Hi")}},
+	}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	// The body is validated as well.
+	if err = w.Write(&BatchRequestLine[*ChatCompletionRequest[models.Chat]]{
+		CustomID: "req-2",
+		Method:   http.MethodPost,
+		URL:      "/v1/chat/completions",
+		Body:     &ChatCompletionRequest[models.Chat]{Model: models.GPT4o},
+	}); err == nil {
+		t.Fatal("expected an error for a request without messages")
+	}
+
+	var lines, rerr = jsonl.ReadAll[*BatchResponseLine[*ChatCompletionResponse[models.Chat]]](strings.NewReader(
+		`{"id": "batch_req_1", "custom_id": "req-1", "response": {"status_code": 200, "request_id": "req_abc", "body": {"id": "chatcmpl-1", "choices": [{"message": {"role": "assistant", "content": "Hi"}}]}}, "error": null}` + "\n" +
+			`{"id": "batch_req_2", "custom_id": "req-2", "response": null, "error": {"code": "invalid_request", "message": "Bad"}}` + "\n"))
+	if rerr != nil {
+		t.Fatalf("ReadAll error: %v", rerr)
+	}
+	if len(lines) != 2 || lines[0].Response.Body.Choices[0].Message.Content != "Hi" || lines[1].Error.Message != "Bad" {
+		t.Fatalf("unexpected lines: %+v", lines)
+	}
+}
+
+func TestValidateDataset(t *testing.T) {
+	var ok = `{"messages": [{"role": "system", "content": "Be terse."}, {"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello."}]}`
+	var lines = []string{
+		ok,
+		`not json`,
+		``,
+		`{"messages": [{"role": "user", "content": "Hi"}]}`,
+		`{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello."}, {"role": "system", "content": "Be terse."}, {"role": "assistant", "content": "Hi."}]}`,
+		`{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello."}, {"role": "tool", "tool_call_id": "call_1", "content": "42"}, {"role": "assistant", "content": "42."}]}`,
+		`{"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Hello."}, {"role": "user", "content": "Bye"}]}`,
+		`{"messages": [{"role": "user", "content": "` + strings.Repeat("word ", 100) + `"}, {"role": "assistant", "content": "Hello."}]}`,
+		ok,
+	}
+
+	var report, err = ValidateDataset(strings.NewReader(strings.Join(lines, "\n")), &DatasetOptions{MaxExampleTokens: 50})
+	if err != nil {
+		t.Fatalf("ValidateDataset error: %v", err)
+	}
+	if report.Valid() {
+		t.Fatal("expected issues")
+	}
+	if report.Examples != 8 {
+		t.Fatalf("expected 8 examples, got %d", report.Examples)
+	}
+	if want := 2 * countMessageTokens(models.GPT4oMini20240718.String(), []*ChatMessage{
+		SystemMessage("Be terse."), UserMessage("Hi"), AssistantMessage("Hello."),
+	}); report.Tokens != want {
+		t.Fatalf("expected %d tokens, got %d", want, report.Tokens)
+	}
+
+	var got []int
+	for _, issue := range report.Issues {
+		got = append(got, issue.Line)
+	}
+	// Every line but the valid examples and the blank line has an issue, as does the file, which has too few examples.
+	if want := []int{2, 4, 5, 6, 7, 8, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected issues on lines %v, got %v: %v", want, got, report.Issues)
+	}
+
+	var examples []string
+	for i := 0; i < 10; i++ {
+		examples = append(examples, ok)
+	}
+	if report, err = ValidateDataset(strings.NewReader(strings.Join(examples, "\n")), nil); err != nil {
+		t.Fatalf("ValidateDataset error: %v", err)
+	}
+	if !report.Valid() {
+		t.Fatalf("unexpected issues: %v", report.Issues)
+	}
+}
+
+func TestFineTuningCheckpoints(t *testing.T) {
+	var requests []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			if strings.HasSuffix(r.URL.Path, "/checkpoints") {
+				_, _ = io.WriteString(w, `{"object": "list", "data": [{"id": "ftckpt_1", "object": "fine_tuning.job.checkpoint", `+
+					`"fine_tuned_model_checkpoint": "ft:gpt-4o-mini-2024-07-18:org::abc:ckpt-step-88", "fine_tuning_job_id": "ftjob-abc123", `+
+					`"step_number": 88, "metrics": {"step": 88, "train_loss": 0.5}}], "has_more": false}`)
+				return
+			}
+			fallthrough
+		case http.MethodPost:
+			_, _ = io.WriteString(w, `{"object": "list", "data": [{"id": "cp_1", "object": "checkpoint.permission", "project_id": "proj_1"}]}`)
+		case http.MethodDelete:
+			_, _ = io.WriteString(w, `{"id": "cp_1", "object": "checkpoint.permission", "deleted": true}`)
+		}
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithAdminKey(testAdminKey)(client)
+	var ctx = context.Background()
+
+	var ckpts, err = client.ListFineTuningCheckpoints(ctx, "ftjob-abc123")
+	if err != nil {
+		t.Fatalf("ListFineTuningCheckpoints error: %v", err)
+	}
+	var ckpt = ckpts.Data[0]
+	if ckpt.Object != objects.FineTuningJobCheckpoint || ckpt.StepNumber != 88 || ckpt.Metrics.TrainLoss != 0.5 {
+		t.Fatalf("unexpected checkpoint: %+v", ckpt)
+	}
+
+	var perms *List[*CheckpointPermission]
+	if perms, err = client.CreateCheckpointPermissions(ctx, string(ckpt.FineTunedModelCheckpoint), &CheckpointPermissionRequest{
+		ProjectIDs: []string{"proj_1"},
+	}); err != nil {
+		t.Fatalf("CreateCheckpointPermissions error: %v", err)
+	}
+	if perms.Data[0].Object != objects.CheckpointPermission || perms.Data[0].ProjectID != "proj_1" {
+		t.Fatalf("unexpected permission: %+v", perms.Data[0])
+	}
+	if _, err = client.CreateCheckpointPermissions(ctx, "ckpt", &CheckpointPermissionRequest{}); err == nil {
+		t.Fatal("expected an error for a request without project IDs")
+	}
+
+	if _, err = client.ListCheckpointPermissions(ctx, "ckpt", "proj_1"); err != nil {
+		t.Fatalf("ListCheckpointPermissions error: %v", err)
+	}
+
+	var d *DeletionResponse
+	if d, err = client.DeleteCheckpointPermission(ctx, "ckpt", "cp_1"); err != nil {
+		t.Fatalf("DeleteCheckpointPermission error: %v", err)
+	}
+	if !d.Deleted {
+		t.Fatal("expected the permission to be deleted")
+	}
+
+	var admin = "Bearer " + testAdminKey
+	var want = []string{
+		"GET /v1/fine_tuning/jobs/ftjob-abc123/checkpoints Bearer " + testToken,
+		"POST /v1/fine_tuning/checkpoints/ft:gpt-4o-mini-2024-07-18:org::abc:ckpt-step-88/permissions " + admin,
+		"GET /v1/fine_tuning/checkpoints/ckpt/permissions?project_id=proj_1 " + admin,
+		"DELETE /v1/fine_tuning/checkpoints/ckpt/permissions/cp_1 " + admin,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Fatalf("expected requests %q, got %q", want, requests)
+	}
+}
+
+func TestHyperparameters(t *testing.T) {
+	var b, err = json.Marshal(&Hyperparameters{
+		NEpochs:                HyperparameterValue(3),
+		BatchSize:              HyperparameterAuto[int](),
+		LearningRateMultiplier: HyperparameterValue(0.5),
+	})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if got, want := string(b), `{"n_epochs":3,"batch_size":"auto","learning_rate_multiplier":0.5}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	var j = &FineTuningJob{}
+	if err = json.Unmarshal([]byte(`{"id": "ftjob-abc123", "hyperparameters": `+
+		`{"n_epochs": "auto", "batch_size": 4, "learning_rate_multiplier": 1.8}}`), j); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	var h = j.Hyperparameters
+	if !h.NEpochs.Auto() || h.BatchSize.Auto() || h.BatchSize.Value() != 4 || h.LearningRateMultiplier.Value() != 1.8 {
+		t.Fatalf("unexpected hyperparameters: n_epochs=%v batch_size=%v learning_rate_multiplier=%v",
+			h.NEpochs, h.BatchSize, h.LearningRateMultiplier)
+	}
+
+	for _, invalid := range []string{`"none"`, `1.5`, `true`} {
+		if err = json.Unmarshal([]byte(invalid), &Hyperparameter[int]{}); err == nil {
+			t.Fatalf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestFineTuningIntegrations(t *testing.T) {
+	var body []byte
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, `{"id": "ftjob-abc123", "object": "fine_tuning.job", "integrations": `+
+			`[{"type": "wandb", "wandb": {"project": "my-project", "tags": ["experiment"]}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var j, err = client.CreateFineTuningJob(context.Background(), &FineTuningJobRequest{
+		Model:        models.GPT4oMini20240718,
+		TrainingFile: "file-abc123",
+		Integrations: []*FineTuningIntegration{WandB(&WandBConfig{Project: "my-project", Tags: []string{"experiment"}})},
+	})
+	if err != nil {
+		t.Fatalf("CreateFineTuningJob error: %v", err)
+	}
+	if want := `"integrations":[{"type":"wandb","wandb":{"project":"my-project","tags":["experiment"]}}]`; !strings.Contains(string(body), want) {
+		t.Fatalf("expected the request to contain %s, got %s", want, body)
+	}
+	if len(j.Integrations) != 1 || j.Integrations[0].WandB.Project != "my-project" {
+		t.Fatalf("unexpected integrations: %+v", j.Integrations)
+	}
+
+	if _, err = client.CreateFineTuningJob(context.Background(), &FineTuningJobRequest{
+		Model:        models.GPT4oMini20240718,
+		TrainingFile: "file-abc123",
+		Integrations: []*FineTuningIntegration{WandB(&WandBConfig{})},
+	}); err == nil {
+		t.Fatal("expected an error for a wandb integration without a project")
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	var requests []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v1/batches":
+			var body, _ = io.ReadAll(r.Body)
+			if want := `{"input_file_id":"file-in","endpoint":"/v1/embeddings","completion_window":"24h"}`; string(body) != want {
+				t.Errorf("expected body %s, got %s", want, body)
+			}
+			fallthrough
+		case "/v1/batches/batch_abc123":
+			_, _ = io.WriteString(w, `{"id": "batch_abc123", "object": "batch", "endpoint": "/v1/embeddings", "status": "completed", `+
+				`"output_file_id": "file-out", "error_file_id": "file-err", "request_counts": {"total": 4, "completed": 2, "failed": 2}}`)
+		case "/v1/files/file-out/content":
+			_, _ = io.WriteString(w, `{"id": "batch_req_1", "custom_id": "a", "response": {"status_code": 200, "request_id": "req_1", "body": {"object": "list", "data": [{"object": "embedding", "embedding": [0.5], "index": 0}]}}, "error": null}`+"\n"+
+				`{"id": "batch_req_2", "custom_id": "b", "response": {"status_code": 200, "request_id": "req_2", "body": {"object": "list", "data": [{"object": "embedding", "embedding": [0.25], "index": 0}]}}, "error": null}`+"\n")
+		case "/v1/files/file-err/content":
+			_, _ = io.WriteString(w, `{"id": "batch_req_3", "custom_id": "c", "response": {"status_code": 400, "request_id": "req_3", "body": {"error": {"message": "Bad input", "type": "invalid_request_error"}}}, "error": null}`+"\n"+
+				`{"id": "batch_req_4", "custom_id": "d", "response": null, "error": {"code": "batch_expired", "message": "Expired"}}`+"\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	if _, err := client.CreateBatch(ctx, &BatchRequest{InputFileID: "file-in", Endpoint: "/v1/images/generations"}); err == nil {
+		t.Fatal("expected an error for an unsupported endpoint")
+	}
+
+	var b, err = client.CreateBatch(ctx, &BatchRequest{InputFileID: "file-in", Endpoint: "/v1/embeddings"})
+	if err != nil {
+		t.Fatalf("CreateBatch error: %v", err)
+	}
+	if b.Object != objects.Batch || b.RequestCounts.Failed != 2 {
+		t.Fatalf("unexpected batch: %+v", b)
+	}
+
+	var results *BatchResults[*EmbeddingResponse]
+	if results, err = GetBatchResults[*EmbeddingResponse](ctx, client, b); err != nil {
+		t.Fatalf("GetBatchResults error: %v", err)
+	}
+	if len(results.Responses) != 2 || results.Responses["b"].Data[0].Embedding[0] != 0.25 {
+		t.Fatalf("unexpected responses: %+v", results.Responses)
+	}
+	if e := results.Errors["c"]; e == nil || e.StatusCode != http.StatusBadRequest || e.RequestID != "req_3" || !errors.Is(e, ErrInvalidRequest) {
+		t.Fatalf("unexpected error for c: %v", e)
+	}
+	if e := results.Errors["d"]; e == nil || e.Code != "batch_expired" {
+		t.Fatalf("unexpected error for d: %v", e)
+	}
+
+	if _, err = GetBatchResults[*EmbeddingResponse](ctx, client, &Batch{ID: "batch_abc123", Status: "in_progress"}); err == nil {
+		t.Fatal("expected an error for a batch without results")
+	}
+
+	var want = []string{"POST /v1/batches", "GET /v1/files/file-out/content", "GET /v1/files/file-err/content"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Fatalf("expected requests %q, got %q", want, requests)
+	}
+}
+
+func TestSubmitBatch(t *testing.T) {
+	var input string
+	var deleted []string
+	var createBatch = http.StatusOK
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/files":
+			var f, fh, err = r.FormFile("file")
+			if err != nil || r.FormValue("purpose") != "batch" || fh.Filename != "batch.jsonl" {
+				http.Error(w, "bad upload", http.StatusBadRequest)
+				return
+			}
+			var b, _ = io.ReadAll(f)
+			input = string(b)
+			_, _ = io.WriteString(w, `{"id": "file-in", "object": "file", "purpose": "batch"}`)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			_, _ = io.WriteString(w, `{"id": "file-in", "object": "file", "deleted": true}`)
+		case r.URL.Path == "/v1/batches":
+			var b, _ = io.ReadAll(r.Body)
+			if want := `{"input_file_id":"file-in","endpoint":"/v1/embeddings","completion_window":"24h","metadata":{"job":"nightly"}}`; string(b) != want {
+				t.Errorf("expected body %s, got %s", want, b)
+			}
+			w.WriteHeader(createBatch)
+			_, _ = io.WriteString(w, `{"id": "batch_abc123", "object": "batch", "input_file_id": "file-in", "status": "validating"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var inputs = []*BatchInput[*EmbeddingRequest]{
+		{CustomID: "a", Body: &EmbeddingRequest{Input: []string{"Lorem"}, Model: models.AdaEmbeddingV2}},
+		{CustomID: "b", Body: &EmbeddingRequest{Input: []string{"ipsum"}, Model: models.AdaEmbeddingV2}},
+	}
+	var metadata = map[string]string{"job": "nightly"}
+
+	var b, err = SubmitBatch(ctx, client, "/v1/embeddings", inputs, metadata)
+	if err != nil {
+		t.Fatalf("SubmitBatch error: %v", err)
+	}
+	if b.ID != "batch_abc123" || b.InputFileID != "file-in" {
+		t.Fatalf("unexpected batch: %+v", b)
+	}
+	var want = `{"custom_id":"a","method":"POST","url":"/v1/embeddings","body":{"input":["Lorem"],"model":"text-embedding-ada-002","user":""}}` + "\n" +
+		`{"custom_id":"b","method":"POST","url":"/v1/embeddings","body":{"input":["ipsum"],"model":"text-embedding-ada-002","user":""}}` + "\n"
+	if input != want {
+		t.Fatalf("expected input file %s, got %s", want, input)
+	}
+
+	if _, err = SubmitBatch(ctx, client, "/v1/embeddings", append(inputs, inputs[0]), nil); err == nil {
+		t.Fatal("expected an error for a duplicate custom_id")
+	}
+	if _, err = SubmitBatch(ctx, client, "/v1/images/generations", inputs, nil); err == nil {
+		t.Fatal("expected an error for an unsupported endpoint")
+	}
+
+	createBatch = http.StatusBadRequest
+	if _, err = SubmitBatch(ctx, client, "/v1/embeddings", inputs, metadata); err == nil {
+		t.Fatal("expected an error when the batch cannot be created")
+	}
+	if !reflect.DeepEqual(deleted, []string{"/v1/files/file-in"}) {
+		t.Fatalf("expected the input file to be deleted, got %v", deleted)
+	}
+}
+
+func TestWaitForBatch(t *testing.T) {
+	var polls int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		var status = "in_progress"
+		if polls == 3 {
+			status = "completed"
+		}
+		_, _ = fmt.Fprintf(w, `{"id": "batch_abc123", "object": "batch", "status": %q, "request_counts": {"total": 2, "completed": %d, "failed": 0}}`,
+			status, polls-1)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+
+	var completed []int
+	var b, err = WaitForBatch(context.Background(), client, "batch_abc123", &BatchWaitOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+		Progress: func(b *Batch) {
+			completed = append(completed, b.RequestCounts.Completed)
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitForBatch error: %v", err)
+	}
+	if b.Status != "completed" || !b.Done() {
+		t.Fatalf("unexpected batch: %+v", b)
+	}
+	if !reflect.DeepEqual(completed, []int{0, 1, 2}) {
+		t.Fatalf("unexpected progress: %v", completed)
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	polls = -100
+	if _, err = WaitForBatch(ctx, client, "batch_abc123", &BatchWaitOptions{Interval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestImageData(t *testing.T) {
+	// A 1x1 transparent PNG.
+	const pixel = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+	var png, _ = base64.StdEncoding.DecodeString(pixel)
+
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("expected no credentials, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/pixel.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(png)
+	}))
+	defer ts.Close()
+
+	var b64 = &ImageData{B64JSON: params.Optional(pixel)}
+	var img, err = b64.Image()
+	if err != nil {
+		t.Fatalf("Image error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 1 || b.Dy() != 1 {
+		t.Fatalf("unexpected bounds: %v", b)
+	}
+
+	var u = &ImageData{URL: params.Optional(ts.URL + "/pixel.png")}
+	if _, err = u.Bytes(); err == nil {
+		t.Fatal("expected an error for an image returned as a URL")
+	}
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+	var dir = t.TempDir()
+	for name, d := range map[string]*ImageData{"b64.png": b64, "url.png": u} {
+		var path = filepath.Join(dir, name)
+		if err = client.SaveImage(ctx, d, path); err != nil {
+			t.Fatalf("SaveImage error: %v", err)
+		}
+		var b, _ = os.ReadFile(path)
+		if !bytes.Equal(b, png) {
+			t.Fatalf("unexpected contents of %s: %q", name, b)
+		}
+	}
+
+	var missing = filepath.Join(dir, "missing.png")
+	if err = client.SaveImage(ctx, &ImageData{URL: params.Optional(ts.URL + "/missing.png")}, missing); err == nil {
+		t.Fatal("expected an error for a missing image")
+	}
+	if _, err = os.Stat(missing); !os.IsNotExist(err) {
+		t.Fatalf("expected the file to be removed, got %v", err)
+	}
+}
+
+func TestCreateImageValidation(t *testing.T) {
+	var body []byte
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, `{"created": 1, "data": [{"url": "https://example.com/image.png", "revised_prompt": "A red fox in the snow."}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var resp, err = client.CreateImage(ctx, &CreateImageRequest{
+		Model:   models.DALLE3,
+		Prompt:  "A fox",
+		Size:    images.Size1792x1024,
+		Quality: images.QualityHD,
+		Style:   images.StyleNatural,
+	})
+	if err != nil {
+		t.Fatalf("CreateImage error: %v", err)
+	}
+	if want := `{"model":"dall-e-3","prompt":"A fox","size":"1792x1024","quality":"hd","style":"natural"}`; string(body) != want {
+		t.Fatalf("expected body %s, got %s", want, body)
+	}
+	if resp.Data[0].RevisedPrompt == nil || *resp.Data[0].RevisedPrompt != "A red fox in the snow." {
+		t.Fatalf("unexpected revised prompt: %v", resp.Data[0].RevisedPrompt)
+	}
+
+	if _, err = client.CreateImage(ctx, &CreateImageRequest{
+		Model:             models.GPTImage1,
+		Prompt:            "A fox",
+		Size:              images.SizeAuto,
+		Quality:           images.QualityHigh,
+		OutputFormat:      images.OutputFormatWebP,
+		OutputCompression: params.Optional(50),
+		Background:        images.BackgroundTransparent,
+	}); err != nil {
+		t.Fatalf("CreateImage error: %v", err)
+	}
+	if want := `{"model":"gpt-image-1","prompt":"A fox","size":"auto","quality":"high","output_format":"webp","output_compression":50,"background":"transparent"}`; string(body) != want {
+		t.Fatalf("expected body %s, got %s", want, body)
+	}
+
+	for name, ir := range map[string]*CreateImageRequest{
+		"dall-e-2 size":          {Prompt: "A fox", Size: images.Size1792x1024},
+		"dall-e-2 quality":       {Prompt: "A fox", Quality: images.QualityHD},
+		"dall-e-2 style":         {Prompt: "A fox", Style: images.StyleVivid},
+		"dall-e-2 background":    {Prompt: "A fox", Background: images.BackgroundOpaque},
+		"dall-e-2 prompt":        {Prompt: strings.Repeat("a", 1001)},
+		"dall-e-3 n":             {Model: models.DALLE3, Prompt: "A fox", N: 2},
+		"dall-e-3 output format": {Model: models.DALLE3, Prompt: "A fox", OutputFormat: images.OutputFormatPNG},
+		"gpt-image-1 format":     {Model: models.GPTImage1, Prompt: "A fox", ResponseFormat: images.FormatURL},
+		"gpt-image-1 quality":    {Model: models.GPTImage1, Prompt: "A fox", Quality: images.QualityStandard},
+		"gpt-image-1 png compression": {
+			Model: models.GPTImage1, Prompt: "A fox", OutputCompression: params.Optional(50),
+		},
+		"gpt-image-1 compression range": {
+			Model: models.GPTImage1, Prompt: "A fox", OutputFormat: images.OutputFormatJPEG, OutputCompression: params.Optional(101),
+		},
+		"gpt-image-1 transparent jpeg": {
+			Model: models.GPTImage1, Prompt: "A fox", OutputFormat: images.OutputFormatJPEG, Background: images.BackgroundTransparent,
+		},
+		"no prompt": {},
+	} {
+		body = nil
+		if _, err = client.CreateImage(ctx, ir); err == nil || !strings.HasPrefix(err.Error(), "openai: ") {
+			t.Errorf("%s: expected an openai: error, got: %v", name, err)
+		}
+		if body != nil {
+			t.Errorf("%s: expected the request not to be sent", name)
+		}
+	}
+}
+
+func TestImageEnumValidation(t *testing.T) {
+	var sent int
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		_, _ = io.WriteString(w, `{"created": 1, "data": [{"url": "https://example.com/image.png"}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var requests = map[string]func() error{
+		"create size": func() error {
+			var _, err = client.CreateImage(ctx, &CreateImageRequest{Prompt: "A fox", Size: images.Size(42)})
+			return err
+		},
+		"create format": func() error {
+			var _, err = client.CreateImage(ctx, &CreateImageRequest{Prompt: "A fox", ResponseFormat: images.Format(42)})
+			return err
+		},
+		"edit size": func() error {
+			var _, err = client.EditImage(ctx, &EditImageRequest{Image: "image.png", Prompt: "A fox", Size: images.Size1792x1024})
+			return err
+		},
+		"edit prompt": func() error {
+			var _, err = client.EditImage(ctx, &EditImageRequest{Image: "image.png"})
+			return err
+		},
+		"variation format": func() error {
+			var _, err = client.ImageVariation(ctx, &VariationImageRequest{Image: "image.png", ResponseFormat: images.Format(42)})
+			return err
+		},
+		"variation n": func() error {
+			var _, err = client.ImageVariation(ctx, &VariationImageRequest{Image: "image.png", N: 11})
+			return err
+		},
+	}
+	for name, send := range requests {
+		if err := send(); err == nil || !strings.HasPrefix(err.Error(), "openai: ") {
+			t.Errorf("%s: expected an openai: error, got: %v", name, err)
+		}
+	}
+	if sent != 0 {
+		t.Fatalf("expected no requests to be sent, got %d", sent)
+	}
+
+	if _, err := client.ImageVariation(ctx, &VariationImageRequest{Image: "image.png", Size: images.Size512x512, ResponseFormat: images.FormatB64JSON}); err != nil {
+		t.Fatalf("ImageVariation error: %v", err)
+	}
+	if sent != 1 {
+		t.Fatalf("expected 1 request to be sent, got %d", sent)
+	}
+}
+
+func TestVerboseTranscription(t *testing.T) {
+	var form url.Values
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		form = r.MultipartForm.Value
+		_, _ = io.WriteString(w, `{"task": "transcribe", "language": "english", "duration": 2.5, "text": "Hello world.", `+
+			`"segments": [{"id": 0, "seek": 0, "start": 0.0, "end": 2.5, "text": " Hello world.", "tokens": [50364, 2425], `+
+			`"temperature": 0.0, "avg_logprob": -0.1, "compression_ratio": 0.8, "no_speech_prob": 0.01}], `+
+			`"words": [{"word": "Hello", "start": 0.0, "end": 1.0}, {"word": "world", "start": 1.2, "end": 2.1}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateTranscription(context.Background(), &TranscriptionRequest{
+		File:                   strings.NewReader("..."),
+		Filename:               "audio.mp3",
+		Model:                  models.Whisper1,
+		ResponseFormat:         audio.TranscriptionFormatVerboseJSON,
+		TimestampGranularities: []audio.TimestampGranularity{audio.TimestampGranularitySegment, audio.TimestampGranularityWord},
+	})
+	if err != nil {
+		t.Fatalf("CreateTranscription error: %v", err)
+	}
+	if form.Get("response_format") != "verbose_json" || !reflect.DeepEqual(form["timestamp_granularities[]"], []string{"segment", "word"}) {
+		t.Fatalf("unexpected form: %v", form)
+	}
+	if resp.Language != "english" || resp.Duration != 2.5 || len(resp.Segments) != 1 || len(resp.Words) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if s := resp.Segments[0]; s.End != 2.5 || math.Abs(s.Confidence()-math.Exp(-0.1)) > 1e-9 {
+		t.Fatalf("unexpected segment: %+v", s)
+	}
+	if w := resp.Words[1]; w.Word != "world" || w.Start != 1.2 || w.End != 2.1 {
+		t.Fatalf("unexpected word: %+v", w)
+	}
+
+	if _, err = client.CreateTranscription(context.Background(), &TranscriptionRequest{
+		File:                   strings.NewReader("..."),
+		Filename:               "audio.mp3",
+		Model:                  models.Whisper1,
+		TimestampGranularities: []audio.TimestampGranularity{audio.TimestampGranularityWord},
+	}); err == nil {
+		t.Fatal("expected an error for timestamps without the verbose_json format")
+	}
+}
+
+func TestTranscriptionSubtitles(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "1\n00:00:00,000 --> 00:00:02,500\nHello world.\n\n")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateTranscription(context.Background(), &TranscriptionRequest{
+		File:           strings.NewReader("..."),
+		Filename:       "audio.mp3",
+		Model:          models.Whisper1,
+		ResponseFormat: audio.TranscriptionFormatSRT,
+	})
+	if err != nil {
+		t.Fatalf("CreateTranscription error: %v", err)
+	}
+	if resp.Text != "1\n00:00:00,000 --> 00:00:02,500\nHello world.\n\n" {
+		t.Fatalf("unexpected transcription: %q", resp.Text)
+	}
+
+	var verbose = &TranscriptionResponse{Segments: []*TranscriptionSegment{
+		{Start: 0, End: 2.5, Text: " Hello world."},
+		{Start: 3661.0016, End: 3662.25, Text: " Goodbye."},
+	}}
+	if got, want := verbose.SRT(), "1\n00:00:00,000 --> 00:00:02,500\nHello world.\n\n"+
+		"2\n01:01:01,002 --> 01:01:02,250\nGoodbye.\n\n"; got != want {
+		t.Fatalf("expected SRT %q, got %q", want, got)
+	}
+	if got, want := verbose.VTT(), "WEBVTT\n\n00:00:00.000 --> 00:00:02.500\nHello world.\n\n"+
+		"01:01:01.002 --> 01:01:02.250\nGoodbye.\n\n"; got != want {
+		t.Fatalf("expected VTT %q, got %q", want, got)
+	}
+}
+
+func TestChunkedTranscription(t *testing.T) {
+	// 3 seconds of 8 kHz mono 16-bit audio, each second filled with its index.
+	var format = []byte{1, 0, 1, 0, 0x40, 0x1F, 0, 0, 0x80, 0x3E, 0, 0, 2, 0, 16, 0}
+	var data = make([]byte, 3*16000)
+	for i := range data {
+		data[i] = byte(i / 16000)
+	}
+
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var f, _, err = r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var b, _ = io.ReadAll(f)
+		if len(b) != 44+16000 || string(b[:4]) != "RIFF" || string(b[36:40]) != "data" {
+			http.Error(w, "invalid chunk", http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"language": "english", "duration": 1.0, "text": " Chunk %d.", `+
+			`"segments": [{"id": 0, "start": 0.0, "end": 0.5, "text": " Chunk."}], `+
+			`"words": [{"word": "Chunk", "start": 0.1, "end": 0.4}]}`, b[44])
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateChunkedTranscription(context.Background(), &TranscriptionRequest{
+		File:           bytes.NewReader(wavFile(format, data)),
+		Filename:       "audio.wav",
+		Model:          models.Whisper1,
+		ResponseFormat: audio.TranscriptionFormatVerboseJSON,
+	}, &WAVSplitter{MaxDuration: time.Second}, 2)
+	if err != nil {
+		t.Fatalf("CreateChunkedTranscription error: %v", err)
+	}
+	if resp.Text != "Chunk 0. Chunk 1. Chunk 2." || resp.Duration != 3 || resp.Language != "english" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(resp.Segments) != 3 || resp.Segments[2].ID != 2 || resp.Segments[2].Start != 2 || resp.Segments[2].End != 2.5 {
+		t.Fatalf("unexpected segments: %+v", resp.Segments)
+	}
+	if len(resp.Words) != 3 || resp.Words[1].Start != 1.1 || resp.Words[1].End != 1.4 {
+		t.Fatalf("unexpected words: %+v", resp.Words)
+	}
+
+	if _, err = (&WAVSplitter{}).Split(strings.NewReader("ID3..."), "audio.mp3"); err == nil {
+		t.Fatal("expected an error splitting a file which is not WAV")
+	}
+
+	var none = AudioSplitterFunc(func(io.Reader, string) ([]*AudioChunk, error) { return nil, nil })
+	if _, err = client.CreateChunkedTranscription(context.Background(), &TranscriptionRequest{
+		File:     strings.NewReader("..."),
+		Filename: "audio.mp3",
+		Model:    models.Whisper1,
+	}, none, 0); err == nil {
+		t.Fatal("expected an error for a splitter which returns no chunks")
+	}
+}
+
+func TestStreamSpeech(t *testing.T) {
+	// The second chunk is only sent once the first has been received, so the test hangs if the audio is buffered.
+	var received = make(chan struct{})
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = io.WriteString(w, "first")
+		w.(http.Flusher).Flush()
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+		}
+		_, _ = io.WriteString(w, "second")
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var sr = &SpeechRequest{Model: models.TTS1, Input: "Lorem ipsum", Voice: audio.VoiceAlloy}
+
+	var chunks []string
+	var err = StreamSpeech(context.Background(), client, sr, func(chunk []byte) error {
+		if len(chunks) == 0 {
+			close(received)
+		}
+		chunks = append(chunks, string(chunk))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamSpeech error: %v", err)
+	}
+	if !reflect.DeepEqual(chunks, []string{"first", "second"}) {
+		t.Fatalf("unexpected chunks: %q", chunks)
+	}
+
+	var errStop = errors.New("stop")
+	if err = StreamSpeech(context.Background(), client, sr, func([]byte) error { return errStop }); !errors.Is(err, errStop) {
+		t.Fatalf("expected the callback's error, got: %v", err)
+	}
+}
+
+func TestSpeechValidation(t *testing.T) {
+	var client, _ = newTestClient("http://127.0.0.1:0")
+	var speed = func(f float64) *float64 { return &f }
+
+	for name, sr := range map[string]*SpeechRequest{
+		"no model":    {Input: "Lorem ipsum", Voice: audio.VoiceAlloy},
+		"no input":    {Model: models.TTS1, Voice: audio.VoiceAlloy},
+		"long input":  {Model: models.TTS1, Input: strings.Repeat("é", 4097), Voice: audio.VoiceAlloy},
+		"no voice":    {Model: models.TTS1, Input: "Lorem ipsum"},
+		"pcm16":       {Model: models.TTS1, Input: "Lorem ipsum", Voice: audio.VoiceAlloy, ResponseFormat: audio.FormatPCM16},
+		"slow speed":  {Model: models.TTS1, Input: "Lorem ipsum", Voice: audio.VoiceAlloy, Speed: speed(0.1)},
+		"quick speed": {Model: models.TTS1, Input: "Lorem ipsum", Voice: audio.VoiceAlloy, Speed: speed(4.5)},
+	} {
+		if _, err := client.CreateSpeech(context.Background(), sr); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	var b, err = json.Marshal(&SpeechRequest{
+		Model:          models.TTS1HD,
+		Input:          strings.Repeat("é", 4096),
+		Voice:          audio.VoiceCoral,
+		ResponseFormat: audio.FormatPCM,
+		Speed:          speed(0.25),
+	})
+	if err != nil {
+		t.Fatalf("error marshaling request: %v", err)
+	}
+	if !strings.Contains(string(b), `"voice":"coral","response_format":"pcm","speed":0.25`) {
+		t.Fatalf("unexpected request: %s", b)
+	}
+}
+
+func TestModerationBatch(t *testing.T) {
+	var input []string
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var mr ModerationRequest
+		if err := json.NewDecoder(r.Body).Decode(&mr); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		input = mr.Input
+		_, _ = io.WriteString(w, `{"id": "modr-123", "model": "text-moderation-007", "results": [`+
+			`{"flagged": false, "categories": {}, "category_scores": {}}, `+
+			`{"flagged": true, "categories": {"hate": true, "violence/graphic": true}, "category_scores": {"hate": 0.9}}, `+
+			`{"flagged": true, "categories": {"self-harm": true}, "category_scores": {"self-harm": 0.8}}]}`)
+	}))
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var resp, err = client.CreateModeration(context.Background(), &ModerationRequest{
+		Input: []string{"Lorem", "ipsum", "dolor"},
+	})
+	if err != nil {
+		t.Fatalf("CreateModeration error: %v", err)
+	}
+	if !reflect.DeepEqual(input, []string{"Lorem", "ipsum", "dolor"}) {
+		t.Fatalf("unexpected input: %q", input)
+	}
+
+	var flagged = resp.Flagged()
+	var want = []*FlaggedInput{
+		{Index: 1, Categories: []string{"hate", "violence/graphic"}},
+		{Index: 2, Categories: []string{"self-harm"}},
+	}
+	if !reflect.DeepEqual(flagged, want) {
+		t.Fatalf("unexpected flagged inputs: %+v", flagged)
+	}
+
+	if _, err = client.CreateModeration(context.Background(), &ModerationRequest{Input: []string{"Lorem"}}); err == nil {
+		t.Fatal("expected an error for results which do not match the inputs")
+	}
+	if _, err = client.CreateModeration(context.Background(), &ModerationRequest{}); err == nil {
+		t.Fatal("expected an error without inputs")
+	}
+}
+
+func TestModerationPolicy(t *testing.T) {
+	var p = &Policy{
+		Default: Threshold{Review: 0.5, Block: 0.9},
+		Categories: map[moderation.Category]Threshold{
+			moderation.CategorySexualMinors: {Block: 0.1},
+			moderation.CategoryViolence:     {Review: 0.8},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		scores *ResultCategoryScores
+		want   *Verdict
+	}{
+		"allow": {
+			scores: &ResultCategoryScores{Hate: 0.4, Violence: 0.7},
+			want:   &Verdict{Decision: moderation.DecisionAllow},
+		},
+		"review": {
+			scores: &ResultCategoryScores{Hate: 0.6, SelfHarm: 0.5, Violence: 0.95},
+			want: &Verdict{Decision: moderation.DecisionReview, Categories: []moderation.Category{
+				moderation.CategoryHate, moderation.CategorySelfHarm, moderation.CategoryViolence,
+			}},
+		},
+		"block": {
+			scores: &ResultCategoryScores{Hate: 0.6, SexualMinors: 0.2},
+			want:   &Verdict{Decision: moderation.DecisionBlock, Categories: []moderation.Category{moderation.CategorySexualMinors}},
+		},
+	} {
+		if got := p.Evaluate(&Result{CategoryScores: tc.scores}); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %+v, want %+v", name, got, tc.want)
+		}
+	}
+
+	var verdicts = p.EvaluateAll(&ModerationResponse{Results: []Result{
+		{CategoryScores: &ResultCategoryScores{}},
+		{CategoryScores: &ResultCategoryScores{ViolenceGraphic: 0.95}},
+	}})
+	if len(verdicts) != 2 || verdicts[0].Decision != moderation.DecisionAllow || verdicts[1].Decision != moderation.DecisionBlock {
+		t.Fatalf("unexpected verdicts: %+v", verdicts)
+	}
+	if verdicts[1].Decision.String() != "block" || verdicts[1].Categories[0].String() != "violence/graphic" {
+		t.Fatalf("unexpected verdict: %+v", verdicts[1])
+	}
+}
+
+// recordedRequest is a request received by a routesServer.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]any
+}
+
+// routesServer returns a server which responds to requests for "METHOD /path" in |responses| with the given JSON, and
+// to any other request with a 404. Each request is appended to |requests|, if not nil.
+func routesServer(responses map[string]string, requests *[]*recordedRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rr = &recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()}
+		if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+			_ = json.Unmarshal(b, &rr.Body)
+		}
+		if requests != nil {
+			*requests = append(*requests, rr)
+		}
+
+		var res, ok = responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.Error(w, "the resource path doesn't exist", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, res)
+	}))
+}
+
+func TestChunkingStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		cs   ChunkingStrategy
+		json string
+	}{
+		{cs: ChunkingStrategy{}, json: `{"type":"auto"}`},
+		{
+			cs:   ChunkingStrategy{Static: &StaticChunkingStrategy{MaxChunkSizeTokens: 1000, ChunkOverlapTokens: 200}},
+			json: `{"type":"static","static":{"max_chunk_size_tokens":1000,"chunk_overlap_tokens":200}}`,
+		},
+	} {
+		var b, err = json.Marshal(tc.cs)
+		if err != nil {
+			t.Fatalf("error marshaling %+v: %v", tc.cs, err)
+		}
+		if string(b) != tc.json {
+			t.Fatalf("expected %s, got %s", tc.json, b)
+		}
+
+		var cs ChunkingStrategy
+		if err = json.Unmarshal(b, &cs); err != nil {
+			t.Fatalf("error unmarshaling %s: %v", b, err)
+		}
+		if !reflect.DeepEqual(cs, tc.cs) {
+			t.Fatalf("expected %+v, got %+v", tc.cs, cs)
+		}
+	}
+}
+
+func TestVectorStores(t *testing.T) {
+	const store = `{"id": "vs_abc123", "object": "vector_store", "name": "Support FAQ", "status": "completed", ` +
+		`"file_counts": {"completed": 2, "total": 2}, "metadata": {"team": "support"}}`
+	const file = `{"id": "file-abc123", "object": "vector_store.file", "vector_store_id": "vs_abc123", ` +
+		`"status": "failed", "last_error": {"code": "invalid_file", "message": "The file could not be parsed."}, ` +
+		`"chunking_strategy": {"type": "static", "static": {"max_chunk_size_tokens": 1000, "chunk_overlap_tokens": 200}}}`
+	const batch = `{"id": "vsfb_abc123", "object": "vector_store.file_batch", "vector_store_id": "vs_abc123", ` +
+		`"status": "%s", "file_counts": {"in_progress": 1, "total": 2}}`
+	const deleted = `{"id": "%s", "object": "%s", "deleted": true}`
+
+	var requests []*recordedRequest
+	var ts = routesServer(map[string]string{
+		"POST /v1/vector_stores":                                           store,
+		"GET /v1/vector_stores":                                            `{"object": "list", "data": [` + store + `]}`,
+		"GET /v1/vector_stores/vs_abc123":                                  store,
+		"POST /v1/vector_stores/vs_abc123":                                 store,
+		"DELETE /v1/vector_stores/vs_abc123":                               fmt.Sprintf(deleted, "vs_abc123", "vector_store.deleted"),
+		"POST /v1/vector_stores/vs_abc123/files":                           file,
+		"GET /v1/vector_stores/vs_abc123/files":                            `{"object": "list", "data": [` + file + `]}`,
+		"GET /v1/vector_stores/vs_abc123/files/file-abc123":                file,
+		"DELETE /v1/vector_stores/vs_abc123/files/file-abc123":             fmt.Sprintf(deleted, "file-abc123", "vector_store.file.deleted"),
+		"POST /v1/vector_stores/vs_abc123/file_batches":                    fmt.Sprintf(batch, "in_progress"),
+		"GET /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123":         fmt.Sprintf(batch, "in_progress"),
+		"POST /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123/cancel": fmt.Sprintf(batch, "cancelled"),
+		"GET /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123/files":   `{"object": "list", "data": [` + file + `]}`,
+	}, &requests)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var vs, err = client.CreateVectorStore(ctx, &VectorStoreRequest{
+		Name:             params.Optional("Support FAQ"),
+		FileIDs:          []string{"file-abc123", "file-def456"},
+		ChunkingStrategy: &ChunkingStrategy{Static: &StaticChunkingStrategy{MaxChunkSizeTokens: 1000, ChunkOverlapTokens: 200}},
+		ExpiresAfter:     &ExpirationPolicy{Anchor: "last_active_at", Days: 7},
+	})
+	if err != nil {
+		t.Fatalf("CreateVectorStore error: %v", err)
+	}
+	if vs.ID != "vs_abc123" || vs.Status != "completed" || vs.FileCounts.Completed != 2 || vs.Metadata["team"] != "support" {
+		t.Fatalf("unexpected vector store: %+v", vs)
+	}
+	var body = requests[0].Body
+	if body["name"] != "Support FAQ" || len(body["file_ids"].([]any)) != 2 {
+		t.Fatalf("unexpected request body: %v", body)
+	}
+	if cs, _ := body["chunking_strategy"].(map[string]any); cs["type"] != "static" {
+		t.Fatalf("unexpected chunking strategy: %v", body["chunking_strategy"])
+	}
+	if ea, _ := body["expires_after"].(map[string]any); ea["anchor"] != "last_active_at" || ea["days"] != float64(7) {
+		t.Fatalf("unexpected expiration policy: %v", body["expires_after"])
+	}
+
+	var stores *List[*VectorStore]
+	if stores, err = client.ListVectorStores(ctx, WithListOptions(&ListOptions{Limit: 1})); err != nil {
+		t.Fatalf("ListVectorStores error: %v", err)
+	}
+	if len(stores.Data) != 1 || requests[1].Query.Get("limit") != "1" {
+		t.Fatalf("unexpected vector stores %+v for query %v", stores, requests[1].Query)
+	}
+	if _, err = client.RetrieveVectorStore(ctx, "vs_abc123"); err != nil {
+		t.Fatalf("RetrieveVectorStore error: %v", err)
+	}
+	if _, err = client.ModifyVectorStore(ctx, "vs_abc123", &VectorStoreRequest{Metadata: map[string]string{"team": "support"}}); err != nil {
+		t.Fatalf("ModifyVectorStore error: %v", err)
+	}
+
+	var f *VectorStoreFile
+	if f, err = client.CreateVectorStoreFile(ctx, "vs_abc123", &VectorStoreFileRequest{FileID: "file-abc123"}); err != nil {
+		t.Fatalf("CreateVectorStoreFile error: %v", err)
+	}
+	if f.Status != "failed" || f.LastError.Code != "invalid_file" || f.ChunkingStrategy.Static.MaxChunkSizeTokens != 1000 {
+		t.Fatalf("unexpected vector store file: %+v", f)
+	}
+	if _, ok := requests[len(requests)-1].Body["chunking_strategy"]; ok {
+		t.Fatalf("unexpected chunking strategy in request: %v", requests[len(requests)-1].Body)
+	}
+	if _, err = client.ListVectorStoreFiles(ctx, "vs_abc123"); err != nil {
+		t.Fatalf("ListVectorStoreFiles error: %v", err)
+	}
+	if _, err = client.RetrieveVectorStoreFile(ctx, "vs_abc123", "file-abc123"); err != nil {
+		t.Fatalf("RetrieveVectorStoreFile error: %v", err)
+	}
+
+	var fb *VectorStoreFileBatch
+	if fb, err = client.CreateVectorStoreFileBatch(ctx, "vs_abc123", &VectorStoreFileBatchRequest{
+		FileIDs: []string{"file-abc123", "file-def456"},
+	}); err != nil {
+		t.Fatalf("CreateVectorStoreFileBatch error: %v", err)
+	}
+	if fb.Status != "in_progress" || fb.FileCounts.InProgress != 1 {
+		t.Fatalf("unexpected file batch: %+v", fb)
+	}
+	if _, err = client.RetrieveVectorStoreFileBatch(ctx, "vs_abc123", "vsfb_abc123"); err != nil {
+		t.Fatalf("RetrieveVectorStoreFileBatch error: %v", err)
+	}
+	if fb, err = client.CancelVectorStoreFileBatch(ctx, "vs_abc123", "vsfb_abc123"); err != nil || fb.Status != "cancelled" {
+		t.Fatalf("CancelVectorStoreFileBatch returned %+v, %v", fb, err)
+	}
+	var files *List[*VectorStoreFile]
+	if files, err = client.ListVectorStoreFileBatchFiles(ctx, "vs_abc123", "vsfb_abc123"); err != nil || len(files.Data) != 1 {
+		t.Fatalf("ListVectorStoreFileBatchFiles returned %+v, %v", files, err)
+	}
+
+	var d *DeletionResponse
+	if d, err = client.DeleteVectorStoreFile(ctx, "vs_abc123", "file-abc123"); err != nil || !d.Deleted {
+		t.Fatalf("DeleteVectorStoreFile returned %+v, %v", d, err)
+	}
+	if d, err = client.DeleteVectorStore(ctx, "vs_abc123"); err != nil || !d.Deleted {
+		t.Fatalf("DeleteVectorStore returned %+v, %v", d, err)
+	}
+
+	var got []string
+	for _, r := range requests {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	var want = []string{
+		"POST /v1/vector_stores",
+		"GET /v1/vector_stores",
+		"GET /v1/vector_stores/vs_abc123",
+		"POST /v1/vector_stores/vs_abc123",
+		"POST /v1/vector_stores/vs_abc123/files",
+		"GET /v1/vector_stores/vs_abc123/files",
+		"GET /v1/vector_stores/vs_abc123/files/file-abc123",
+		"POST /v1/vector_stores/vs_abc123/file_batches",
+		"GET /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123",
+		"POST /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123/cancel",
+		"GET /v1/vector_stores/vs_abc123/file_batches/vsfb_abc123/files",
+		"DELETE /v1/vector_stores/vs_abc123/files/file-abc123",
+		"DELETE /v1/vector_stores/vs_abc123",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestAuditLogs(t *testing.T) {
+	var requests []*recordedRequest
+	var ts = routesServer(map[string]string{
+		"GET /v1/organization/audit_logs": `{"object": "list", "first_id": "audit_log-1", "last_id": "audit_log-3", ` +
+			`"has_more": true, "data": [` +
+			`{"id": "audit_log-1", "type": "api_key.created", "effective_at": 1720804090, ` +
+			`"project": {"id": "proj_abc", "name": "Default"}, ` +
+			`"actor": {"type": "session", "session": {"user": {"id": "user-abc", "email": "user@example.com"}, "ip_address": "127.0.0.1"}}, ` +
+			`"api_key.created": {"id": "key_abc", "data": {"scopes": ["api.model.request"]}}}, ` +
+			`{"id": "audit_log-2", "type": "user.updated", "effective_at": 1720804100, ` +
+			`"actor": {"type": "api_key", "api_key": {"id": "key_def", "type": "service_account", "service_account": {"id": "svc_abc"}}}, ` +
+			`"user.updated": {"id": "user-def", "changes_requested": {"role": "owner"}}}, ` +
+			`{"id": "audit_log-3", "type": "login.succeeded", "effective_at": 1720804200, ` +
+			`"actor": {"type": "session", "session": {"user": {"id": "user-abc", "email": "user@example.com"}}}}]}`,
+	}, &requests)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithAdminKey(testAdminKey)(client)
+
+	var after, before = time.Unix(1720000000, 0), time.Unix(1730000000, 0)
+	var l, err = client.ListAuditLogs(context.Background(), &AuditLogsRequest{
+		EffectiveAfter:  &after,
+		EffectiveBefore: &before,
+		ProjectIDs:      []string{"proj_abc", "proj_def"},
+		EventTypes:      []auditlogs.Event{auditlogs.EventAPIKeyCreated, auditlogs.EventUserUpdated},
+		ActorEmails:     []string{"user@example.com"},
+		Limit:           3,
+		After:           "audit_log-0",
+		Before:          "audit_log-9",
+	})
+	if err != nil {
+		t.Fatalf("ListAuditLogs error: %v", err)
+	}
+
+	var want = url.Values{
+		"effective_at[gte]": {"1720000000"},
+		"effective_at[lt]":  {"1730000000"},
+		"project_ids[]":     {"proj_abc", "proj_def"},
+		"event_types[]":     {"api_key.created", "user.updated"},
+		"actor_emails[]":    {"user@example.com"},
+		"limit":             {"3"},
+		"after":             {"audit_log-0"},
+		"before":            {"audit_log-9"},
+	}
+	if !reflect.DeepEqual(requests[0].Query, want) {
+		t.Fatalf("unexpected query: %v", requests[0].Query)
+	}
+
+	if len(l.Data) != 3 || !l.HasMore || l.LastID != "audit_log-3" {
+		t.Fatalf("unexpected audit logs: %+v", l)
+	}
+	var created, updated, login = l.Data[0], l.Data[1], l.Data[2]
+	if created.Type != auditlogs.EventAPIKeyCreated || created.Project.ID != "proj_abc" ||
+		created.Actor.Session.User.Email != "user@example.com" {
+		t.Fatalf("unexpected audit log: %+v", created)
+	}
+	if created.APIKeyCreated == nil || !reflect.DeepEqual(created.APIKeyCreated.Data.Scopes, []string{"api.model.request"}) {
+		t.Fatalf("unexpected api_key.created payload: %+v", created.APIKeyCreated)
+	}
+	if updated.Type != auditlogs.EventUserUpdated || updated.Actor.APIKey.ServiceAccount.ID != "svc_abc" {
+		t.Fatalf("unexpected audit log: %+v", updated)
+	}
+	if updated.UserUpdated == nil || updated.UserUpdated.ChangesRequested.Role != "owner" || updated.APIKeyCreated != nil {
+		t.Fatalf("unexpected user.updated payload: %+v", updated.UserUpdated)
+	}
+	if login.Type != auditlogs.EventLoginSucceeded || login.Project != nil {
+		t.Fatalf("unexpected audit log: %+v", login)
+	}
+
+	// A nil request sends no query.
+	if _, err = client.ListAuditLogs(context.Background(), nil); err != nil {
+		t.Fatalf("ListAuditLogs error: %v", err)
+	}
+	if len(requests[1].Query) != 0 {
+		t.Fatalf("unexpected query: %v", requests[1].Query)
+	}
+}
+
+func TestFineTuningJobs(t *testing.T) {
+	const job = `{"id": "ftjob-abc123", "object": "fine_tuning.job", "model": "gpt-4o-mini-2024-07-18", ` +
+		`"created_at": 1721764800, "fine_tuned_model": null, "organization_id": "org-123", "result_files": [], ` +
+		`"status": "%s", "training_file": "file-abc123", "validation_file": null, "seed": 42, ` +
+		`"hyperparameters": {"n_epochs": 3, "batch_size": "auto", "learning_rate_multiplier": 1.8}}`
+
+	var requests []*recordedRequest
+	var ts = routesServer(map[string]string{
+		"POST /v1/fine_tuning/jobs":                     fmt.Sprintf(job, "validating_files"),
+		"GET /v1/fine_tuning/jobs":                      `{"object": "list", "data": [` + fmt.Sprintf(job, "running") + `], "has_more": true}`,
+		"GET /v1/fine_tuning/jobs/ftjob-abc123":         fmt.Sprintf(job, "running"),
+		"POST /v1/fine_tuning/jobs/ftjob-abc123/cancel": fmt.Sprintf(job, "cancelled"),
+		"GET /v1/fine_tuning/jobs/ftjob-abc123/events": `{"object": "list", "data": [{"id": "ftevent-abc123", ` +
+			`"object": "fine_tuning.job.event", "level": "info", "message": "Step 10/100: training loss=0.45", ` +
+			`"type": "metrics", "data": {"step": 10, "train_loss": 0.45}}], "has_more": false}`,
+		"GET /v1/fine_tuning/jobs/ftjob-abc123/checkpoints": `{"object": "list", "data": [{"id": "ftckpt-abc123", ` +
+			`"object": "fine_tuning.job.checkpoint", "fine_tuned_model_checkpoint": "ft:gpt-4o-mini-2024-07-18:org:custom:abc123:ckpt-step-10", ` +
+			`"fine_tuning_job_id": "ftjob-abc123", "step_number": 10, "metrics": {"step": 10, "train_loss": 0.45}}], "has_more": false}`,
+	}, &requests)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var j, err = client.CreateFineTuningJob(ctx, &FineTuningJobRequest{
+		Model:        models.GPT4oMini20240718,
+		TrainingFile: "file-abc123",
+		Hyperparameters: &Hyperparameters{
+			NEpochs:   HyperparameterValue(3),
+			BatchSize: HyperparameterAuto[int](),
+		},
+		Suffix: "custom",
+	})
+	if err != nil {
+		t.Fatalf("CreateFineTuningJob error: %v", err)
+	}
+	if j.ID != "ftjob-abc123" || j.Status != "validating_files" || j.Seed != 42 || j.FineTunedModel != nil {
+		t.Fatalf("unexpected job: %+v", j)
+	}
+	if j.Hyperparameters.NEpochs.Value() != 3 || !j.Hyperparameters.BatchSize.Auto() || j.Hyperparameters.LearningRateMultiplier.Value() != 1.8 {
+		t.Fatalf("unexpected hyperparameters: %+v", j.Hyperparameters)
+	}
+	var body = requests[0].Body
+	if body["model"] != "gpt-4o-mini-2024-07-18" || body["training_file"] != "file-abc123" || body["suffix"] != "custom" {
+		t.Fatalf("unexpected request body: %v", body)
+	}
+	if hp, _ := body["hyperparameters"].(map[string]any); hp["n_epochs"] != float64(3) || hp["batch_size"] != "auto" {
+		t.Fatalf("unexpected hyperparameters in request: %v", body["hyperparameters"])
+	}
+
+	var jobs *List[*FineTuningJob]
+	if jobs, err = client.ListFineTuningJobs(ctx, WithListOptions(&ListOptions{Limit: 1, After: "ftjob-abc000"})); err != nil {
+		t.Fatalf("ListFineTuningJobs error: %v", err)
+	}
+	if len(jobs.Data) != 1 || !jobs.HasMore || jobs.Data[0].Status != "running" {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+	if q := requests[1].Query; q.Get("limit") != "1" || q.Get("after") != "ftjob-abc000" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+
+	if j, err = client.RetrieveFineTuningJob(ctx, "ftjob-abc123"); err != nil || j.Status != "running" {
+		t.Fatalf("RetrieveFineTuningJob returned %+v, %v", j, err)
+	}
+	if j, err = client.CancelFineTuningJob(ctx, "ftjob-abc123"); err != nil || j.Status != "cancelled" {
+		t.Fatalf("CancelFineTuningJob returned %+v, %v", j, err)
+	}
+
+	var events *List[*FineTuningJobEvent]
+	if events, err = client.ListFineTuningEvents(ctx, "ftjob-abc123"); err != nil {
+		t.Fatalf("ListFineTuningEvents error: %v", err)
+	}
+	if len(events.Data) != 1 || events.Data[0].Type != "metrics" || !strings.Contains(string(events.Data[0].Data), `"train_loss"`) {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	var checkpoints *List[*FineTuningJobCheckpoint]
+	if checkpoints, err = client.ListFineTuningCheckpoints(ctx, "ftjob-abc123"); err != nil {
+		t.Fatalf("ListFineTuningCheckpoints error: %v", err)
+	}
+	if len(checkpoints.Data) != 1 || checkpoints.Data[0].StepNumber != 10 || checkpoints.Data[0].Metrics.TrainLoss != 0.45 {
+		t.Fatalf("unexpected checkpoints: %+v", checkpoints)
+	}
+
+	var got []string
+	for _, r := range requests {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	var want = []string{
+		"POST /v1/fine_tuning/jobs",
+		"GET /v1/fine_tuning/jobs",
+		"GET /v1/fine_tuning/jobs/ftjob-abc123",
+		"POST /v1/fine_tuning/jobs/ftjob-abc123/cancel",
+		"GET /v1/fine_tuning/jobs/ftjob-abc123/events",
+		"GET /v1/fine_tuning/jobs/ftjob-abc123/checkpoints",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestModels(t *testing.T) {
+	const model = `{"id": "%s", "object": "model", "created": 1686935002, "owned_by": "%s"}`
+	const ft = "ft:gpt-4o-mini-2024-07-18:org:custom:abc123"
+
+	var requests []*recordedRequest
+	var ts = routesServer(map[string]string{
+		"GET /v1/models": `{"object": "list", "data": [` + fmt.Sprintf(model, "gpt-4o", "system") + `, ` +
+			fmt.Sprintf(model, ft, "org-123") + `]}`,
+		"GET /v1/models/gpt-4o":   fmt.Sprintf(model, "gpt-4o", "system"),
+		"DELETE /v1/models/" + ft: `{"id": "` + ft + `", "object": "model", "deleted": true}`,
+	}, &requests)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var ctx = context.Background()
+
+	var l, err = client.ListModels(ctx)
+	if err != nil {
+		t.Fatalf("ListModels error: %v", err)
+	}
+	if len(l.Data) != 2 || l.Data[0].ID != "gpt-4o" || l.Data[1].OwnedBy != "org-123" {
+		t.Fatalf("unexpected models: %+v", l)
+	}
+
+	var m *Model
+	if m, err = client.RetrieveModel(ctx, "gpt-4o"); err != nil {
+		t.Fatalf("RetrieveModel error: %v", err)
+	}
+	if m.ID != "gpt-4o" || m.Object != objects.Model || m.Created != 1686935002 || m.OwnedBy != "system" {
+		t.Fatalf("unexpected model: %+v", m)
+	}
+
+	var d *DeletionResponse
+	if d, err = client.DeleteModel(ctx, ft); err != nil || !d.Deleted || d.ID != ft {
+		t.Fatalf("DeleteModel returned %+v, %v", d, err)
+	}
+
+	if _, err = client.RetrieveModel(ctx, "does-not-exist"); err == nil {
+		t.Fatal("expected an error retrieving a model which does not exist")
+	}
+
+	var got []string
+	for _, r := range requests {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	var want = []string{"GET /v1/models", "GET /v1/models/gpt-4o", "DELETE /v1/models/" + ft, "GET /v1/models/does-not-exist"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestCosts(t *testing.T) {
+	var requests []*recordedRequest
+	var ts = routesServer(map[string]string{
+		"GET /v1/organization/costs": `{"object": "page", "has_more": true, "next_page": "page_AAAA", "data": [` +
+			`{"object": "bucket", "start_time": 1730419200, "end_time": 1730505600, "results": [` +
+			`{"object": "organization.costs.result", "amount": {"value": 0.06, "currency": "usd"}, ` +
+			`"line_item": "gpt-4o, input", "project_id": "proj_abc"}, ` +
+			`{"object": "organization.costs.result", "amount": {"value": 0.12, "currency": "usd"}, ` +
+			`"line_item": "gpt-4o, output", "project_id": "proj_abc"}]}]}`,
+	}, &requests)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	WithAdminKey(testAdminKey)(client)
+
+	var start, end = time.Unix(1730419200, 0), time.Unix(1731024000, 0)
+	var p, err = client.GetCosts(context.Background(), &CostsRequest{
+		StartTime:   start,
+		EndTime:     &end,
+		BucketWidth: "1d",
+		ProjectIDs:  []string{"proj_abc", "proj_def"},
+		GroupBy:     []string{"project_id", "line_item"},
+		Limit:       7,
+		Page:        "page_0000",
+	})
+	if err != nil {
+		t.Fatalf("GetCosts error: %v", err)
+	}
+
+	var want = url.Values{
+		"start_time":    {"1730419200"},
+		"end_time":      {"1731024000"},
+		"bucket_width":  {"1d"},
+		"project_ids[]": {"proj_abc", "proj_def"},
+		"group_by[]":    {"project_id", "line_item"},
+		"limit":         {"7"},
+		"page":          {"page_0000"},
+	}
+	if !reflect.DeepEqual(requests[0].Query, want) {
+		t.Fatalf("unexpected query: %v", requests[0].Query)
+	}
+
+	if !p.HasMore || p.NextPage == nil || *p.NextPage != "page_AAAA" || len(p.Data) != 1 {
+		t.Fatalf("unexpected page: %+v", p)
+	}
+	var b = p.Data[0]
+	if b.StartTime != 1730419200 || b.EndTime != 1730505600 || len(b.Results) != 2 {
+		t.Fatalf("unexpected bucket: %+v", b)
+	}
+	var r = b.Results[1]
+	if r.Amount.Value != 0.12 || r.Amount.Currency != "usd" || *r.LineItem != "gpt-4o, output" || *r.ProjectID != "proj_abc" {
+		t.Fatalf("unexpected result: %+v", r)
+	}
+}
+
+func TestDebug(t *testing.T) {
+	var ts = OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	var client, _ = newTestClient(ts.URL)
+	WithDebug(&buf)(client)
+
+	if _, err := client.RetrieveModel(context.Background(), "does-not-exist"); err == nil {
+		t.Fatalf("expected RetrieveModel to fail")
+	}
+
+	var out = buf.String()
+	for _, s := range []string{"GET /v1/models/does-not-exist", "Authorization: REDACTED", "404 Not Found", "model_not_found"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expected dump to contain %q, got: %s", s, out)
+		}
+	}
+	if strings.Contains(out, testToken) {
+		t.Fatalf("expected the token to be masked, got: %s", out)
+	}
+}
+
+// OpenAITestServer Creates a mocked OpenAI server which can pretend to handle requests during testing.
+func OpenAITestServer() *httptest.Server {
+	return httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("received request at path %q\n", r.URL.Path)
+		w.Header().Set("x-request-id", testRequestID)
+
+		// check auth
+		var token = testToken
+		if strings.HasPrefix(r.URL.Path, "/v1/organization/") {
+			token = testAdminKey
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
@@ -372,6 +4849,33 @@ func OpenAITestServer() *httptest.Server {
 			return
 		case "/v1/images/generations":
 			handleImageEndpoint(w, r)
+		case "/v1/audio/transcriptions":
+			handleTranscriptionEndpoint(w, r)
+		case "/v1/audio/speech":
+			handleSpeechEndpoint(w, r)
+		case "/v1/fine_tuning/jobs/ftjob-abc123/events":
+			handleFineTuningEventsEndpoint(w, r)
+		case "/v1/assistants":
+			handleAssistantsEndpoint(w, r)
+		case "/v1/uploads/upload_abc123/parts":
+			handleUploadPartsEndpoint(w, r)
+		case "/v1/organization/projects":
+			var b, _ = json.Marshal(&List[*Project]{Object: objects.List})
+			_, _ = w.Write(b)
+		case "/v1/files":
+			handleFilesEndpoint(w, r)
+		case "/v1/files/file-abc123/content":
+			_, _ = io.WriteString(w, `{"prompt": "Lorem", "completion": "ipsum"}`)
+		case "/v1/models/does-not-exist":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error": {"message": "The model 'does-not-exist' does not exist", `+
+				`"type": "invalid_request_error", "param": "model", "code": "model_not_found"}}`)
+		case "/v1/models/rate-limited":
+			w.Header().Set("Retry-After", "2")
+			w.Header().Set("x-ratelimit-remaining-tokens", "10")
+			w.Header().Set("x-ratelimit-reset-tokens", "6m0s")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"error": {"message": "Rate limit reached", "type": "requests", "code": "rate_limit_exceeded"}}`)
 		// TODO: Implement the other endpoints.
 		default:
 			// the endpoint doesn't exist
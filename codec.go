@@ -0,0 +1,39 @@
+package openai
+
+import "encoding/json"
+
+// Codec encodes the JSON bodies of requests and decodes the JSON bodies of responses and stream events (see
+// WithCodec), e.g. to replace encoding/json with a faster implementation such as jsoniter or sonic. Implementations
+// must be compatible with encoding/json, honoring its struct tags and the json.Marshaler and json.Unmarshaler
+// interfaces, and must be safe for concurrent use.
+type Codec interface {
+	// Marshal returns the JSON encoding of |v|.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal parses the JSON encoded |data| and stores the result in |v|. |data| is reused once Unmarshal returns,
+	// so |v| must not retain references to it (e.g. strings which share its memory).
+	Unmarshal(data []byte, v any) error
+}
+
+// StdCodec is a Codec which uses the encoding/json package. It is used by default.
+type StdCodec struct{}
+
+var _ Codec = StdCodec{}
+
+// Marshal implements the Codec interface.
+func (StdCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements the Codec interface.
+func (StdCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// codecOrDefault returns |c|, or StdCodec if it is nil.
+func codecOrDefault(c Codec) Codec {
+	if c == nil {
+		return StdCodec{}
+	}
+
+	return c
+}
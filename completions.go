@@ -2,7 +2,7 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 
 	"github.com/fabiustech/openai/models"
 	"github.com/fabiustech/openai/objects"
@@ -15,10 +15,11 @@ type CompletionRequest[T models.Completion | models.FineTunedModel] struct {
 	// See more here: https://beta.openai.com/docs/models/overview
 	Model T `json:"model"`
 	// Prompt specifies the prompt(s) to generate completions for, encoded as a string, array of strings, array of
-	// tokens, or array of token arrays. Note that <|endoftext|> is the document separator that the model sees during
-	// training, so if a prompt is not specified the model will generate as if from the beginning of a new document.
+	// tokens, or array of token arrays (see TextPrompt, TextPrompts, TokenPrompt, and TokenPrompts). Note that
+	// <|endoftext|> is the document separator that the model sees during training, so if a prompt is not specified
+	// the model will generate as if from the beginning of a new document.
 	// Defaults to <|endoftext|>.
-	Prompt string `json:"prompt,omitempty"`
+	Prompt *Prompt `json:"prompt,omitempty"`
 	// Suffix specifies the suffix that comes after a completion of inserted text.
 	// Defaults to null.
 	Suffix string `json:"suffix,omitempty"`
@@ -59,8 +60,8 @@ type CompletionRequest[T models.Completion | models.FineTunedModel] struct {
 	// Defaults to false.
 	Echo bool `json:"echo,omitempty"`
 	// Stop specifies up to 4 sequences where the API will stop generating further tokens. The returned text will not
-	// contain the stop sequence.
-	Stop []string `json:"stop,omitempty"`
+	// contain the stop sequence. Requests with more than 4 sequences are rejected before being sent.
+	Stop StopSequences `json:"stop,omitempty"`
 	// PresencePenalty can be a number between -2.0 and 2.0. Positive values penalize new tokens based on whether they
 	// appear in the text so far, increasing the model's likelihood to talk about new topics.
 	// Defaults to 0.
@@ -93,6 +94,25 @@ type CompletionRequest[T models.Completion | models.FineTunedModel] struct {
 	User string `json:"user,omitempty"`
 }
 
+// model returns the model of the request (see WithModelRoute).
+func (cr *CompletionRequest[T]) model() string {
+	return fmt.Sprint(cr.Model)
+}
+
+// maxTokens returns the maximum number of tokens each completion of |cr| may generate.
+func (cr *CompletionRequest[T]) maxTokens() int {
+	if cr.MaxTokens == 0 {
+		return 16
+	}
+
+	return cr.MaxTokens
+}
+
+// validate returns an error if |cr| contains parameter values which would be rejected by the API.
+func (cr *CompletionRequest[T]) validate() error {
+	return cr.Stop.validate()
+}
+
 // CompletionChoice represents one of possible completions.
 type CompletionChoice struct {
 	Text         string         `json:"text"`
@@ -111,6 +131,8 @@ type LogprobResult struct {
 
 // CompletionResponse is the response from the completions endpoint.
 type CompletionResponse[T models.Completion | models.FineTunedModel] struct {
+	ResponseMeta
+
 	ID      string              `json:"id"`
 	Object  objects.Object      `json:"object"`
 	Created uint64              `json:"created"`
@@ -120,14 +142,21 @@ type CompletionResponse[T models.Completion | models.FineTunedModel] struct {
 }
 
 // CreateCompletion creates a completion for the provided prompt and parameters.
-func (c *Client) CreateCompletion(ctx context.Context, cr *CompletionRequest[models.Completion]) (*CompletionResponse[models.Completion], error) {
-	var b, err = c.post(ctx, routes.Completions, cr)
+func (c *Client) CreateCompletion(ctx context.Context, cr *CompletionRequest[models.Completion], opts ...RequestOption) (*CompletionResponse[models.Completion], error) {
+	if err := cr.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validatePrompt(fmt.Sprint(cr.Model), cr.Prompt, cr.Suffix, cr.maxTokens()); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.Completions, cr, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &CompletionResponse[models.Completion]{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
@@ -135,14 +164,21 @@ func (c *Client) CreateCompletion(ctx context.Context, cr *CompletionRequest[mod
 }
 
 // CreateFineTunedCompletion creates a completion for the provided prompt and parameters, using a fine-tuned model.
-func (c *Client) CreateFineTunedCompletion(ctx context.Context, cr *CompletionRequest[models.FineTunedModel]) (*CompletionResponse[models.FineTunedModel], error) {
-	var b, err = c.post(ctx, routes.Completions, cr)
+func (c *Client) CreateFineTunedCompletion(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error) {
+	if err := cr.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validatePrompt(fmt.Sprint(cr.Model), cr.Prompt, cr.Suffix, cr.maxTokens()); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.Completions, cr, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &CompletionResponse[models.FineTunedModel]{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
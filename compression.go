@@ -0,0 +1,128 @@
+package openai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultCompressionMinSize is the minimum size of a request body for it to be compressed, by default.
+const defaultCompressionMinSize = 1024
+
+// CompressionConfig configures the compression of requests and responses (see WithCompression).
+type CompressionConfig struct {
+	// MinSize is the minimum size, in bytes, of a JSON request body for it to be compressed. Smaller bodies are sent
+	// as is, as compressing them saves little.
+	// Defaults to 1024.
+	MinSize int
+}
+
+// compression gzip compresses request bodies, and decompresses gzip responses.
+type compression struct {
+	minSize int
+}
+
+// newCompression returns a *compression configured by |cfg|, which may be nil.
+func newCompression(cfg *CompressionConfig) *compression {
+	var cp = &compression{minSize: defaultCompressionMinSize}
+	if cfg != nil && cfg.MinSize > 0 {
+		cp.minSize = cfg.MinSize
+	}
+
+	return cp
+}
+
+// do sends |req| with |hc|, compressing its body and decompressing the response.
+func (cp *compression) do(hc *http.Client, req *http.Request) (*http.Response, error) {
+	var r, err = cp.compress(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *http.Response
+	if resp, err = hc.Do(r); err != nil {
+		return nil, err
+	}
+	if err = decompress(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// compress returns a copy of |req| which accepts gzip responses, with its body gzip compressed if it is JSON of at
+// least the minimum size. |req| itself is not modified, so that it can be rewound and compressed again if retried.
+func (cp *compression) compress(req *http.Request) (*http.Request, error) {
+	var r = req.Clone(req.Context())
+	// Setting Accept-Encoding disables the transparent decompression of the transport, so responses are decompressed
+	// by decompress regardless of how the transport is configured.
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	if req.ContentLength < int64(cp.minSize) || r.Header.Get("Content-Encoding") != "" ||
+		!strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return r, nil
+	}
+
+	var b, err = requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	var zw = gzip.NewWriter(&buf)
+	if _, err = zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+
+	var z = buf.Bytes()
+	r.Body = io.NopCloser(bytes.NewReader(z))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(z)), nil
+	}
+	r.ContentLength = int64(len(z))
+	r.Header.Set("Content-Encoding", "gzip")
+
+	return r, nil
+}
+
+// decompress replaces the body of |resp| with its decompressed contents, if it is gzip encoded.
+func decompress(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	var zr, err = gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return nil
+}
+
+// gzipBody is a decompressed response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+// Close implements the io.Closer interface.
+func (b *gzipBody) Close() error {
+	var err = b.Reader.Close()
+	if cerr := b.body.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
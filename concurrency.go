@@ -0,0 +1,41 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// semaphore limits the number of requests in flight at once.
+type semaphore chan struct{}
+
+// acquire blocks until a slot is available or |ctx| is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot acquired with acquire.
+func (s semaphore) release() {
+	<-s
+}
+
+// releaseBody wraps a response body, calling |release| when the body is closed. This keeps resources tied to a request
+// (e.g. its semaphore slot or deadline) held until the caller is finished with a streamed response.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close implements the io.Closer interface.
+func (b *releaseBody) Close() error {
+	var err = b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,54 @@
+// Package content contains the enum values which represent the types
+// of the parts of multi-part chat message content, and their options.
+package content
+
+// Type represents the enum values for the types of content parts.
+type Type int
+
+const (
+	// TypeInvalid represents an invalid Type option.
+	TypeInvalid Type = iota
+	// TypeText specifies a text content part.
+	TypeText
+	// TypeImageURL specifies an image content part, referenced by a URL
+	// or embedded as a data URI.
+	TypeImageURL
+	// TypeInputAudio specifies an audio content part, embedded as base64
+	// encoded data.
+	TypeInputAudio
+)
+
+// String implements the fmt.Stringer interface.
+func (t Type) String() string {
+	return typeToString[t]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t Type) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |t| to TypeInvalid.
+func (t *Type) UnmarshalText(b []byte) error {
+	if val, ok := stringToType[(string(b))]; ok {
+		*t = val
+		return nil
+	}
+
+	*t = TypeInvalid
+
+	return nil
+}
+
+var typeToString = map[Type]string{
+	TypeText:       "text",
+	TypeImageURL:   "image_url",
+	TypeInputAudio: "input_audio",
+}
+
+var stringToType = map[string]Type{
+	"text":        TypeText,
+	"image_url":   TypeImageURL,
+	"input_audio": TypeInputAudio,
+}
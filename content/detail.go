@@ -0,0 +1,54 @@
+package content
+
+// Detail represents the enum values for the fidelity with which the
+// model processes an image.
+type Detail int
+
+const (
+	// DetailInvalid represents an invalid Detail option.
+	DetailInvalid Detail = iota
+	// DetailAuto lets the model choose between DetailLow and DetailHigh
+	// based on the size of the image.
+	DetailAuto
+	// DetailLow processes a low-resolution version of the image, using
+	// fewer tokens.
+	DetailLow
+	// DetailHigh processes the image at high resolution, allowing the
+	// model to see finer details at the cost of more tokens.
+	DetailHigh
+)
+
+// String implements the fmt.Stringer interface.
+func (d Detail) String() string {
+	return detailToString[d]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Detail) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |d| to DetailInvalid.
+func (d *Detail) UnmarshalText(b []byte) error {
+	if val, ok := stringToDetail[(string(b))]; ok {
+		*d = val
+		return nil
+	}
+
+	*d = DetailInvalid
+
+	return nil
+}
+
+var detailToString = map[Detail]string{
+	DetailAuto: "auto",
+	DetailLow:  "low",
+	DetailHigh: "high",
+}
+
+var stringToDetail = map[string]Detail{
+	"auto": DetailAuto,
+	"low":  DetailLow,
+	"high": DetailHigh,
+}
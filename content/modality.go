@@ -0,0 +1,47 @@
+package content
+
+// Modality represents the enum values for the types of output which
+// the model generates.
+type Modality int
+
+const (
+	// ModalityInvalid represents an invalid Modality option.
+	ModalityInvalid Modality = iota
+	// ModalityText specifies text output.
+	ModalityText
+	// ModalityAudio specifies audio output, for models which support it.
+	ModalityAudio
+)
+
+// String implements the fmt.Stringer interface.
+func (m Modality) String() string {
+	return modalityToString[m]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m Modality) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |m| to ModalityInvalid.
+func (m *Modality) UnmarshalText(b []byte) error {
+	if val, ok := stringToModality[(string(b))]; ok {
+		*m = val
+		return nil
+	}
+
+	*m = ModalityInvalid
+
+	return nil
+}
+
+var modalityToString = map[Modality]string{
+	ModalityText:  "text",
+	ModalityAudio: "audio",
+}
+
+var stringToModality = map[string]Modality{
+	"text":  ModalityText,
+	"audio": ModalityAudio,
+}
@@ -0,0 +1,179 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fabiustech/openai/tokenizer"
+)
+
+// ErrContextLengthExceeded is matched (via errors.Is) by a *ContextLengthError.
+var ErrContextLengthExceeded = errors.New("openai: context length exceeded")
+
+// ContextLengthError is returned, without sending the request, when the prompt of a request plus the tokens it may
+// generate exceed the context window of its model (see WithContextValidation).
+type ContextLengthError struct {
+	// Model is the model of the request.
+	Model string
+	// PromptTokens is the (estimated, for text) number of tokens in the prompt.
+	PromptTokens int
+	// MaxTokens is the maximum number of tokens the request may generate.
+	MaxTokens int
+	// ContextWindow is the maximum number of tokens supported by Model.
+	ContextWindow int
+}
+
+// Error implements the error interface.
+func (e *ContextLengthError) Error() string {
+	return fmt.Sprintf("openai: %s has a context window of %d tokens, but the request has %d prompt tokens and "+
+		"max_tokens of %d (%d in total); shorten the prompt or reduce max_tokens",
+		e.Model, e.ContextWindow, e.PromptTokens, e.MaxTokens, e.PromptTokens+e.MaxTokens)
+}
+
+// Is reports whether |target| is ErrContextLengthExceeded.
+func (e *ContextLengthError) Is(target error) bool {
+	return target == ErrContextLengthExceeded
+}
+
+// ContextWindows maps model names to the maximum number of tokens (prompt plus completion) they support. Models are
+// matched by their longest prefix in the table, and fine-tuned models (e.g. "ft:gpt-4o-mini:org::id") by their base
+// model.
+type ContextWindows map[string]int
+
+// DefaultContextWindows contains the context windows of OpenAI's models.
+var DefaultContextWindows = ContextWindows{
+	"gpt-4o":                 128000,
+	"chatgpt-4o":             128000,
+	"gpt-4-turbo":            128000,
+	"gpt-4-0125-preview":     128000,
+	"gpt-4-1106-preview":     128000,
+	"gpt-4":                  8192,
+	"gpt-4-32k":              32768,
+	"gpt-3.5-turbo":          16385,
+	"gpt-3.5-turbo-instruct": 4096,
+	"o1":                     200000,
+	"o1-mini":                128000,
+	"o1-preview":             128000,
+	"o3-mini":                200000,
+	"davinci-002":            16384,
+	"babbage-002":            16384,
+	"text-davinci-003":       4097,
+	"text-davinci-002":       4097,
+	"code-davinci-002":       8001,
+	"code-cushman-001":       2048,
+	"text-curie-001":         2049,
+	"text-babbage-001":       2049,
+	"text-ada-001":           2049,
+	"davinci":                2049,
+	"curie":                  2049,
+	"babbage":                2049,
+	"ada":                    2049,
+	"text-embedding-3-small": 8191,
+	"text-embedding-3-large": 8191,
+	"text-embedding-ada-002": 8191,
+	"text-similarity-":       2046,
+	"text-search-":           2046,
+	"code-search-":           2046,
+}
+
+// Lookup returns the context window of |model|, and whether it was found.
+func (w ContextWindows) Lookup(model string) (int, bool) {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.TrimPrefix(model, "ft:")
+	}
+
+	return longestPrefix(w, model)
+}
+
+// Validate returns a *ContextLengthError if |promptTokens| plus |maxTokens| exceed the context window of |model|.
+// Models with an unknown context window are not validated.
+func (w ContextWindows) Validate(model string, promptTokens, maxTokens int) error {
+	var window, ok = w.Lookup(model)
+	if !ok || promptTokens+maxTokens <= window {
+		return nil
+	}
+
+	return &ContextLengthError{
+		Model:         model,
+		PromptTokens:  promptTokens,
+		MaxTokens:     maxTokens,
+		ContextWindow: window,
+	}
+}
+
+// validatePrompt validates each prompt of |p| (and |suffix|) for |model|, if context validation is enabled. Text is
+// counted with the tokenizer package, so counts are estimates unless the model's encoding has been registered.
+func (c *Client) validatePrompt(model string, p *Prompt, suffix string, maxTokens int) error {
+	if c.contextWindows == nil {
+		return nil
+	}
+
+	var counts []int
+	switch {
+	case p.Tokens() != nil:
+		for _, t := range p.Tokens() {
+			counts = append(counts, len(t))
+		}
+	case p.Text() != nil:
+		for _, s := range p.Text() {
+			counts = append(counts, tokenizer.Count(model, s))
+		}
+	default:
+		// The prompt defaults to <|endoftext|>.
+		counts = append(counts, 1)
+	}
+
+	var extra = tokenizer.Count(model, suffix)
+	for _, n := range counts {
+		if err := c.contextWindows.Validate(model, n+extra, maxTokens); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMessages validates |messages| for |model|, which may generate |maxTokens|, if context validation is enabled.
+func (c *Client) validateMessages(model string, messages []*ChatMessage, maxTokens int) error {
+	if c.contextWindows == nil {
+		return nil
+	}
+
+	return c.contextWindows.Validate(model, countMessageTokens(model, messages), maxTokens)
+}
+
+// countMessageTokens estimates the number of tokens in |messages| when sent to |model|. Each message is counted as its
+// content plus a few tokens of formatting, as the API does.
+func countMessageTokens(model string, messages []*ChatMessage) int {
+	// Every reply is primed with <|start|>assistant<|message|>.
+	var n = 3
+	for _, m := range messages {
+		n += 3 + tokenizer.Count(model, m.Text())
+		if m.Name != "" {
+			n += 1 + tokenizer.Count(model, m.Name)
+		}
+		for _, tc := range m.ToolCalls {
+			if tc.Function != nil {
+				n += tokenizer.Count(model, tc.Function.Name) + tokenizer.Count(model, tc.Function.Arguments)
+			}
+		}
+	}
+
+	return n
+}
+
+// validateInputs validates each of |inputs| for |model|, which generates no tokens, if context validation is enabled.
+func (c *Client) validateInputs(model string, inputs []string) error {
+	if c.contextWindows == nil {
+		return nil
+	}
+
+	for _, s := range inputs {
+		if err := c.contextWindows.Validate(model, tokenizer.Count(model, s), 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
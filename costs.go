@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+// CostsRequest contains all relevant fields for requests to the organization/costs endpoint.
+type CostsRequest struct {
+	// StartTime is the start of the time range (inclusive).
+	StartTime time.Time
+	// EndTime is the end of the time range (exclusive).
+	// Defaults to now.
+	EndTime *time.Time
+	// BucketWidth is the width of each time bucket in the response. Currently only "1d" is supported.
+	// Defaults to "1d".
+	BucketWidth string
+	// ProjectIDs limits the results to only these projects.
+	ProjectIDs []string
+	// GroupBy groups the costs by the specified fields. Supported fields are "project_id" and "line_item".
+	GroupBy []string
+	// Limit specifies the number of daily buckets to return, between 1 and 180.
+	// Defaults to 7.
+	Limit int
+	// Page is a cursor for pagination, set to the value of NextPage from the previous response.
+	Page string
+}
+
+// values returns |cr| encoded as query parameters.
+func (cr *CostsRequest) values() url.Values {
+	var v = url.Values{
+		"start_time": {strconv.FormatInt(cr.StartTime.Unix(), 10)},
+	}
+
+	if cr.EndTime != nil {
+		v.Set("end_time", strconv.FormatInt(cr.EndTime.Unix(), 10))
+	}
+
+	if cr.BucketWidth != "" {
+		v.Set("bucket_width", cr.BucketWidth)
+	}
+
+	for _, id := range cr.ProjectIDs {
+		v.Add("project_ids[]", id)
+	}
+
+	for _, g := range cr.GroupBy {
+		v.Add("group_by[]", g)
+	}
+
+	if cr.Limit > 0 {
+		v.Set("limit", strconv.Itoa(cr.Limit))
+	}
+
+	if cr.Page != "" {
+		v.Set("page", cr.Page)
+	}
+
+	return v
+}
+
+// CostsPage is a page of daily cost buckets.
+type CostsPage struct {
+	ResponseMeta
+
+	Object objects.Object `json:"object"`
+	Data   []*CostsBucket `json:"data"`
+	// HasMore is true if there are more buckets to retrieve.
+	HasMore bool `json:"has_more"`
+	// NextPage is the cursor to set on CostsRequest.Page to retrieve the next page.
+	NextPage *string `json:"next_page"`
+}
+
+// CostsBucket contains the costs incurred during a single time bucket.
+type CostsBucket struct {
+	Object objects.Object `json:"object"`
+	// StartTime is the Unix timestamp (in seconds) of the start of the bucket (inclusive).
+	StartTime uint64 `json:"start_time"`
+	// EndTime is the Unix timestamp (in seconds) of the end of the bucket (exclusive).
+	EndTime uint64         `json:"end_time"`
+	Results []*CostsResult `json:"results"`
+}
+
+// CostsResult is a single line item of the costs incurred during a bucket.
+type CostsResult struct {
+	Object objects.Object `json:"object"`
+	Amount *CostsAmount   `json:"amount"`
+	// LineItem is only set when grouping by "line_item".
+	LineItem *string `json:"line_item"`
+	// ProjectID is only set when grouping by "project_id".
+	ProjectID *string `json:"project_id"`
+}
+
+// CostsAmount is a monetary amount.
+type CostsAmount struct {
+	// Value is the numeric value of the cost.
+	Value float64 `json:"value"`
+	// Currency is the lowercase ISO-4217 currency code (e.g. "usd").
+	Currency string `json:"currency"`
+}
+
+// GetCosts returns the costs incurred by the organization, bucketed by day. This endpoint requires an admin API key
+// (see WithAdminKey).
+func (c *Client) GetCosts(ctx context.Context, cr *CostsRequest, opts ...RequestOption) (*CostsPage, error) {
+	var res, err = c.get(ctx, withQuery(routes.OrganizationCosts, cr.values()), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &CostsPage{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,94 @@
+package openai
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// maskedHeaders are the headers whose values are masked in debug dumps.
+var maskedHeaders = []string{"Authorization", "Api-Key", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// debugWriter serializes writes from concurrent requests to a debug dump.
+type debugWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write implements the io.Writer interface.
+func (d *debugWriter) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.w.Write(p)
+}
+
+// dumpRequest writes |req|, including its body, to the debug dump with sensitive headers masked.
+func (d *debugWriter) dumpRequest(req *http.Request) {
+	var r = req.Clone(req.Context())
+	maskHeaders(r.Header)
+
+	// The clone shares the body of |req|, so a fresh copy is needed to dump it without consuming the original.
+	r.Body = nil
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			r.Body = body
+		}
+	}
+
+	var b, err = httputil.DumpRequestOut(r, r.Body != nil)
+	if err != nil {
+		_, _ = fmt.Fprintf(d, "openai: failed to dump request: %v\n\n", err)
+		return
+	}
+
+	_, _ = fmt.Fprintf(d, "%s\n\n", b)
+}
+
+// dumpResponse writes the status and headers of |resp| to the debug dump, and arranges for its body to be written as
+// it is read. Streamed responses (e.g. server-sent events) are therefore dumped frame by frame as they arrive.
+func (d *debugWriter) dumpResponse(resp *http.Response) {
+	var h = resp.Header
+	resp.Header = h.Clone()
+	maskHeaders(resp.Header)
+
+	var b, err = httputil.DumpResponse(resp, false)
+	resp.Header = h
+	if err != nil {
+		_, _ = fmt.Fprintf(d, "openai: failed to dump response: %v\n\n", err)
+		return
+	}
+
+	_, _ = d.Write(b)
+	resp.Body = &teeBody{ReadCloser: resp.Body, w: d}
+}
+
+// teeBody writes everything read from a response body to |w|.
+type teeBody struct {
+	io.ReadCloser
+	w io.Writer
+}
+
+// Read implements the io.Reader interface.
+func (t *teeBody) Read(p []byte) (int, error) {
+	var n, err = t.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = t.w.Write(p[:n])
+	}
+	if err == io.EOF {
+		_, _ = t.w.Write([]byte("\n\n"))
+	}
+
+	return n, err
+}
+
+// maskHeaders replaces the values of any sensitive headers in |h|.
+func maskHeaders(h http.Header) {
+	for _, k := range maskedHeaders {
+		if _, ok := h[k]; ok {
+			h.Set(k, "REDACTED")
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package openai
+
+import (
+	"github.com/fabiustech/openai/objects"
+)
+
+// DeletionResponse is the response returned from endpoints which delete objects.
+type DeletionResponse struct {
+	ResponseMeta
+
+	// ID is the ID of the deleted object.
+	ID string `json:"id"`
+	// Object specifies the type of the deleted object (e.g. Model).
+	Object objects.Object `json:"object"`
+	// Deleted is true if the object was deleted.
+	Deleted bool `json:"deleted"`
+}
@@ -2,7 +2,6 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/fabiustech/openai/models"
 	"github.com/fabiustech/openai/objects"
@@ -35,6 +34,11 @@ type EditsRequest struct {
 	TopP *float64 `json:"top_p,omitempty"`
 }
 
+// model returns the model of the request (see WithModelRoute).
+func (er *EditsRequest) model() string {
+	return er.Model.String()
+}
+
 // EditsChoice represents one of possible edits.
 type EditsChoice struct {
 	Text  string `json:"text"`
@@ -43,6 +47,8 @@ type EditsChoice struct {
 
 // EditsResponse represents a response structure for Edits API.
 type EditsResponse struct {
+	ResponseMeta
+
 	Object  objects.Object `json:"object"` // "edit"
 	Created uint64         `json:"created"`
 	Usage   *Usage         `json:"usage"`
@@ -50,14 +56,14 @@ type EditsResponse struct {
 }
 
 // CreateEdit creates a new edit for the provided input, instruction, and parameters.
-func (c *Client) CreateEdit(ctx context.Context, er *EditsRequest) (*EditsResponse, error) {
-	var b, err = c.post(ctx, routes.Edits, er)
+func (c *Client) CreateEdit(ctx context.Context, er *EditsRequest, opts ...RequestOption) (*EditsResponse, error) {
+	var res, err = c.post(ctx, routes.Edits, er, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &EditsResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
@@ -2,7 +2,13 @@ package openai
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/fabiustech/openai/embeddings"
 
 	"github.com/fabiustech/openai/models"
 	"github.com/fabiustech/openai/objects"
@@ -21,8 +27,53 @@ type Embedding struct {
 	Index     int            `json:"index"`
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface. Embeddings returned with embeddings.FormatBase64 are decoded
+// from their packed float32 values.
+func (e *Embedding) UnmarshalJSON(b []byte) error {
+	type embedding Embedding
+	var v struct {
+		*embedding
+		Embedding json.RawMessage `json:"embedding"`
+	}
+	v.embedding = (*embedding)(e)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if len(v.Embedding) == 0 || v.Embedding[0] != '"' {
+		e.Embedding = nil
+		if len(v.Embedding) == 0 {
+			return nil
+		}
+		return json.Unmarshal(v.Embedding, &e.Embedding)
+	}
+
+	var s string
+	if err := json.Unmarshal(v.Embedding, &s); err != nil {
+		return err
+	}
+
+	var packed, err = base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(packed)%4 != 0 {
+		return errors.New("openai: invalid base64 embedding length")
+	}
+
+	e.Embedding = make([]float64, len(packed)/4)
+	for i := range e.Embedding {
+		e.Embedding[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(packed[i*4:])))
+	}
+
+	return nil
+}
+
 // EmbeddingResponse is the response from a Create embeddings request.
 type EmbeddingResponse struct {
+	ResponseMeta
+
 	*List[*Embedding]
 	Model models.Embedding
 	Usage *Usage
@@ -37,17 +88,30 @@ type EmbeddingRequest struct {
 	Model models.Embedding `json:"model"`
 	// User is a unique identifier representing your end-user, which will help OpenAI to monitor and detect abuse.
 	User string `json:"user"`
+	// EncodingFormat specifies the format in which vectors are returned. Vectors are decoded into Embedding.Embedding
+	// regardless of the format.
+	// Defaults to embeddings.FormatFloat.
+	EncodingFormat embeddings.Format `json:"encoding_format,omitempty"`
+}
+
+// model returns the model of the request (see WithModelRoute).
+func (er *EmbeddingRequest) model() string {
+	return er.Model.String()
 }
 
 // CreateEmbeddings creates an embedding vector representing the input text.
-func (c *Client) CreateEmbeddings(ctx context.Context, request *EmbeddingRequest) (*EmbeddingResponse, error) {
-	var b, err = c.post(ctx, routes.Embeddings, request)
+func (c *Client) CreateEmbeddings(ctx context.Context, request *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error) {
+	if err := c.validateInputs(request.Model.String(), request.Input); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.Embeddings, request, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &EmbeddingResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
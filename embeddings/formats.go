@@ -0,0 +1,50 @@
+// Package embeddings contains the enum values which represent the various
+// encoding formats in which the OpenAI embeddings endpoint returns vectors.
+package embeddings
+
+// Format represents the enum values for the formats in which
+// embedding vectors are returned.
+type Format int
+
+const (
+	// FormatInvalid represents an invalid Format option.
+	FormatInvalid Format = iota
+	// FormatFloat specifies that the API will return vectors as arrays of floating point numbers.
+	FormatFloat
+	// FormatBase64 specifies that the API will return vectors as base64 encoded little-endian float32 values, which
+	// are much smaller and faster to parse than FormatFloat for large batches. They are decoded transparently.
+	FormatBase64
+)
+
+// String implements the fmt.Stringer interface.
+func (f Format) String() string {
+	return formatToString[f]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |f| to FormatInvalid.
+func (f *Format) UnmarshalText(b []byte) error {
+	if val, ok := stringToFormat[(string(b))]; ok {
+		*f = val
+		return nil
+	}
+
+	*f = FormatInvalid
+
+	return nil
+}
+
+var formatToString = map[Format]string{
+	FormatFloat:  "float",
+	FormatBase64: "base64",
+}
+
+var stringToFormat = map[string]Format{
+	"float":  FormatFloat,
+	"base64": FormatBase64,
+}
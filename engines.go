@@ -2,7 +2,6 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
 	"path"
 
 	"github.com/fabiustech/openai/routes"
@@ -10,6 +9,8 @@ import (
 
 // Engine contains all relevant fields for requests to the engines endpoint.
 type Engine struct {
+	ResponseMeta
+
 	ID     string `json:"id"`
 	Object string `json:"object"`
 	Owner  string `json:"owner"`
@@ -21,14 +22,14 @@ type Engine struct {
 //
 // Deprecated: Please use their replacement, Models, instead.
 // https://beta.openai.com/docs/api-reference/models
-func (c *Client) ListEngines(ctx context.Context) (*List[*Engine], error) {
-	var b, err = c.get(ctx, routes.Engines)
+func (c *Client) ListEngines(ctx context.Context, opts ...RequestOption) (*List[*Engine], error) {
+	var res, err = c.get(ctx, routes.Engines, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var el = &List[*Engine]{}
-	if err = json.Unmarshal(b, el); err != nil {
+	if err = res.decode(el); err != nil {
 		return nil, err
 	}
 
@@ -39,14 +40,14 @@ func (c *Client) ListEngines(ctx context.Context) (*List[*Engine], error) {
 //
 // Deprecated: Please use their replacement, Models, instead.
 // https://beta.openai.com/docs/api-reference/models
-func (c *Client) GetEngine(ctx context.Context, id string) (*Engine, error) {
-	var b, err = c.get(ctx, path.Join(routes.Engines, id))
+func (c *Client) GetEngine(ctx context.Context, id string, opts ...RequestOption) (*Engine, error) {
+	var res, err = c.get(ctx, path.Join(routes.Engines, id), opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var e = &Engine{}
-	if err = json.Unmarshal(b, e); err != nil {
+	if err = res.decode(e); err != nil {
 		return nil, err
 	}
 
@@ -1,32 +1,171 @@
 package openai
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+var (
+	// ErrInvalidRequest matches (via errors.Is) an *APIError with type "invalid_request_error", which indicates that
+	// the request was malformed or missing required parameters.
+	ErrInvalidRequest = errors.New("openai: invalid request")
+	// ErrAuthentication matches (via errors.Is) an *APIError with a 401 status code, which indicates that the API key
+	// is invalid, expired, or revoked.
+	ErrAuthentication = errors.New("openai: authentication error")
+	// ErrPermission matches (via errors.Is) an *APIError with a 403 status code, which indicates that the API key
+	// does not have permission to access the requested resource.
+	ErrPermission = errors.New("openai: permission denied")
+	// ErrNotFound matches (via errors.Is) an *APIError with a 404 status code.
+	ErrNotFound = errors.New("openai: not found")
+	// ErrRateLimit matches (via errors.Is) an *APIError with a 429 status code, which indicates that a rate limit or
+	// quota was exceeded.
+	ErrRateLimit = errors.New("openai: rate limit exceeded")
+	// ErrServer matches (via errors.Is) an *APIError with a 5xx status code, which indicates an issue on OpenAI's
+	// servers.
+	ErrServer = errors.New("openai: server error")
+)
+
 // errorResponse wraps the returned error.
 type errorResponse struct {
-	Error *Error `json:"error,omitempty"`
+	Error *APIError `json:"error,omitempty"`
 }
 
-// Error represents an error response from the API.
-type Error struct {
-	Code    int     `json:"code"`
-	Message string  `json:"message"`
-	Param   *string `json:"param,omitempty"`
-	Type    string  `json:"type"`
+// APIError represents an error response from the API. Use errors.As to access its fields, or errors.Is with one of the
+// Err* sentinel values to branch on broad classes of errors.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response. It is 0 for errors sent within a stream.
+	StatusCode int `json:"-"`
+	// Code is a machine-readable code for the error (e.g. "invalid_api_key"), if provided.
+	Code string `json:"code,omitempty"`
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+	// Param is the request parameter which caused the error, if any.
+	Param *string `json:"param,omitempty"`
+	// Type is the type of the error (e.g. "invalid_request_error" or "server_error").
+	Type string `json:"type"`
+	// RequestID is the unique ID assigned to the request by OpenAI. Include it when contacting support.
+	RequestID string `json:"-"`
+}
+
+// Error is an alias of APIError.
+//
+// Deprecated: Use APIError instead.
+type Error = APIError
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The API is inconsistent about whether "code" is a string or
+// a number, so both are accepted. Some OpenAI-compatible servers (e.g. Ollama) send the error as a bare string, which
+// is used as the message.
+func (e *APIError) UnmarshalJSON(b []byte) error {
+	var msg string
+	if err := json.Unmarshal(b, &msg); err == nil {
+		e.Message = msg
+		return nil
+	}
+
+	type apiError APIError
+	var v = &struct {
+		*apiError
+		Code json.RawMessage `json:"code"`
+	}{apiError: (*apiError)(e)}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+
+	var s string
+	if err := json.Unmarshal(v.Code, &s); err == nil {
+		e.Code = s
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(v.Code, &n); err == nil {
+		e.Code = n.String()
+	}
+
+	return nil
 }
 
 // Error implements the error interface.
-func (e *Error) Error() string {
-	return fmt.Sprintf("Code: %v, Message: %s, Type: %s, Param: %v", e.Code, e.Message, e.Type, e.Param)
+func (e *APIError) Error() string {
+	var s = "openai: "
+	if e.StatusCode != 0 {
+		s += fmt.Sprintf("status code: %d, ", e.StatusCode)
+	}
+
+	if e.Type != "" {
+		s += fmt.Sprintf("type: %s, ", e.Type)
+	}
+
+	if e.Code != "" {
+		s += fmt.Sprintf("code: %s, ", e.Code)
+	}
+
+	if e.Param != nil {
+		s += fmt.Sprintf("param: %s, ", *e.Param)
+	}
+
+	s += "message: " + e.Message
+
+	if e.RequestID != "" {
+		s += ", request id: " + e.RequestID
+	}
+
+	return s
 }
 
-// Retryable returns true if the error is retryable.
-func (e *Error) Retryable() bool {
-	if e.Code >= http.StatusInternalServerError {
+// Is reports whether |target| is one of the Err* sentinel values which describes |e|.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.Type == "invalid_request_error"
+	case ErrAuthentication:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPermission:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimit:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// Retryable returns true if the error is transient: a 429 (unless the quota of the account is exhausted), or a 500,
+// 502, or 503. Other errors, such as a 504 whose request may already have been processed, are not retryable.
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return e.Code != "insufficient_quota"
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
 		return true
+	default:
+		return false
+	}
+}
+
+// newAPIError returns an *APIError for a response with status code |status| and body |b|. If |b| is not a JSON error
+// object, it is used as the message and Type is left empty.
+func newAPIError(status int, b []byte) *APIError {
+	var er = &errorResponse{}
+	if err := json.Unmarshal(b, er); err != nil || er.Error == nil {
+		var msg = string(b)
+		if msg == "" {
+			msg = http.StatusText(status)
+		}
+
+		return &APIError{
+			StatusCode: status,
+			Message:    msg,
+		}
 	}
-	return e.Code == http.StatusTooManyRequests
+
+	er.Error.StatusCode = status
+
+	return er.Error
 }
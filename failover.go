@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+// defaultFailoverCooldown is how long a base URL is considered unhealthy after a failure, by default.
+const defaultFailoverCooldown = 30 * time.Second
+
+// FailoverConfig configures the failover between base URLs (see WithBaseURLs).
+type FailoverConfig struct {
+	// Cooldown is how long a base URL is skipped for after a request to it fails with a 5xx response, a timeout, or a
+	// connection error. Once it has elapsed, the base URL is tried again, in its original order.
+	// Defaults to 30s.
+	Cooldown time.Duration
+}
+
+// endpoint is a base URL within a failover.
+type endpoint struct {
+	url *url.URL
+	// unhealthyUntil is the time until which the endpoint should be skipped.
+	unhealthyUntil time.Time
+}
+
+// failover sends requests to the first healthy of an ordered list of base URLs.
+type failover struct {
+	cooldown time.Duration
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
+
+// newFailover returns a *failover between |urls|, configured by |cfg|.
+func newFailover(urls []*url.URL, cfg *FailoverConfig) *failover {
+	var f = &failover{cooldown: defaultFailoverCooldown}
+	if cfg != nil && cfg.Cooldown > 0 {
+		f.cooldown = cfg.Cooldown
+	}
+
+	for _, u := range urls {
+		f.endpoints = append(f.endpoints, &endpoint{url: u})
+	}
+
+	return f
+}
+
+// order returns the endpoints in the order in which they should be tried: healthy endpoints in their configured
+// order, followed by unhealthy ones, those which recover first first.
+func (f *failover) order() []*endpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var now = time.Now()
+	var healthy, unhealthy []*endpoint
+	for _, e := range f.endpoints {
+		if e.unhealthyUntil.After(now) {
+			unhealthy = append(unhealthy, e)
+			continue
+		}
+		healthy = append(healthy, e)
+	}
+
+	// Insertion sort, as there are few endpoints.
+	for i := 1; i < len(unhealthy); i++ {
+		for j := i; j > 0 && unhealthy[j].unhealthyUntil.Before(unhealthy[j-1].unhealthyUntil); j-- {
+			unhealthy[j], unhealthy[j-1] = unhealthy[j-1], unhealthy[j]
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// record records the outcome |err| of a request to |e|.
+func (f *failover) record(e *endpoint, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if failoverError(err) {
+		e.unhealthyUntil = time.Now().Add(f.cooldown)
+	} else if err == nil {
+		e.unhealthyUntil = time.Time{}
+	}
+}
+
+// failoverError returns true if |err| indicates that the base URL it was sent to is degraded, so that the request
+// should be sent to the next one.
+func failoverError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	return circuitFailure(err)
+}
+
+// send sends |req| for |route| with |send| to each endpoint in turn, until one does not fail with a failover error.
+func (f *failover) send(req *http.Request, route string, send func(req *http.Request) (*http.Response, error)) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for i, e := range f.order() {
+		if i > 0 && (req.Context().Err() != nil || !rewind(req)) {
+			break
+		}
+
+		var u = *e.url
+		u.Path = path.Join(u.Path, route)
+		u.RawQuery = req.URL.RawQuery
+		req.URL, req.Host = &u, u.Host
+
+		resp, err = send(req)
+		f.record(e, err)
+		if !failoverError(err) {
+			break
+		}
+	}
+
+	return resp, err
+}
@@ -2,26 +2,33 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
+	"io"
+	"mime/multipart"
 	"os"
 	"path"
+	"path/filepath"
 
+	"github.com/fabiustech/openai/files"
 	"github.com/fabiustech/openai/objects"
 	"github.com/fabiustech/openai/routes"
 )
 
 // FileRequest contains all relevant data for upload requests to the files endpoint.
 type FileRequest struct {
-	// File is the JSON Lines file to be uploaded. If the purpose is set to "fine-tune", each line is a JSON record
-	// with "prompt" and "completion" fields representing your training examples:
+	// File is the contents of the file to be uploaded. If the purpose is set to files.PurposeFineTune, each line is a
+	// JSON record with "prompt" and "completion" fields representing your training examples:
 	// https://beta.openai.com/docs/guides/fine-tuning/prepare-training-data.
-	File *os.File
-	// Purpose is the intended purpose of the uploaded documents. Use "fine-tune" for Fine-tuning.
+	File io.Reader
+	// Filename is the name of the file to be uploaded. If empty and File has a Name method (e.g. *os.File), the base
+	// of its name is used.
+	Filename string
+	// Purpose is the intended purpose of the uploaded documents. Use files.PurposeFineTune for Fine-tuning.
 	// This allows OpenAI to validate the format of the uploaded file.
-	Purpose string
+	Purpose files.Purpose
 }
 
-// NewFineTuneFileRequest returns a |*FileRequest| with File opened from |path| and Purpose set to "fine-tuned".
+// NewFineTuneFileRequest returns a |*FileRequest| with File opened from |path| and Purpose set to
+// files.PurposeFineTune.
 func NewFineTuneFileRequest(path string) (*FileRequest, error) {
 	var f, err = os.Open(path)
 	if err != nil {
@@ -29,30 +36,55 @@ func NewFineTuneFileRequest(path string) (*FileRequest, error) {
 	}
 
 	return &FileRequest{
-		File:    f,
-		Purpose: "fine-tune",
+		File:     f,
+		Filename: filepath.Base(path),
+		Purpose:  files.PurposeFineTune,
 	}, nil
 }
 
+// filename returns the name of the file to be uploaded.
+func (fr *FileRequest) filename() string {
+	if fr.Filename != "" {
+		return fr.Filename
+	}
+
+	if n, ok := fr.File.(interface{ Name() string }); ok {
+		return filepath.Base(n.Name())
+	}
+
+	return "file"
+}
+
+// writeForm writes the fields of |fr| to |w|.
+func (fr *FileRequest) writeForm(w *multipart.Writer) error {
+	if err := w.WriteField("purpose", fr.Purpose.String()); err != nil {
+		return err
+	}
+
+	return writeFormFile(w, "file", fr.filename(), fr.File)
+}
+
 // File represents an OpenAPI file.
 type File struct {
+	ResponseMeta
+
 	ID        string         `json:"id"`
 	Object    objects.Object `json:"object"`
 	Bytes     int            `json:"bytes"`
 	CreatedAt int            `json:"created_at"`
 	Filename  string         `json:"filename"`
-	Purpose   string         `json:"purpose"`
+	Purpose   files.Purpose  `json:"purpose"`
 }
 
-// ListFiles returns a list of files that belong to the user's organization.
-func (c *Client) ListFiles(ctx context.Context) (*List[*File], error) {
-	var b, err = c.get(ctx, routes.Files)
+// ListFiles returns a list of files that belong to the user's organization. Paginate the results with WithListOptions.
+func (c *Client) ListFiles(ctx context.Context, opts ...RequestOption) (*List[*File], error) {
+	var res, err = c.get(ctx, routes.Files, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var fl = &List[*File]{}
-	if err = json.Unmarshal(b, fl); err != nil {
+	if err = res.decode(fl); err != nil {
 		return nil, err
 	}
 
@@ -60,15 +92,16 @@ func (c *Client) ListFiles(ctx context.Context) (*List[*File], error) {
 }
 
 // UploadFile uploads a file that contains document(s) to be used across various endpoints/features. Currently, the size
-// of all the files uploaded by one organization can be up to 1 GB.
-func (c *Client) UploadFile(ctx context.Context, fr *FileRequest) (*File, error) {
-	var b, err = c.postFile(ctx, fr)
+// of all the files uploaded by one organization can be up to 1 GB. The file is read into memory before it is sent;
+// upload large files in parts with CreateUpload and UploadParts instead.
+func (c *Client) UploadFile(ctx context.Context, fr *FileRequest, opts ...RequestOption) (*File, error) {
+	var res, err = c.postForm(ctx, routes.Files, fr.writeForm, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &File{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
@@ -76,23 +109,38 @@ func (c *Client) UploadFile(ctx context.Context, fr *FileRequest) (*File, error)
 }
 
 // DeleteFile deletes a file.
-func (c *Client) DeleteFile(ctx context.Context, id string) error {
-	var _, err = c.delete(ctx, path.Join(routes.Files, id))
+func (c *Client) DeleteFile(ctx context.Context, id string, opts ...RequestOption) error {
+	var _, err = c.delete(ctx, path.Join(routes.Files, id), opts...)
 
 	return err
 }
 
 // RetrieveFile returns information about a specific file.
-func (c *Client) RetrieveFile(ctx context.Context, id string) (*File, error) {
-	var b, err = c.get(ctx, path.Join(routes.Files, id))
+func (c *Client) RetrieveFile(ctx context.Context, id string, opts ...RequestOption) (*File, error) {
+	var res, err = c.get(ctx, path.Join(routes.Files, id), opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &File{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
 	return f, nil
 }
+
+// GetFileContent writes the contents of the specified file to |w|. The contents are streamed, so large files (such as
+// fine-tuning results or batch output files) are never fully loaded into memory. If copying fails part way, |w| may
+// contain a prefix of the contents.
+func (c *Client) GetFileContent(ctx context.Context, id string, w io.Writer, opts ...RequestOption) error {
+	var rc, err = c.getStream(ctx, path.Join(routes.Files, id, "content"), opts...)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(w, rc)
+
+	return err
+}
@@ -0,0 +1,75 @@
+// Package files contains the enum values which represent the various
+// purposes of files uploaded to the OpenAI files endpoint.
+package files
+
+// Purpose represents the enum values for the intended purpose of an uploaded file.
+type Purpose int
+
+const (
+	// PurposeInvalid represents an invalid Purpose option.
+	PurposeInvalid Purpose = iota
+	// PurposeFineTune specifies that the file contains training or validation data for fine-tuning.
+	PurposeFineTune
+	// PurposeFineTuneResults specifies that the file contains the results of a fine-tuning job. Files with this
+	// purpose are created by OpenAI and cannot be uploaded.
+	PurposeFineTuneResults
+	// PurposeAssistants specifies that the file is to be used with the Assistants and Vector Stores APIs.
+	PurposeAssistants
+	// PurposeAssistantsOutput specifies that the file was generated by an assistant. Files with this purpose are
+	// created by OpenAI and cannot be uploaded.
+	PurposeAssistantsOutput
+	// PurposeBatch specifies that the file contains the input of a batch job.
+	PurposeBatch
+	// PurposeBatchOutput specifies that the file contains the output of a batch job. Files with this purpose are
+	// created by OpenAI and cannot be uploaded.
+	PurposeBatchOutput
+	// PurposeVision specifies that the file is an image to be used for vision fine-tuning.
+	PurposeVision
+	// PurposeUserData specifies that the file is a flexible file type for any purpose.
+	PurposeUserData
+)
+
+// String implements the fmt.Stringer interface.
+func (p Purpose) String() string {
+	return purposeToString[p]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p Purpose) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |p| to PurposeInvalid.
+func (p *Purpose) UnmarshalText(b []byte) error {
+	if val, ok := stringToPurpose[(string(b))]; ok {
+		*p = val
+		return nil
+	}
+
+	*p = PurposeInvalid
+
+	return nil
+}
+
+var purposeToString = map[Purpose]string{
+	PurposeFineTune:         "fine-tune",
+	PurposeFineTuneResults:  "fine-tune-results",
+	PurposeAssistants:       "assistants",
+	PurposeAssistantsOutput: "assistants_output",
+	PurposeBatch:            "batch",
+	PurposeBatchOutput:      "batch_output",
+	PurposeVision:           "vision",
+	PurposeUserData:         "user_data",
+}
+
+var stringToPurpose = map[string]Purpose{
+	"fine-tune":         PurposeFineTune,
+	"fine-tune-results": PurposeFineTuneResults,
+	"assistants":        PurposeAssistants,
+	"assistants_output": PurposeAssistantsOutput,
+	"batch":             PurposeBatch,
+	"batch_output":      PurposeBatchOutput,
+	"vision":            PurposeVision,
+	"user_data":         PurposeUserData,
+}
@@ -2,9 +2,9 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
 	"path"
 
+	"github.com/fabiustech/openai/files"
 	"github.com/fabiustech/openai/models"
 	"github.com/fabiustech/openai/objects"
 	"github.com/fabiustech/openai/routes"
@@ -91,6 +91,15 @@ type FineTuneRequest struct {
 	Suffix string `json:"suffix,omitempty"`
 }
 
+// model returns the model of the request (see WithModelRoute).
+func (ftr *FineTuneRequest) model() string {
+	if ftr.Model == nil {
+		return ""
+	}
+
+	return ftr.Model.String()
+}
+
 // Event represents an event related to a fine-tune request.
 type Event struct {
 	Object    objects.Object `json:"object"`
@@ -101,6 +110,8 @@ type Event struct {
 
 // FineTuneResponse is the response from fine-tunes endpoints.
 type FineTuneResponse struct {
+	ResponseMeta
+
 	ID             string                 `json:"id"`
 	Object         objects.Object         `json:"object"`
 	Model          models.FineTune        `json:"model"`
@@ -123,13 +134,15 @@ type FineTuneResponse struct {
 		Bytes     int            `json:"bytes"`
 		CreatedAt uint64         `json:"created_at"`
 		Filename  string         `json:"filename"`
-		Purpose   string         `json:"purpose"`
+		Purpose   files.Purpose  `json:"purpose"`
 	} `json:"training_files"`
 	UpdatedAt uint64 `json:"updated_at"`
 }
 
 // FineTuneDeletionResponse is the response from the fine-tunes/delete endpoint.
 type FineTuneDeletionResponse struct {
+	ResponseMeta
+
 	ID      string         `json:"id"`
 	Object  objects.Object `json:"object"`
 	Deleted bool           `json:"deleted"`
@@ -137,14 +150,14 @@ type FineTuneDeletionResponse struct {
 
 // CreateFineTune creates a job that fine-tunes a specified model from a given dataset. *FineTuneResponse includes
 // details of the enqueued job including job status and the name of the fine-tuned models once complete.
-func (c *Client) CreateFineTune(ctx context.Context, ftr *FineTuneRequest) (*FineTuneResponse, error) {
-	var b, err = c.post(ctx, routes.FineTunes, ftr)
+func (c *Client) CreateFineTune(ctx context.Context, ftr *FineTuneRequest, opts ...RequestOption) (*FineTuneResponse, error) {
+	var res, err = c.post(ctx, routes.FineTunes, ftr, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &FineTuneResponse{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
@@ -152,14 +165,14 @@ func (c *Client) CreateFineTune(ctx context.Context, ftr *FineTuneRequest) (*Fin
 }
 
 // ListFineTunes lists your organization's fine-tuning jobs.
-func (c *Client) ListFineTunes(ctx context.Context) (*List[*FineTuneResponse], error) {
-	var b, err = c.get(ctx, routes.FineTunes)
+func (c *Client) ListFineTunes(ctx context.Context, opts ...RequestOption) (*List[*FineTuneResponse], error) {
+	var res, err = c.get(ctx, routes.FineTunes, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var l = &List[*FineTuneResponse]{}
-	if err = json.Unmarshal(b, l); err != nil {
+	if err = res.decode(l); err != nil {
 		return nil, err
 	}
 
@@ -167,14 +180,14 @@ func (c *Client) ListFineTunes(ctx context.Context) (*List[*FineTuneResponse], e
 }
 
 // RetrieveFineTune gets info about the fine-tune job.
-func (c *Client) RetrieveFineTune(ctx context.Context, id string) (*FineTuneResponse, error) {
-	var b, err = c.get(ctx, path.Join(routes.FineTunes, id))
+func (c *Client) RetrieveFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneResponse, error) {
+	var res, err = c.get(ctx, path.Join(routes.FineTunes, id), opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &FineTuneResponse{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
@@ -182,14 +195,14 @@ func (c *Client) RetrieveFineTune(ctx context.Context, id string) (*FineTuneResp
 }
 
 // CancelFineTune immediately cancels a fine-tune job.
-func (c *Client) CancelFineTune(ctx context.Context, id string) (*FineTuneResponse, error) {
-	var b, err = c.post(ctx, path.Join(routes.FineTunes, id, "cancel"), nil)
+func (c *Client) CancelFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneResponse, error) {
+	var res, err = c.post(ctx, path.Join(routes.FineTunes, id, "cancel"), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &FineTuneResponse{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
@@ -198,14 +211,14 @@ func (c *Client) CancelFineTune(ctx context.Context, id string) (*FineTuneRespon
 
 // ListFineTuneEvents returns fine-grained status updates for a fine-tune job.
 // TODO: Support streaming (in a different method).
-func (c *Client) ListFineTuneEvents(ctx context.Context, id string) (*List[*Event], error) {
-	var b, err = c.get(ctx, path.Join(routes.FineTunes, id, "events"))
+func (c *Client) ListFineTuneEvents(ctx context.Context, id string, opts ...RequestOption) (*List[*Event], error) {
+	var res, err = c.get(ctx, path.Join(routes.FineTunes, id, "events"), opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var l = &List[*Event]{}
-	if err = json.Unmarshal(b, l); err != nil {
+	if err = res.decode(l); err != nil {
 		return nil, err
 	}
 
@@ -213,14 +226,14 @@ func (c *Client) ListFineTuneEvents(ctx context.Context, id string) (*List[*Even
 }
 
 // DeleteFineTune delete a fine-tuned model. You must have the Owner role in your organization.
-func (c *Client) DeleteFineTune(ctx context.Context, id string) (*FineTuneDeletionResponse, error) {
-	var b, err = c.delete(ctx, path.Join(routes.FineTunes, id))
+func (c *Client) DeleteFineTune(ctx context.Context, id string, opts ...RequestOption) (*FineTuneDeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.FineTunes, id), opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var f = &FineTuneDeletionResponse{}
-	if err = json.Unmarshal(b, f); err != nil {
+	if err = res.decode(f); err != nil {
 		return nil, err
 	}
 
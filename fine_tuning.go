@@ -0,0 +1,365 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/fabiustech/openai/jsonl"
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/roles"
+	"github.com/fabiustech/openai/routes"
+)
+
+// FineTuningJobRequest contains all relevant fields for requests to the fine_tuning/jobs endpoint.
+type FineTuningJobRequest struct {
+	// Model specifies the name of the model to fine-tune.
+	Model models.FineTune `json:"model"`
+	// TrainingFile specifies the ID of an uploaded file that contains training data. Your dataset must be formatted
+	// as a JSONL file, and must be uploaded with the purpose files.PurposeFineTune. See the fine-tuning guide for more
+	// details:
+	//
+	// https://platform.openai.com/docs/guides/fine-tuning
+	TrainingFile string `json:"training_file"`
+	// Hyperparameters specifies the hyperparameters used for the fine-tuning job.
+	// Defaults to null (all hyperparameters chosen automatically).
+	Hyperparameters *Hyperparameters `json:"hyperparameters,omitempty"`
+	// Suffix specifies a string of up to 64 characters that will be added to your fine-tuned model name. For example,
+	// a suffix of "custom-model-name" would produce a model name like
+	// ft:gpt-4o-mini:openai:custom-model-name:7p4lURel.
+	Suffix string `json:"suffix,omitempty"`
+	// ValidationFile specifies the ID of an uploaded file that contains validation data. If you provide this file,
+	// the data is used to generate validation metrics periodically during fine-tuning. Your train and validation data
+	// should be mutually exclusive.
+	// Defaults to null.
+	ValidationFile *string `json:"validation_file,omitempty"`
+	// Seed controls the reproducibility of the job. Passing in the same seed and job parameters should produce the
+	// same results, but may differ in rare cases.
+	// Defaults to null (a seed is generated for you).
+	Seed *int `json:"seed,omitempty"`
+	// Integrations specifies the integrations to enable for the job (e.g. WandB).
+	// Defaults to null.
+	Integrations []*FineTuningIntegration `json:"integrations,omitempty"`
+}
+
+// model returns the model of the request (see WithModelRoute).
+func (fr *FineTuningJobRequest) model() string {
+	return fr.Model.String()
+}
+
+// FineTuningIntegration is an integration enabled for a fine-tuning job. Use WandB to construct one.
+type FineTuningIntegration struct {
+	// Type is the type of the integration. Only "wandb" is supported.
+	Type string `json:"type"`
+	// WandB configures the Weights and Biases integration.
+	WandB *WandBConfig `json:"wandb,omitempty"`
+}
+
+// WandBConfig configures the Weights and Biases integration, which logs the metrics of a fine-tuning job to a run.
+type WandBConfig struct {
+	// Project is the name of the project the run is created in.
+	Project string `json:"project"`
+	// Name is the display name of the run.
+	// Defaults to the ID of the job.
+	Name *string `json:"name,omitempty"`
+	// Entity is the team or username the run is created for.
+	// Defaults to the default entity of the API key registered with OpenAI.
+	Entity *string `json:"entity,omitempty"`
+	// Tags are added to the run, in addition to the tags OpenAI adds (e.g. "openai/finetune" and the ID of the job).
+	Tags []string `json:"tags,omitempty"`
+}
+
+// WandB returns a *FineTuningIntegration which logs the metrics of a fine-tuning job to Weights and Biases, as
+// configured by |cfg|. The Weights and Biases API key must first be registered in the settings of the organization.
+func WandB(cfg *WandBConfig) *FineTuningIntegration {
+	return &FineTuningIntegration{Type: "wandb", WandB: cfg}
+}
+
+// validate returns an error if |fr| has an invalid integration.
+func (fr *FineTuningJobRequest) validate() error {
+	for _, in := range fr.Integrations {
+		if in.Type == "wandb" && (in.WandB == nil || in.WandB.Project == "") {
+			return errors.New("openai: wandb integration requires a project")
+		}
+	}
+
+	return nil
+}
+
+// Hyperparameters represents the hyperparameters used for a fine-tuning job. Each is either a number or "auto", and
+// those of a FineTuningJob are "auto" unless they were set when the job was created.
+type Hyperparameters struct {
+	// NEpochs specifies the number of epochs to train the model for. An epoch refers to one full cycle through the
+	// training dataset.
+	// Defaults to null (chosen automatically).
+	NEpochs *Hyperparameter[int] `json:"n_epochs,omitempty"`
+	// BatchSize specifies the number of examples in each batch. A larger batch size means that model parameters are
+	// updated less frequently, but with lower variance.
+	// Defaults to null (chosen automatically).
+	BatchSize *Hyperparameter[int] `json:"batch_size,omitempty"`
+	// LearningRateMultiplier specifies the scaling factor for the learning rate. A smaller learning rate may be
+	// useful to avoid overfitting.
+	// Defaults to null (chosen automatically).
+	LearningRateMultiplier *Hyperparameter[float64] `json:"learning_rate_multiplier,omitempty"`
+}
+
+// Hyperparameter is a fine-tuning hyperparameter, which is encoded as either a number, or the string "auto" to have
+// the API choose it based on the dataset. Use HyperparameterValue or HyperparameterAuto to construct one.
+type Hyperparameter[T int | float64] struct {
+	value T
+	auto  bool
+}
+
+// HyperparameterValue returns a *Hyperparameter set to |v|.
+func HyperparameterValue[T int | float64](v T) *Hyperparameter[T] {
+	return &Hyperparameter[T]{value: v}
+}
+
+// HyperparameterAuto returns a *Hyperparameter which is chosen automatically.
+func HyperparameterAuto[T int | float64]() *Hyperparameter[T] {
+	return &Hyperparameter[T]{auto: true}
+}
+
+// Auto returns true if |h| is chosen automatically.
+func (h *Hyperparameter[T]) Auto() bool {
+	return h != nil && h.auto
+}
+
+// Value returns the value of |h|, or 0 if it is nil or chosen automatically.
+func (h *Hyperparameter[T]) Value() T {
+	if h == nil {
+		return 0
+	}
+
+	return h.value
+}
+
+// String implements the fmt.Stringer interface.
+func (h *Hyperparameter[T]) String() string {
+	if h.auto {
+		return "auto"
+	}
+
+	return fmt.Sprint(h.value)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (h *Hyperparameter[T]) MarshalJSON() ([]byte, error) {
+	if h.auto {
+		return []byte(`"auto"`), nil
+	}
+
+	return json.Marshal(h.value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *Hyperparameter[T]) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s != "auto" {
+			return fmt.Errorf("openai: invalid hyperparameter: %s", b)
+		}
+		*h = Hyperparameter[T]{auto: true}
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("openai: invalid hyperparameter: %s", b)
+	}
+	*h = Hyperparameter[T]{value: v}
+
+	return nil
+}
+
+// FineTuningExample is a line of the training or validation file of a chat fine-tuning job. Use it with the jsonl
+// package to write training files.
+type FineTuningExample struct {
+	// Messages is the conversation to train on. The model learns to generate its assistant messages.
+	Messages []*ChatMessage `json:"messages"`
+	// Tools describes the tools which are available in the conversation.
+	Tools []*Tool `json:"tools,omitempty"`
+	// ParallelToolCalls specifies whether the conversation may contain parallel tool calls.
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+}
+
+var _ jsonl.Validator = (*FineTuningExample)(nil)
+
+// Validate returns an error if |ex| has no messages, a message without a valid role, or no assistant message to train
+// on.
+func (ex *FineTuningExample) Validate() error {
+	if len(ex.Messages) == 0 {
+		return errors.New("openai: example has no messages")
+	}
+
+	var assistant bool
+	for i, m := range ex.Messages {
+		switch m.Role {
+		case roles.Invalid:
+			return fmt.Errorf("openai: message %d has no valid role", i)
+		case roles.Assistant:
+			if m.Text() == "" && len(m.ToolCalls) == 0 {
+				return fmt.Errorf("openai: assistant message %d has no content or tool calls", i)
+			}
+			assistant = true
+		case roles.Tool:
+			if m.ToolCallID == "" {
+				return fmt.Errorf("openai: tool message %d has no tool call ID", i)
+			}
+		}
+	}
+
+	if !assistant {
+		return errors.New("openai: example has no assistant message")
+	}
+
+	return nil
+}
+
+// FineTuningJobError contains details about why a fine-tuning job failed.
+type FineTuningJobError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param"`
+}
+
+// FineTuningJob represents a fine-tuning job that has been created through the API.
+type FineTuningJob struct {
+	ResponseMeta
+
+	ID              string                 `json:"id"`
+	Object          objects.Object         `json:"object"`
+	CreatedAt       uint64                 `json:"created_at"`
+	Error           *FineTuningJobError    `json:"error"`
+	FineTunedModel  *models.FineTunedModel `json:"fine_tuned_model"`
+	FinishedAt      *uint64                `json:"finished_at"`
+	Hyperparameters *Hyperparameters       `json:"hyperparameters"`
+	Model           string                 `json:"model"`
+	OrganizationID  string                 `json:"organization_id"`
+	ResultFiles     []string               `json:"result_files"`
+	// Status is one of "validating_files", "queued", "running", "succeeded", "failed", or "cancelled".
+	Status          string  `json:"status"`
+	TrainedTokens   *int    `json:"trained_tokens"`
+	TrainingFile    string  `json:"training_file"`
+	ValidationFile  *string `json:"validation_file"`
+	Seed            int     `json:"seed"`
+	EstimatedFinish *uint64 `json:"estimated_finish"`
+	// Integrations are the integrations enabled for the job.
+	Integrations []*FineTuningIntegration `json:"integrations"`
+}
+
+// FineTuningJobEvent represents a status update for a fine-tuning job.
+type FineTuningJobEvent struct {
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	CreatedAt uint64         `json:"created_at"`
+	// Level is one of "info", "warn", or "error".
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	// Type is one of "message" or "metrics".
+	Type string `json:"type"`
+	// Data contains any additional data associated with the event (e.g. training metrics).
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// CreateFineTuningJob creates a fine-tuning job which begins the process of creating a new model from a given
+// dataset. *FineTuningJob includes details of the enqueued job including job status and the name of the fine-tuned
+// model once complete.
+func (c *Client) CreateFineTuningJob(ctx context.Context, fr *FineTuningJobRequest, opts ...RequestOption) (*FineTuningJob, error) {
+	if err := fr.validate(); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.FineTuningJobs, fr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var j = &FineTuningJob{}
+	if err = res.decode(j); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// ListFineTuningJobs lists your organization's fine-tuning jobs. Paginate the results with WithListOptions.
+func (c *Client) ListFineTuningJobs(ctx context.Context, opts ...RequestOption) (*List[*FineTuningJob], error) {
+	var res, err = c.get(ctx, routes.FineTuningJobs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*FineTuningJob]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveFineTuningJob gets info about a fine-tuning job.
+func (c *Client) RetrieveFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error) {
+	var res, err = c.get(ctx, path.Join(routes.FineTuningJobs, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var j = &FineTuningJob{}
+	if err = res.decode(j); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// CancelFineTuningJob immediately cancels a fine-tuning job.
+func (c *Client) CancelFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error) {
+	var res, err = c.post(ctx, path.Join(routes.FineTuningJobs, id, "cancel"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var j = &FineTuningJob{}
+	if err = res.decode(j); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// ListFineTuningEvents returns status updates for a fine-tuning job. Paginate the results with WithListOptions.
+func (c *Client) ListFineTuningEvents(ctx context.Context, id string, opts ...RequestOption) (*List[*FineTuningJobEvent], error) {
+	var res, err = c.get(ctx, path.Join(routes.FineTuningJobs, id, "events"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*FineTuningJobEvent]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// StreamFineTuningEvents tails the status updates for a fine-tuning job as they occur. Events are sent as server-sent
+// events until the job finishes, at which point Recv returns io.EOF. It is the caller's responsibility to close the
+// returned *Stream.
+func (c *Client) StreamFineTuningEvents(ctx context.Context, id string, opts ...RequestOption) (*Stream[*FineTuningJobEvent], error) {
+	var route = withQuery(path.Join(routes.FineTuningJobs, id, "events"), url.Values{"stream": {"true"}})
+
+	var rc, err = c.getStream(ctx, route, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var s = newStream[*FineTuningJobEvent](rc)
+	s.codec = c.codec
+
+	return s, nil
+}
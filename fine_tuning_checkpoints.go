@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+// FineTuningCheckpointMetrics contains the training metrics of a fine-tuning job at a checkpoint.
+type FineTuningCheckpointMetrics struct {
+	Step                       float64 `json:"step"`
+	TrainLoss                  float64 `json:"train_loss"`
+	TrainMeanTokenAccuracy     float64 `json:"train_mean_token_accuracy"`
+	ValidLoss                  float64 `json:"valid_loss"`
+	ValidMeanTokenAccuracy     float64 `json:"valid_mean_token_accuracy"`
+	FullValidLoss              float64 `json:"full_valid_loss"`
+	FullValidMeanTokenAccuracy float64 `json:"full_valid_mean_token_accuracy"`
+}
+
+// FineTuningJobCheckpoint is a model checkpoint created at the end of a training epoch of a fine-tuning job. Its
+// FineTunedModelCheckpoint can be used like any other fine-tuned model.
+type FineTuningJobCheckpoint struct {
+	ID                       string                       `json:"id"`
+	Object                   objects.Object               `json:"object"`
+	CreatedAt                uint64                       `json:"created_at"`
+	FineTunedModelCheckpoint models.FineTunedModel        `json:"fine_tuned_model_checkpoint"`
+	FineTuningJobID          string                       `json:"fine_tuning_job_id"`
+	StepNumber               int                          `json:"step_number"`
+	Metrics                  *FineTuningCheckpointMetrics `json:"metrics"`
+}
+
+// CheckpointPermission grants a project access to a fine-tuned model checkpoint.
+type CheckpointPermission struct {
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	CreatedAt uint64         `json:"created_at"`
+	ProjectID string         `json:"project_id"`
+}
+
+// CheckpointPermissionRequest is the request body for CreateCheckpointPermissions.
+type CheckpointPermissionRequest struct {
+	// ProjectIDs are the projects to grant access to the checkpoint.
+	ProjectIDs []string `json:"project_ids"`
+}
+
+// ListFineTuningCheckpoints lists the checkpoints of a fine-tuning job. Paginate the results with WithListOptions.
+func (c *Client) ListFineTuningCheckpoints(ctx context.Context, id string, opts ...RequestOption) (*List[*FineTuningJobCheckpoint], error) {
+	var res, err = c.get(ctx, path.Join(routes.FineTuningJobs, id, "checkpoints"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*FineTuningJobCheckpoint]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// CreateCheckpointPermissions grants the projects of |pr| access to |checkpoint|, a fine-tuned model checkpoint (see
+// FineTuningJobCheckpoint.FineTunedModelCheckpoint), so that it can be used across projects. It requires an admin API
+// key (see WithAdminKey).
+func (c *Client) CreateCheckpointPermissions(ctx context.Context, checkpoint string, pr *CheckpointPermissionRequest, opts ...RequestOption) (*List[*CheckpointPermission], error) {
+	if len(pr.ProjectIDs) == 0 {
+		return nil, errors.New("openai: at least one project ID is required")
+	}
+
+	var res, err = c.post(ctx, path.Join(routes.FineTuningCheckpoints, checkpoint, "permissions"), pr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*CheckpointPermission]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// ListCheckpointPermissions lists the permissions of |checkpoint|, a fine-tuned model checkpoint. If |projectID| is not
+// empty, only the permission of that project is returned. It requires an admin API key (see WithAdminKey). Paginate the
+// results with WithListOptions.
+func (c *Client) ListCheckpointPermissions(ctx context.Context, checkpoint, projectID string, opts ...RequestOption) (*List[*CheckpointPermission], error) {
+	var route = path.Join(routes.FineTuningCheckpoints, checkpoint, "permissions")
+	if projectID != "" {
+		route = withQuery(route, url.Values{"project_id": {projectID}})
+	}
+
+	var res, err = c.get(ctx, route, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*CheckpointPermission]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// DeleteCheckpointPermission revokes a permission of |checkpoint|, a fine-tuned model checkpoint. It requires an admin
+// API key (see WithAdminKey).
+func (c *Client) DeleteCheckpointPermission(ctx context.Context, checkpoint, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.FineTuningCheckpoints, checkpoint, "permissions", id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fabiustech/openai/jsonl"
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/roles"
+)
+
+const (
+	// defaultMaxExampleTokens is the maximum number of tokens in a training example, by default.
+	defaultMaxExampleTokens = 65536
+	// defaultMinExamples is the minimum number of examples the fine-tuning jobs endpoints accept.
+	defaultMinExamples = 10
+)
+
+// DatasetOptions configures ValidateDataset.
+type DatasetOptions struct {
+	// Model is the model which will be fine-tuned, whose tokenizer is used to count tokens.
+	// Defaults to models.GPT4oMini20240718.
+	Model models.FineTune
+	// MaxExampleTokens is the maximum number of tokens in an example. Longer examples are truncated during training.
+	// Defaults to 65536.
+	MaxExampleTokens int
+	// MinExamples is the minimum number of examples in the file.
+	// Defaults to 10.
+	MinExamples int
+}
+
+// DatasetIssue is a problem with a training file.
+type DatasetIssue struct {
+	// Line is the line of the file the issue occurs on, starting from 1, or 0 if it concerns the whole file.
+	Line    int
+	Message string
+}
+
+// String implements the fmt.Stringer interface.
+func (i *DatasetIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// DatasetReport is the result of ValidateDataset.
+type DatasetReport struct {
+	// Examples is the number of examples in the file, including invalid ones.
+	Examples int
+	// Tokens is the estimated number of tokens in the valid examples, which is billed once per epoch.
+	Tokens int
+	Issues []*DatasetIssue
+}
+
+// Valid returns true if no issues were found.
+func (r *DatasetReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// ValidateDataset checks the training file read from |r|, a JSON Lines file of FineTuningExample, before it is used
+// to create a fine-tuning job. Every line is checked, and each problem is reported as a DatasetIssue with its line
+// number: lines which are not valid examples, messages in an order the endpoints reject, and examples with more
+// tokens than |opts| allows. |opts| may be nil. An error is only returned if |r| cannot be read.
+func ValidateDataset(r io.Reader, opts *DatasetOptions) (*DatasetReport, error) {
+	var o = DatasetOptions{
+		Model:            models.GPT4oMini20240718,
+		MaxExampleTokens: defaultMaxExampleTokens,
+		MinExamples:      defaultMinExamples,
+	}
+	if opts != nil {
+		if opts.Model != models.UnknownFineTune {
+			o.Model = opts.Model
+		}
+		if opts.MaxExampleTokens > 0 {
+			o.MaxExampleTokens = opts.MaxExampleTokens
+		}
+		if opts.MinExamples > 0 {
+			o.MinExamples = opts.MinExamples
+		}
+	}
+
+	var report = &DatasetReport{}
+	var jr = jsonl.NewReader[*FineTuningExample](r)
+	for {
+		var ex, err = jr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var le *jsonl.LineError
+		if errors.As(err, &le) {
+			report.Examples++
+			report.Issues = append(report.Issues, &DatasetIssue{Line: le.Line, Message: le.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Examples++
+
+		var line = jr.Line()
+		if msg := checkMessageOrder(ex.Messages); msg != "" {
+			report.Issues = append(report.Issues, &DatasetIssue{Line: line, Message: msg})
+			continue
+		}
+
+		var n = countMessageTokens(o.Model.String(), ex.Messages)
+		if n > o.MaxExampleTokens {
+			report.Issues = append(report.Issues, &DatasetIssue{
+				Line:    line,
+				Message: fmt.Sprintf("example has %d tokens, more than the maximum of %d", n, o.MaxExampleTokens),
+			})
+			continue
+		}
+		report.Tokens += n
+	}
+
+	if report.Examples < o.MinExamples {
+		report.Issues = append(report.Issues, &DatasetIssue{
+			Message: fmt.Sprintf("file has %d examples, fewer than the minimum of %d", report.Examples, o.MinExamples),
+		})
+	}
+
+	return report, nil
+}
+
+// checkMessageOrder returns a description of the first message of |messages| which is out of order, or "" if they are
+// in order. System (or developer) messages must come first, followed by a user message. Assistant messages must
+// respond to a user or tool message, tool messages must respond to the tool calls of an assistant message, and the
+// conversation must end with an assistant message.
+func checkMessageOrder(messages []*ChatMessage) string {
+	var prev = roles.Invalid
+	for i, m := range messages {
+		switch m.Role {
+		case roles.System, roles.Developer:
+			if prev != roles.Invalid && prev != roles.System && prev != roles.Developer {
+				return fmt.Sprintf("%s message %d follows a %s message", m.Role, i, prev)
+			}
+		case roles.User:
+		case roles.Assistant:
+			if prev != roles.User && prev != roles.Tool {
+				return fmt.Sprintf("assistant message %d does not follow a user or tool message", i)
+			}
+		case roles.Tool:
+			if prev != roles.Tool && (prev != roles.Assistant || len(messages[i-1].ToolCalls) == 0) {
+				return fmt.Sprintf("tool message %d does not follow an assistant message with tool calls", i)
+			}
+		}
+		prev = m.Role
+	}
+
+	if prev != roles.Assistant {
+		return "example does not end with an assistant message"
+	}
+
+	return ""
+}
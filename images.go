@@ -1,33 +1,227 @@
 package openai
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	// Register the decoders of the formats the images endpoints return.
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/fabiustech/openai/images"
+	"github.com/fabiustech/openai/models"
 	"github.com/fabiustech/openai/routes"
 )
 
-// CreateImageRequest contains all relevant fields for requests to the images/generations endpoint.
+// CreateImageRequest contains all relevant fields for requests to the images/generations endpoint. The supported
+// values of each field depend on Model; unsupported values are rejected by CreateImage before the request is sent.
 type CreateImageRequest struct {
-	// Prompt is a text description of the desired image(s). The maximum length is 1000 characters.
+	// Model specifies the model to use.
+	// Defaults to models.DALLE2.
+	Model models.Image `json:"model,omitempty"`
+	// Prompt is a text description of the desired image(s). The maximum length is 1000 characters for models.DALLE2,
+	// 4000 for models.DALLE3, and 32000 for models.GPTImage1.
 	Prompt string `json:"prompt"`
-	// N specifies the number of images to generate. Must be between 1 and 10.
+	// N specifies the number of images to generate. Must be between 1 and 10, or 1 for models.DALLE3.
 	// Defaults to 1.
 	N int `json:"n,omitempty"`
 	// Size specifies the size of the generated images. Must be one of images.Size256x256, images.Size512x512, or
-	// images.Size1024x1024.
-	// Defaults to images.Size1024x1024.
+	// images.Size1024x1024 for models.DALLE2; images.Size1024x1024, images.Size1792x1024, or images.Size1024x1792 for
+	// models.DALLE3; and images.Size1024x1024, images.Size1536x1024, images.Size1024x1536, or images.SizeAuto for
+	// models.GPTImage1.
+	// Defaults to images.Size1024x1024, or images.SizeAuto for models.GPTImage1.
 	Size images.Size `json:"size,omitempty"`
+	// Quality specifies the quality of the generated images. Must be images.QualityStandard for models.DALLE2; one of
+	// images.QualityStandard or images.QualityHD for models.DALLE3; and one of images.QualityLow,
+	// images.QualityMedium, images.QualityHigh, or images.QualityAuto for models.GPTImage1.
+	// Defaults to images.QualityStandard, or images.QualityAuto for models.GPTImage1.
+	Quality images.Quality `json:"quality,omitempty"`
+	// Style specifies the style of the generated images. Only supported by models.DALLE3.
+	// Defaults to images.StyleVivid.
+	Style images.Style `json:"style,omitempty"`
 	// ResponseFormat specifies the format in which the generated images are returned. Must be one of images.FormatURL
-	// or images.FormatB64JSON.
+	// or images.FormatB64JSON. Not supported by models.GPTImage1, which always returns Base64 data.
 	// Defaults to images.FormatURL.
 	ResponseFormat images.Format `json:"response_format,omitempty"`
+	// OutputFormat specifies the file format of the generated images. Only supported by models.GPTImage1.
+	// Defaults to images.OutputFormatPNG.
+	OutputFormat images.OutputFormat `json:"output_format,omitempty"`
+	// OutputCompression specifies the compression level (0-100%) of the generated images. Only supported by
+	// models.GPTImage1 with images.OutputFormatJPEG or images.OutputFormatWebP.
+	// Defaults to 100.
+	OutputCompression *int `json:"output_compression,omitempty"`
+	// Background specifies the background of the generated images. Only supported by models.GPTImage1.
+	// Defaults to images.BackgroundAuto.
+	Background images.Background `json:"background,omitempty"`
 	// User specifies a unique identifier representing your end-user, which can help OpenAI to monitor and detect abuse:
 	// https://beta.openai.com/docs/guides/safety-best-practices/end-user-ids.
 	User string `json:"user,omitempty"`
 }
 
+// model returns the model of the request (see WithModelRoute).
+func (ir *CreateImageRequest) model() string {
+	return ir.Model.String()
+}
+
+// imageModel describes the parameters supported by an image model.
+type imageModel struct {
+	maxPrompt int
+	maxN      int
+	sizes     []images.Size
+	qualities []images.Quality
+	// gptImage is true for models which support the parameters of models.GPTImage1, and not those of the DALL·E
+	// models.
+	gptImage bool
+}
+
+var imageModels = map[models.Image]*imageModel{
+	models.DALLE2: {
+		maxPrompt: 1000,
+		maxN:      10,
+		sizes:     []images.Size{images.Size256x256, images.Size512x512, images.Size1024x1024},
+		qualities: []images.Quality{images.QualityStandard},
+	},
+	models.DALLE3: {
+		maxPrompt: 4000,
+		maxN:      1,
+		sizes:     []images.Size{images.Size1024x1024, images.Size1792x1024, images.Size1024x1792},
+		qualities: []images.Quality{images.QualityStandard, images.QualityHD},
+	},
+	models.GPTImage1: {
+		maxPrompt: 32000,
+		maxN:      10,
+		sizes:     []images.Size{images.Size1024x1024, images.Size1536x1024, images.Size1024x1536, images.SizeAuto},
+		qualities: []images.Quality{images.QualityLow, images.QualityMedium, images.QualityHigh, images.QualityAuto},
+		gptImage:  true,
+	},
+}
+
+// validate returns an error if |ir| sets a parameter to a value which its model does not support.
+func (ir *CreateImageRequest) validate() error {
+	var model = ir.Model
+	if model == models.UnknownImage {
+		model = models.DALLE2
+	}
+	var m, ok = imageModels[model]
+	if !ok {
+		return fmt.Errorf("openai: unknown image model %d", model)
+	}
+
+	switch {
+	case ir.Prompt == "":
+		return errors.New("openai: prompt is required")
+	case utf8.RuneCountInString(ir.Prompt) > m.maxPrompt:
+		return fmt.Errorf("openai: prompt is longer than the maximum of %d characters for %s", m.maxPrompt, model)
+	case ir.N < 0 || ir.N > m.maxN:
+		return fmt.Errorf("openai: n must be between 1 and %d for %s", m.maxN, model)
+	case ir.Size != images.SizeInvalid && !containsImageOption(m.sizes, ir.Size):
+		return fmt.Errorf("openai: size %s is not supported by %s", imageSizeName(ir.Size), model)
+	case ir.Quality != images.QualityInvalid && !containsImageOption(m.qualities, ir.Quality):
+		return fmt.Errorf("openai: quality %s is not supported by %s", ir.Quality, model)
+	case ir.Style != images.StyleInvalid && model != models.DALLE3:
+		return fmt.Errorf("openai: style is not supported by %s", model)
+	case !validImageFormat(ir.ResponseFormat):
+		return fmt.Errorf("openai: invalid response format %d", ir.ResponseFormat)
+	}
+
+	if !m.gptImage {
+		if ir.OutputFormat != images.OutputFormatInvalid || ir.OutputCompression != nil ||
+			ir.Background != images.BackgroundInvalid {
+			return fmt.Errorf("openai: output_format, output_compression, and background are not supported by %s", model)
+		}
+		return nil
+	}
+
+	switch {
+	case ir.ResponseFormat != images.FormatInvalid:
+		return fmt.Errorf("openai: response_format is not supported by %s, which always returns Base64 data", model)
+	case ir.OutputCompression != nil && (*ir.OutputCompression < 0 || *ir.OutputCompression > 100):
+		return errors.New("openai: output_compression must be between 0 and 100")
+	case ir.OutputCompression != nil && ir.OutputFormat != images.OutputFormatJPEG &&
+		ir.OutputFormat != images.OutputFormatWebP:
+		return errors.New("openai: output_compression is only supported by the jpeg and webp output formats")
+	case ir.Background == images.BackgroundTransparent && ir.OutputFormat == images.OutputFormatJPEG:
+		return errors.New("openai: transparent backgrounds are not supported by the jpeg output format")
+	}
+
+	return nil
+}
+
+// validate returns an error if |eir| is missing a required field, or sets a parameter to an unsupported value. The
+// images/edits endpoint only supports models.DALLE2.
+func (eir *EditImageRequest) validate() error {
+	switch {
+	case eir.Image == "":
+		return errors.New("openai: image is required")
+	case eir.Prompt == "":
+		return errors.New("openai: prompt is required")
+	case utf8.RuneCountInString(eir.Prompt) > imageModels[models.DALLE2].maxPrompt:
+		return fmt.Errorf("openai: prompt is longer than the maximum of %d characters", imageModels[models.DALLE2].maxPrompt)
+	}
+
+	return validateImageOptions(eir.N, eir.Size, eir.ResponseFormat)
+}
+
+// validate returns an error if |vir| is missing a required field, or sets a parameter to an unsupported value. The
+// images/variations endpoint only supports models.DALLE2.
+func (vir *VariationImageRequest) validate() error {
+	if vir.Image == "" {
+		return errors.New("openai: image is required")
+	}
+
+	return validateImageOptions(vir.N, vir.Size, vir.ResponseFormat)
+}
+
+// validateImageOptions returns an error if |n|, |size|, or |format| is not supported by models.DALLE2. The zero value
+// of each is the default, and is valid.
+func validateImageOptions(n int, size images.Size, format images.Format) error {
+	var m = imageModels[models.DALLE2]
+
+	switch {
+	case n < 0 || n > m.maxN:
+		return fmt.Errorf("openai: n must be between 1 and %d", m.maxN)
+	case size != images.SizeInvalid && !containsImageOption(m.sizes, size):
+		return fmt.Errorf("openai: size %s is not supported", imageSizeName(size))
+	case !validImageFormat(format):
+		return fmt.Errorf("openai: invalid response format %d", format)
+	}
+
+	return nil
+}
+
+// validImageFormat returns true if |f| is the zero value (the default), or a known images.Format.
+func validImageFormat(f images.Format) bool {
+	return f == images.FormatInvalid || f == images.FormatURL || f == images.FormatB64JSON
+}
+
+// imageSizeName returns the name of |s|, or its numeric value if it is not a known images.Size.
+func imageSizeName(s images.Size) string {
+	if name := s.String(); name != "" {
+		return name
+	}
+
+	return fmt.Sprint(int(s))
+}
+
+// containsImageOption returns true if |options| contains |v|.
+func containsImageOption[T comparable](options []T, v T) bool {
+	for _, o := range options {
+		if o == v {
+			return true
+		}
+	}
+
+	return false
+}
+
 // EditImageRequest contains all relevant fields for requests to the images/edits endpoint.
 type EditImageRequest struct {
 	// Image is the image to edit. Must be a valid PNG file, less than 4MB, and square. If Mask is not provided, image
@@ -76,26 +270,123 @@ type VariationImageRequest struct {
 
 // ImageResponse represents a response structure for image API.
 type ImageResponse struct {
+	ResponseMeta
+
 	Created uint64       `json:"created,omitempty"`
 	Data    []*ImageData `json:"data,omitempty"`
 }
 
 // ImageData represents a response data structure for image API.
-// Only one field will be non-nil.
+// Only one of URL and B64JSON will be non-nil.
 type ImageData struct {
 	URL     *string `json:"url,omitempty"`
 	B64JSON *string `json:"b64_json,omitempty"`
+	// RevisedPrompt is the prompt which was used to generate the image, if the model revised it (as models.DALLE3
+	// does).
+	RevisedPrompt *string `json:"revised_prompt,omitempty"`
+}
+
+// errImageURL is returned when the Base64 data of an image is requested, but it was returned as a URL.
+var errImageURL = errors.New("openai: image was returned as a URL; use Client.DownloadImage")
+
+// Bytes returns the decoded contents of |d|, if it was returned as Base64 data (see images.FormatB64JSON).
+func (d *ImageData) Bytes() ([]byte, error) {
+	if d.B64JSON == nil {
+		return nil, errImageURL
+	}
+
+	return base64.StdEncoding.DecodeString(*d.B64JSON)
+}
+
+// Image decodes |d| into an image.Image, if it was returned as Base64 data (see images.FormatB64JSON). PNG and JPEG
+// images are supported; other formats can be decoded from Bytes once their decoder is registered.
+func (d *ImageData) Image() (image.Image, error) {
+	var b, err = d.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var img image.Image
+	if img, _, err = image.Decode(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// Save writes the decoded contents of |d| to the file at |path|, if it was returned as Base64 data (see
+// images.FormatB64JSON). Use Client.SaveImage to also save images returned as URLs.
+func (d *ImageData) Save(path string) error {
+	var b, err = d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
+
+// DownloadImage writes the contents of |d| to |w|. Images returned as URLs are fetched with the Client's
+// *http.Client (see WithHTTPClient), without its credentials, as the URLs are pre-signed. The URLs expire an hour after
+// the image is generated.
+func (c *Client) DownloadImage(ctx context.Context, d *ImageData, w io.Writer) error {
+	if d.B64JSON != nil {
+		var _, err = io.Copy(w, base64.NewDecoder(base64.StdEncoding, strings.NewReader(*d.B64JSON)))
+		return err
+	}
+	if d.URL == nil {
+		return errors.New("openai: image has no data")
+	}
+
+	var req, err = http.NewRequestWithContext(ctx, http.MethodGet, *d.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	if resp, err = c.client().Do(req); err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("openai: downloading image: %s", resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
+
+// SaveImage writes the contents of |d| to the file at |path|, downloading it if it was returned as a URL (see
+// DownloadImage). If the download fails, the file is removed.
+func (c *Client) SaveImage(ctx context.Context, d *ImageData, path string) error {
+	var f, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = c.DownloadImage(ctx, d, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return f.Close()
 }
 
 // CreateImage creates an image (or images) given a prompt.
-func (c *Client) CreateImage(ctx context.Context, ir *CreateImageRequest) (*ImageResponse, error) {
-	var b, err = c.post(ctx, routes.ImageGenerations, ir)
+func (c *Client) CreateImage(ctx context.Context, ir *CreateImageRequest, opts ...RequestOption) (*ImageResponse, error) {
+	if err := ir.validate(); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.ImageGenerations, ir, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &ImageResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
@@ -103,14 +394,18 @@ func (c *Client) CreateImage(ctx context.Context, ir *CreateImageRequest) (*Imag
 }
 
 // EditImage creates an edited or extended image (or images) given an original image and a prompt.
-func (c *Client) EditImage(ctx context.Context, eir *EditImageRequest) (*ImageResponse, error) {
-	var b, err = c.post(ctx, routes.ImageEdits, eir)
+func (c *Client) EditImage(ctx context.Context, eir *EditImageRequest, opts ...RequestOption) (*ImageResponse, error) {
+	if err := eir.validate(); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.ImageEdits, eir, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &ImageResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
@@ -118,14 +413,18 @@ func (c *Client) EditImage(ctx context.Context, eir *EditImageRequest) (*ImageRe
 }
 
 // ImageVariation creates a variation (or variations) of a given image.
-func (c *Client) ImageVariation(ctx context.Context, vir *VariationImageRequest) (*ImageResponse, error) {
-	var b, err = c.post(ctx, routes.ImageVariations, vir)
+func (c *Client) ImageVariation(ctx context.Context, vir *VariationImageRequest, opts ...RequestOption) (*ImageResponse, error) {
+	if err := vir.validate(); err != nil {
+		return nil, err
+	}
+
+	var res, err = c.post(ctx, routes.ImageVariations, vir, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &ImageResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
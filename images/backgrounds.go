@@ -0,0 +1,51 @@
+package images
+
+// Background represents the enum values for the background of images generated by models.GPTImage1.
+type Background int
+
+const (
+	// BackgroundInvalid represents an invalid Background option.
+	BackgroundInvalid Background = iota
+	// BackgroundAuto lets the model choose the background. It is the default.
+	BackgroundAuto
+	// BackgroundTransparent generates images with a transparent background, which requires OutputFormatPNG or
+	// OutputFormatWebP.
+	BackgroundTransparent
+	// BackgroundOpaque generates images with an opaque background.
+	BackgroundOpaque
+)
+
+// String implements the fmt.Stringer interface.
+func (bg Background) String() string {
+	return backgroundToString[bg]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (bg Background) MarshalText() ([]byte, error) {
+	return []byte(bg.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |bg| to BackgroundInvalid.
+func (bg *Background) UnmarshalText(b []byte) error {
+	if val, ok := stringToBackground[(string(b))]; ok {
+		*bg = val
+		return nil
+	}
+
+	*bg = BackgroundInvalid
+
+	return nil
+}
+
+var backgroundToString = map[Background]string{
+	BackgroundAuto:        "auto",
+	BackgroundTransparent: "transparent",
+	BackgroundOpaque:      "opaque",
+}
+
+var stringToBackground = map[string]Background{
+	"auto":        BackgroundAuto,
+	"transparent": BackgroundTransparent,
+	"opaque":      BackgroundOpaque,
+}
@@ -1,5 +1,5 @@
 // Package images contains the enum values which represent the various
-// image formats and sizes returned by the OpenAI image endpoints.
+// image formats, sizes, and other options of the OpenAI image endpoints.
 package images
 
 // Format represents the enum values for the formats in which
@@ -48,3 +48,52 @@ var stringToFormat = map[string]Format{
 	"url":      FormatURL,
 	"b64_json": FormatB64JSON,
 }
+
+// OutputFormat represents the enum values for the file formats of images generated by models.GPTImage1.
+type OutputFormat int
+
+const (
+	// OutputFormatInvalid represents an invalid OutputFormat option.
+	OutputFormatInvalid OutputFormat = iota
+	// OutputFormatPNG specifies PNG images. It is the default.
+	OutputFormatPNG
+	// OutputFormatJPEG specifies JPEG images, which are faster to generate than PNG images.
+	OutputFormatJPEG
+	// OutputFormatWebP specifies WebP images.
+	OutputFormatWebP
+)
+
+// String implements the fmt.Stringer interface.
+func (o OutputFormat) String() string {
+	return outputFormatToString[o]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (o OutputFormat) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |o| to OutputFormatInvalid.
+func (o *OutputFormat) UnmarshalText(b []byte) error {
+	if val, ok := stringToOutputFormat[(string(b))]; ok {
+		*o = val
+		return nil
+	}
+
+	*o = OutputFormatInvalid
+
+	return nil
+}
+
+var outputFormatToString = map[OutputFormat]string{
+	OutputFormatPNG:  "png",
+	OutputFormatJPEG: "jpeg",
+	OutputFormatWebP: "webp",
+}
+
+var stringToOutputFormat = map[string]OutputFormat{
+	"png":  OutputFormatPNG,
+	"jpeg": OutputFormatJPEG,
+	"webp": OutputFormatWebP,
+}
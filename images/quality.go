@@ -0,0 +1,63 @@
+package images
+
+// Quality represents the enum values for the quality of generated images. The supported values
+// depend on the model.
+type Quality int
+
+const (
+	// QualityInvalid represents an invalid Quality option.
+	QualityInvalid Quality = iota
+	// QualityStandard is the only quality supported by models.DALLE2, and the default of models.DALLE3.
+	QualityStandard
+	// QualityHD creates images with finer details and greater consistency with models.DALLE3.
+	QualityHD
+	// QualityLow is the fastest and cheapest quality supported by models.GPTImage1.
+	QualityLow
+	// QualityMedium is a quality supported by models.GPTImage1.
+	QualityMedium
+	// QualityHigh is the best quality supported by models.GPTImage1.
+	QualityHigh
+	// QualityAuto lets models.GPTImage1 choose the quality based on the prompt. It is the default of models.GPTImage1.
+	QualityAuto
+)
+
+// String implements the fmt.Stringer interface.
+func (q Quality) String() string {
+	return qualityToString[q]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (q Quality) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |q| to QualityInvalid.
+func (q *Quality) UnmarshalText(b []byte) error {
+	if val, ok := stringToQuality[(string(b))]; ok {
+		*q = val
+		return nil
+	}
+
+	*q = QualityInvalid
+
+	return nil
+}
+
+var qualityToString = map[Quality]string{
+	QualityStandard: "standard",
+	QualityHD:       "hd",
+	QualityLow:      "low",
+	QualityMedium:   "medium",
+	QualityHigh:     "high",
+	QualityAuto:     "auto",
+}
+
+var stringToQuality = map[string]Quality{
+	"standard": QualityStandard,
+	"hd":       QualityHD,
+	"low":      QualityLow,
+	"medium":   QualityMedium,
+	"high":     QualityHigh,
+	"auto":     QualityAuto,
+}
@@ -16,6 +16,21 @@ const (
 	// Size1024x1024 specifies that the API will return an image that is
 	// 1024x1024 pixels.
 	Size1024x1024
+	// Size1792x1024 specifies that the API will return a landscape image
+	// that is 1792x1024 pixels. Only supported by models.DALLE3.
+	Size1792x1024
+	// Size1024x1792 specifies that the API will return a portrait image
+	// that is 1024x1792 pixels. Only supported by models.DALLE3.
+	Size1024x1792
+	// Size1536x1024 specifies that the API will return a landscape image
+	// that is 1536x1024 pixels. Only supported by models.GPTImage1.
+	Size1536x1024
+	// Size1024x1536 specifies that the API will return a portrait image
+	// that is 1024x1536 pixels. Only supported by models.GPTImage1.
+	Size1024x1536
+	// SizeAuto lets the model choose the size of the image. Only
+	// supported by models.GPTImage1, of which it is the default.
+	SizeAuto
 )
 
 // String implements the fmt.Stringer interface.
@@ -45,10 +60,20 @@ var imageToString = map[Size]string{
 	Size256x256:   "256x256",
 	Size512x512:   "512x512",
 	Size1024x1024: "1024x1024",
+	Size1792x1024: "1792x1024",
+	Size1024x1792: "1024x1792",
+	Size1536x1024: "1536x1024",
+	Size1024x1536: "1024x1536",
+	SizeAuto:      "auto",
 }
 
 var stringToImage = map[string]Size{
 	"256x256":   Size256x256,
 	"512x512":   Size512x512,
 	"1024x1024": Size1024x1024,
+	"1792x1024": Size1792x1024,
+	"1024x1792": Size1024x1792,
+	"1536x1024": Size1536x1024,
+	"1024x1536": Size1024x1536,
+	"auto":      SizeAuto,
 }
@@ -0,0 +1,46 @@
+package images
+
+// Style represents the enum values for the style of images generated by models.DALLE3.
+type Style int
+
+const (
+	// StyleInvalid represents an invalid Style option.
+	StyleInvalid Style = iota
+	// StyleVivid leans towards generating hyper-real and dramatic images. It is the default.
+	StyleVivid
+	// StyleNatural produces more natural, less hyper-real looking images.
+	StyleNatural
+)
+
+// String implements the fmt.Stringer interface.
+func (s Style) String() string {
+	return styleToString[s]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Style) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |s| to StyleInvalid.
+func (s *Style) UnmarshalText(b []byte) error {
+	if val, ok := stringToStyle[(string(b))]; ok {
+		*s = val
+		return nil
+	}
+
+	*s = StyleInvalid
+
+	return nil
+}
+
+var styleToString = map[Style]string{
+	StyleVivid:   "vivid",
+	StyleNatural: "natural",
+}
+
+var stringToStyle = map[string]Style{
+	"vivid":   StyleVivid,
+	"natural": StyleNatural,
+}
@@ -0,0 +1,164 @@
+// Package jsonl reads and writes JSON Lines files, such as the training files of fine-tuning jobs and the input and
+// output files of batches, with one value of type T per line:
+//
+//	var w = jsonl.NewWriter[*openai.FineTuningExample](f)
+//	for _, ex := range examples {
+//		if err := w.Write(ex); err != nil {
+//			return err
+//		}
+//	}
+//
+// Values which implement Validator are validated as they are written and read, so that a malformed line is reported
+// with its line number rather than by the API.
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Validator is implemented by values which can check that they contain the fields required of a line.
+type Validator interface {
+	Validate() error
+}
+
+// LineError is returned when a line cannot be encoded, decoded, or validated.
+type LineError struct {
+	// Line is the 1-based number of the line.
+	Line int
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *LineError) Error() string {
+	return fmt.Sprintf("jsonl: line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// validate validates |v|, if it implements Validator.
+func validate(v any) error {
+	if vv, ok := v.(Validator); ok {
+		return vv.Validate()
+	}
+
+	return nil
+}
+
+// Writer writes values of type T as JSON Lines.
+type Writer[T any] struct {
+	w    io.Writer
+	buf  bytes.Buffer
+	enc  *json.Encoder
+	line int
+}
+
+// NewWriter returns a *Writer which writes to |w|.
+func NewWriter[T any](w io.Writer) *Writer[T] {
+	var jw = &Writer[T]{w: w}
+	jw.enc = json.NewEncoder(&jw.buf)
+	// Prompts commonly contain HTML, which need not be escaped.
+	jw.enc.SetEscapeHTML(false)
+
+	return jw
+}
+
+// Write validates |v| and writes it as a single line. Nothing is written if |v| is invalid.
+func (w *Writer[T]) Write(v T) error {
+	w.line++
+	if err := validate(v); err != nil {
+		return &LineError{Line: w.line, Err: err}
+	}
+
+	w.buf.Reset()
+	// Encode terminates the line with a newline.
+	if err := w.enc.Encode(v); err != nil {
+		return &LineError{Line: w.line, Err: err}
+	}
+
+	var _, err = w.w.Write(w.buf.Bytes())
+
+	return err
+}
+
+// Reader reads values of type T from JSON Lines. Blank lines are skipped.
+type Reader[T any] struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewReader returns a *Reader which reads from |r|.
+func NewReader[T any](r io.Reader) *Reader[T] {
+	return &Reader[T]{r: bufio.NewReader(r)}
+}
+
+// Read returns the next value, or io.EOF once there are no more lines. Lines which cannot be decoded, or are invalid,
+// are returned as a *LineError; reading may continue with the next line.
+func (r *Reader[T]) Read() (T, error) {
+	var v T
+	for {
+		var b, err = r.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return v, err
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return v, err
+		}
+		r.line++
+
+		if b = bytes.TrimSpace(b); len(b) == 0 {
+			continue
+		}
+
+		if err = json.Unmarshal(b, &v); err != nil {
+			return v, &LineError{Line: r.line, Err: err}
+		}
+		if err = validate(v); err != nil {
+			return v, &LineError{Line: r.line, Err: err}
+		}
+
+		return v, nil
+	}
+}
+
+// Line returns the line number, starting from 1, of the value last returned by Read.
+func (r *Reader[T]) Line() int {
+	return r.line
+}
+
+// ReadAll reads every value from |r|. It stops at the first error.
+func ReadAll[T any](r io.Reader) ([]T, error) {
+	var jr = NewReader[T](r)
+
+	var values []T
+	for {
+		var v, err = jr.Read()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+// WriteAll writes each of |values| to |w|. It stops at the first error.
+func WriteAll[T any](w io.Writer, values []T) error {
+	var jw = NewWriter[T](w)
+	for _, v := range values {
+		if err := jw.Write(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
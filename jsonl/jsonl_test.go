@@ -0,0 +1,66 @@
+package jsonl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type example struct {
+	Prompt string `json:"prompt"`
+}
+
+func (e *example) Validate() error {
+	if e.Prompt == "" {
+		return errors.New("no prompt")
+	}
+
+	return nil
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	var w = NewWriter[*example](&buf)
+
+	if err := w.Write(&example{Prompt: "<b>Hi</b>"}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	var err = w.Write(&example{})
+	var lerr *LineError
+	if !errors.As(err, &lerr) || lerr.Line != 2 {
+		t.Fatalf("expected a *LineError for line 2, got %v", err)
+	}
+
+	if got, want := buf.String(), "{\"prompt\":\"<b>Hi</b>\"}\n"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestReader(t *testing.T) {
+	var r = NewReader[*example](strings.NewReader("{\"prompt\": \"a\"}\n\n{\"prompt\": \"\"}\n{\"prompt\": \"b\"}"))
+
+	if v, err := r.Read(); err != nil || v.Prompt != "a" {
+		t.Fatalf("unexpected line 1: %+v (error: %v)", v, err)
+	}
+
+	// Blank lines are counted, but skipped.
+	var _, err = r.Read()
+	var lerr *LineError
+	if !errors.As(err, &lerr) || lerr.Line != 3 || lerr.Error() != "jsonl: line 3: no prompt" {
+		t.Fatalf("expected a *LineError for line 3, got %v", err)
+	}
+
+	if v, err := r.Read(); err != nil || v.Prompt != "b" {
+		t.Fatalf("unexpected line 4: %+v (error: %v)", v, err)
+	}
+	if _, err = r.Read(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	if _, err = ReadAll[*example](strings.NewReader("{\"prompt\": \"a\"}\nnot json\n")); !errors.As(err, &lerr) || lerr.Line != 2 {
+		t.Fatalf("expected a *LineError for line 2, got %v", err)
+	}
+}
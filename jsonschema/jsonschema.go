@@ -0,0 +1,257 @@
+// Package jsonschema generates JSON Schemas from Go types, for use as the parameters of function tools and as
+// structured output formats.
+//
+// Schemas are derived from the type's fields and their tags:
+//
+//	type GetWeather struct {
+//		City string `json:"city" description:"The city to get the weather for."`
+//		Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+// Fields are named by their json tag, and are required unless they are tagged omitempty (or, in strict mode, always).
+// The description tag sets the field's description, and the enum tag restricts it to a comma separated list of values.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema.
+type Schema struct {
+	// Type is the JSON type of the value (e.g. "object"). It is a []string if the value may be one of several types
+	// (e.g. ["string", "null"]).
+	Type any `json:"type,omitempty"`
+	// Description describes the value.
+	Description string `json:"description,omitempty"`
+	// Enum restricts the value to a set of values.
+	Enum []any `json:"enum,omitempty"`
+	// Format is the format of a string value (e.g. "date-time").
+	Format string `json:"format,omitempty"`
+	// Properties are the schemas of the properties of an object.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// Required lists the properties of an object which must be present.
+	Required []string `json:"required,omitempty"`
+	// AdditionalProperties is false if an object may not contain properties other than Properties, or the schema of
+	// its values if it is a map.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+	// Items is the schema of the elements of an array.
+	Items *Schema `json:"items,omitempty"`
+}
+
+// Options configures the generation of a Schema.
+type Options struct {
+	// Strict generates a schema compatible with strict function calling and structured outputs: every property is
+	// required (optional properties are made nullable instead), and objects disallow additional properties.
+	// Defaults to false.
+	Strict bool
+}
+
+// For returns the Schema of T.
+func For[T any](opts *Options) (*Schema, error) {
+	return Generate(reflect.TypeOf((*T)(nil)).Elem(), opts)
+}
+
+// Generate returns the Schema of |t|. |opts| may be nil.
+func Generate(t reflect.Type, opts *Options) (*Schema, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	var g = &generator{opts: opts, visiting: map[reflect.Type]bool{}}
+
+	return g.schema(t)
+}
+
+// generator generates a Schema.
+type generator struct {
+	opts *Options
+	// visiting contains the struct types being generated, to detect recursive types.
+	visiting map[reflect.Type]bool
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*interface{ MarshalText() ([]byte, error) })(nil)).Elem()
+)
+
+// schema returns the Schema of |t|.
+func (g *generator) schema(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case t == rawMessageType:
+		return &Schema{}, nil
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return nil, fmt.Errorf("jsonschema: cannot generate a schema for %s, which implements json.Marshaler", t)
+	case t.Kind() != reflect.String && (t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)):
+		// Types which marshal as text (e.g. enums) are strings.
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string.
+			return &Schema{Type: "string"}, nil
+		}
+		var items, err = g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("jsonschema: unsupported map key type %s", t.Key())
+		}
+		if g.opts.Strict {
+			return nil, fmt.Errorf("jsonschema: maps are not supported by strict schemas (%s)", t)
+		}
+		var values, err = g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		return nil, fmt.Errorf("jsonschema: unsupported type %s", t)
+	}
+}
+
+// object returns the Schema of the struct type |t|.
+func (g *generator) object(t reflect.Type) (*Schema, error) {
+	if g.visiting[t] {
+		return nil, fmt.Errorf("jsonschema: recursive type %s is not supported", t)
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	var s = &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if g.opts.Strict {
+		s.AdditionalProperties = false
+	}
+
+	if err := g.fields(t, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// fields adds the properties of the fields of the struct type |t| to |s|. The fields of embedded structs without a
+// json tag are promoted, as they are by encoding/json.
+func (g *generator) fields(t reflect.Type, s *Schema) error {
+	for i := 0; i < t.NumField(); i++ {
+		var f = t.Field(i)
+
+		var tag = f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		var name, flags, _ = strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			var ft = f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := g.fields(ft, s); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var p, err = g.schema(f.Type)
+		if err != nil {
+			return fmt.Errorf("%w (field %s.%s)", err, t.Name(), f.Name)
+		}
+		p.Description = f.Tag.Get("description")
+
+		if enum := f.Tag.Get("enum"); enum != "" {
+			for _, v := range strings.Split(enum, ",") {
+				p.Enum = append(p.Enum, enumValue(p.Type, strings.TrimSpace(v)))
+			}
+		}
+
+		var optional = hasFlag(flags, "omitempty")
+		switch {
+		case !optional:
+			s.Required = append(s.Required, name)
+		case g.opts.Strict:
+			// Strict schemas must require every property, so optional properties are nullable instead.
+			if p.Type != nil {
+				p.Type = []any{p.Type, "null"}
+			}
+			if p.Enum != nil {
+				p.Enum = append(p.Enum, nil)
+			}
+			s.Required = append(s.Required, name)
+		}
+
+		s.Properties[name] = p
+	}
+
+	return nil
+}
+
+// enumValue returns the enum value |v| as the JSON type |typ|.
+func enumValue(typ any, v string) any {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+
+	return v
+}
+
+// hasFlag reports whether the comma separated json tag options |flags| contain |flag|.
+func hasFlag(flags, flag string) bool {
+	for _, f := range strings.Split(flags, ",") {
+		if f == flag {
+			return true
+		}
+	}
+
+	return false
+}
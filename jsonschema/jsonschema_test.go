@@ -0,0 +1,64 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string `json:"city" description:"The city."`
+}
+
+type weatherRequest struct {
+	address
+	Days     int       `json:"days" description:"Number of days." enum:"1,3,7"`
+	Unit     string    `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+	Tags     []string  `json:"tags,omitempty"`
+	Since    time.Time `json:"since"`
+	Internal string    `json:"-"`
+	private  string
+}
+
+func TestFor(t *testing.T) {
+	var s, err = For[weatherRequest](nil)
+	if err != nil {
+		t.Fatalf("For error: %v", err)
+	}
+
+	var b, _ = json.Marshal(s)
+	const want = `{"type":"object","properties":{"city":{"type":"string","description":"The city."},` +
+		`"days":{"type":"integer","description":"Number of days.","enum":[1,3,7]},` +
+		`"since":{"type":"string","format":"date-time"},"tags":{"type":"array","items":{"type":"string"}},` +
+		`"unit":{"type":"string","enum":["celsius","fahrenheit"]}},"required":["city","days","since"]}`
+	if string(b) != want {
+		t.Fatalf("unexpected schema:\n%s\nexpected:\n%s", b, want)
+	}
+
+	if s, err = For[weatherRequest](&Options{Strict: true}); err != nil {
+		t.Fatalf("For error: %v", err)
+	}
+	b, _ = json.Marshal(s.Properties["unit"])
+	if string(b) != `{"type":["string","null"],"enum":["celsius","fahrenheit",null]}` || len(s.Required) != 5 {
+		t.Fatalf("unexpected strict schema: %s, required: %v", b, s.Required)
+	}
+	if s.AdditionalProperties != false {
+		t.Fatalf("expected a strict schema to disallow additional properties")
+	}
+}
+
+type node struct {
+	Children []*node `json:"children"`
+}
+
+func TestForErrors(t *testing.T) {
+	if _, err := For[node](nil); err == nil {
+		t.Fatal("expected an error for a recursive type")
+	}
+	if _, err := For[map[int]string](nil); err == nil {
+		t.Fatal("expected an error for a map with non-string keys")
+	}
+	if _, err := For[chan int](nil); err == nil {
+		t.Fatal("expected an error for a channel")
+	}
+}
@@ -0,0 +1,164 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultKeyCooldown is how long a key is demoted for after a quota or rate limit error, by default.
+const defaultKeyCooldown = time.Minute
+
+// KeySelection determines how the key used for each request is chosen from a key pool (see WithAPIKeys).
+type KeySelection int
+
+const (
+	// RoundRobin uses each key in turn.
+	RoundRobin KeySelection = iota
+	// LeastLoaded uses the key with the fewest requests in flight, e.g. to balance long-running streamed requests.
+	LeastLoaded
+)
+
+// KeyPoolConfig configures the selection of API keys from a pool (see WithAPIKeys).
+type KeyPoolConfig struct {
+	// Selection determines how the key used for each request is chosen.
+	// Defaults to RoundRobin.
+	Selection KeySelection
+	// Cooldown is how long a key is demoted for after a request authenticated with it hits a quota or rate limit
+	// error (a 429 response). Demoted keys are only used when every key is demoted, in which case the key whose
+	// demotion ends first is used. A request whose key has exhausted its quota is sent again with another key, and
+	// combined with WithRetry, rate limited requests are retried with another key.
+	// Defaults to 1m.
+	Cooldown time.Duration
+}
+
+// poolKey is an API key within a keyPool.
+type poolKey struct {
+	value    string
+	inFlight int
+	// demotedUntil is the time until which the key should not be used.
+	demotedUntil time.Time
+}
+
+// keyPool spreads requests across several API keys.
+type keyPool struct {
+	selection KeySelection
+	cooldown  time.Duration
+
+	mu   sync.Mutex
+	keys []*poolKey
+	// next is the index of the next key to consider.
+	next int
+}
+
+// newKeyPool returns a *keyPool of |keys|, configured by |cfg|.
+func newKeyPool(keys []string, cfg *KeyPoolConfig) *keyPool {
+	var p = &keyPool{cooldown: defaultKeyCooldown}
+	if cfg != nil {
+		p.selection = cfg.Selection
+		if cfg.Cooldown > 0 {
+			p.cooldown = cfg.Cooldown
+		}
+	}
+
+	for _, k := range keys {
+		p.keys = append(p.keys, &poolKey{value: k})
+	}
+
+	return p
+}
+
+// acquire returns the key to use for the next request, which must be passed to release once the request is done.
+func (p *keyPool) acquire() *poolKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var now = time.Now()
+	var best = -1
+	for i := range p.keys {
+		var idx = (p.next + i) % len(p.keys)
+		if best < 0 || p.better(p.keys[idx], p.keys[best], now) {
+			best = idx
+		}
+	}
+
+	p.next = (best + 1) % len(p.keys)
+	p.keys[best].inFlight++
+
+	return p.keys[best]
+}
+
+// better reports whether |a| should be used rather than |b|, which comes before it in round-robin order, at |now|.
+// Keys which are not demoted are preferred, then those whose demotion ends first.
+func (p *keyPool) better(a, b *poolKey, now time.Time) bool {
+	var aDemoted, bDemoted = a.demotedUntil.After(now), b.demotedUntil.After(now)
+	switch {
+	case aDemoted != bDemoted:
+		return !aDemoted
+	case aDemoted:
+		return a.demotedUntil.Before(b.demotedUntil)
+	case p.selection == LeastLoaded:
+		return a.inFlight < b.inFlight
+	default:
+		return false
+	}
+}
+
+// release marks a request authenticated with |k| as done, demoting |k| if the request failed with |err| because of a
+// quota or rate limit.
+func (p *keyPool) release(k *poolKey, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k.inFlight--
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		k.demotedUntil = time.Now().Add(p.cooldown)
+	}
+}
+
+// available reports whether any key of the pool is not demoted.
+func (p *keyPool) available() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var now = time.Now()
+	for _, k := range p.keys {
+		if !k.demotedUntil.After(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sendWithKey sends |req|, authenticated with a key from the Client's key pool. If the quota of the key is exhausted,
+// which retrying cannot fix, |req| is sent again with another key, as long as one is not demoted.
+func (c *Client) sendWithKey(req *http.Request) (*http.Response, error) {
+	for {
+		var k = c.keys.acquire()
+		if c.azure != nil {
+			req.Header.Set("api-key", k.value)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+k.value)
+		}
+
+		var resp, err = c.send(req)
+		if err != nil {
+			c.keys.release(k, err)
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.Code == "insufficient_quota" && c.keys.available() && rewind(req) {
+				continue
+			}
+			return resp, err
+		}
+
+		// Streamed responses remain in flight until their body is closed.
+		resp.Body = &releaseBody{ReadCloser: resp.Body, release: func() { c.keys.release(k, nil) }}
+
+		return resp, nil
+	}
+}
@@ -1,13 +1,74 @@
 package openai
 
 import (
+	"net/url"
+	"strconv"
+
 	"github.com/fabiustech/openai/objects"
 )
 
 // List represents a generic form of list of objects returned from many get endpoints.
 type List[T any] struct {
+	ResponseMeta
+
 	// Object specifies the object type (e.g. Model).
 	Object objects.Object `json:"object"`
 	// Data contains the list of objects.
 	Data []T `json:"data"`
+	// FirstID is the ID of the first object in Data. Only set by endpoints which support cursor pagination.
+	FirstID string `json:"first_id,omitempty"`
+	// LastID is the ID of the last object in Data. Only set by endpoints which support cursor pagination.
+	LastID string `json:"last_id,omitempty"`
+	// HasMore is true if there are more objects to retrieve. Only set by endpoints which support cursor pagination.
+	HasMore bool `json:"has_more,omitempty"`
+}
+
+// Order is the sort order of a list, by creation time.
+type Order string
+
+const (
+	// OrderAsc sorts the oldest objects first.
+	OrderAsc Order = "asc"
+	// OrderDesc sorts the newest objects first.
+	OrderDesc Order = "desc"
+)
+
+// ListOptions paginates the results of endpoints which support cursor pagination (see WithListOptions). All fields
+// are optional.
+type ListOptions struct {
+	// Limit specifies the number of objects to return, between 1 and 100.
+	// Defaults to 20.
+	Limit int
+	// Order specifies the sort order of the objects. Only supported by some endpoints (e.g. assistants and vector
+	// stores).
+	// Defaults to OrderDesc.
+	Order Order
+	// After is a cursor for pagination. Set to the LastID of the previous page to fetch the next page.
+	After string
+	// Before is a cursor for pagination. Set to the FirstID of the previous page to fetch the previous page. Only
+	// supported by some endpoints (e.g. assistants and vector stores).
+	Before string
+}
+
+// values returns |lo| encoded as query parameters.
+func (lo *ListOptions) values() url.Values {
+	var v = url.Values{}
+
+	if lo.Limit > 0 {
+		v.Set("limit", strconv.Itoa(lo.Limit))
+	}
+
+	if lo.Order != "" {
+		v.Set("order", string(lo.Order))
+	}
+
+	if lo.After != "" {
+		v.Set("after", lo.After)
+	}
+
+	if lo.Before != "" {
+		v.Set("before", lo.Before)
+	}
+
+	return v
 }
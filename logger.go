@@ -0,0 +1,127 @@
+//go:build go1.21
+
+package openai
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// maxLoggedBodySize is the maximum number of bytes of a request body which are logged.
+const maxLoggedBodySize = 4096
+
+// apiKeyPattern matches OpenAI API keys, so that they are redacted from logged request bodies.
+var apiKeyPattern = regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`)
+
+// LoggerOptions configures the logging of requests (see WithLogger).
+type LoggerOptions struct {
+	// Level is the level at which successful calls are logged.
+	// Defaults to slog.LevelDebug.
+	Level slog.Leveler
+	// ErrorLevel is the level at which failed calls are logged.
+	// Defaults to slog.LevelWarn.
+	ErrorLevel slog.Leveler
+	// IncludePrompts logs the JSON body of each request (truncated to 4 KB), which contains the prompt. Bodies are
+	// omitted by default, as prompts may contain sensitive user data. API keys are redacted regardless.
+	IncludePrompts bool
+}
+
+// slogLogger logs a summary of each call to the API to an *slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+	opts   LoggerOptions
+}
+
+// WithLogger logs a summary of every call to the API (the endpoint, model, status, duration, request ID, token usage,
+// and error) to |l|. The API key and other credentials are never logged. |opts| may be nil.
+func WithLogger(l *slog.Logger, opts *LoggerOptions) Option {
+	return func(c *Client) {
+		var sl = &slogLogger{logger: l}
+		if opts != nil {
+			sl.opts = *opts
+		}
+		if sl.opts.Level == nil {
+			sl.opts.Level = slog.LevelDebug
+		}
+		if sl.opts.ErrorLevel == nil {
+			sl.opts.ErrorLevel = slog.LevelWarn
+		}
+
+		c.logger = sl
+	}
+}
+
+// logRequest implements the requestLogger interface.
+func (l *slogLogger) logRequest(req *http.Request, m *RequestMetrics) {
+	var ctx, level = req.Context(), l.opts.Level.Level()
+	if m.Err != nil {
+		level = l.opts.ErrorLevel.Level()
+	}
+
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	var attrs = []slog.Attr{
+		slog.String("method", m.Method),
+		slog.String("endpoint", m.Endpoint),
+		slog.Int("status", m.StatusCode),
+		slog.Duration("duration", m.Duration),
+	}
+
+	if m.Model != "" {
+		attrs = append(attrs, slog.String("model", m.Model))
+	}
+
+	if m.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", m.RequestID))
+	}
+
+	if m.Usage != nil {
+		attrs = append(attrs, slog.Group("usage",
+			slog.Int("prompt_tokens", m.Usage.PromptTokens),
+			slog.Int("completion_tokens", m.Usage.CompletionTokens),
+			slog.Int("total_tokens", m.Usage.TotalTokens),
+		))
+	}
+
+	if m.Cost != nil {
+		attrs = append(attrs, slog.Float64("cost_usd", *m.Cost))
+	}
+
+	if l.opts.IncludePrompts {
+		if body, ok := loggedBody(req); ok {
+			attrs = append(attrs, slog.String("body", body))
+		}
+	}
+
+	if m.Err != nil {
+		attrs = append(attrs, slog.String("error", m.Err.Error()))
+	}
+
+	l.logger.LogAttrs(ctx, level, "openai request", attrs...)
+}
+
+// loggedBody returns the JSON body of |req|, truncated and with API keys redacted. It returns false if the body is not
+// JSON or cannot be replayed.
+func loggedBody(req *http.Request) (string, bool) {
+	if req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "", false
+	}
+
+	var rc, err = req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer rc.Close()
+
+	var b []byte
+	if b, err = io.ReadAll(io.LimitReader(rc, maxLoggedBodySize)); err != nil {
+		return "", false
+	}
+
+	return apiKeyPattern.ReplaceAllString(string(b), "sk-REDACTED"), true
+}
@@ -0,0 +1,52 @@
+//go:build go1.21
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", testRequestID)
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	var client, _ = newTestClient(ts.URL)
+	WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), nil)(client)
+
+	var er = &EmbeddingRequest{Input: []string{"my key is sk-abcdefghijklmnop"}}
+	if _, err := client.CreateEmbeddings(context.Background(), er); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	var out = buf.String()
+	if !strings.Contains(out, "endpoint=embeddings") || !strings.Contains(out, "request_id="+testRequestID) {
+		t.Fatalf("expected a request summary, got: %s", out)
+	}
+	if strings.Contains(out, "my key is") || strings.Contains(out, testToken) {
+		t.Fatalf("expected prompts and credentials to be omitted, got: %s", out)
+	}
+
+	buf.Reset()
+	WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		&LoggerOptions{IncludePrompts: true})(client)
+
+	if _, err := client.CreateEmbeddings(context.Background(), er); err != nil {
+		t.Fatalf("CreateEmbeddings error: %v", err)
+	}
+
+	out = buf.String()
+	if !strings.Contains(out, "my key is sk-REDACTED") || strings.Contains(out, "sk-abcdefghijklmnop") {
+		t.Fatalf("expected prompt with the API key redacted, got: %s", out)
+	}
+}
@@ -0,0 +1,146 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describes a completed call to the API, including any retries.
+type RequestMetrics struct {
+	// Endpoint is the route of the request (e.g. "embeddings"), without any query string.
+	Endpoint string
+	// Method is the HTTP method of the request.
+	Method string
+	// Model is the model of the request, if any.
+	Model string
+	// StatusCode is the HTTP status code of the final response. It is 0 if no response was received.
+	StatusCode int
+	// RequestID is the unique ID assigned to the final request by OpenAI, if a response was received.
+	RequestID string
+	// Duration is the time taken by the call, including any retries. For streamed responses, it is the time until the
+	// response headers were received.
+	Duration time.Duration
+	// Usage is the token usage reported by the API, if any. Streamed responses are reported once they report their
+	// usage (see StreamOptions.IncludeUsage) or are closed, and only have usage in the former case.
+	Usage *Usage
+	// Cost is the cost of the call in US dollars, if the Client has Pricing (see WithPricing) and the response reported
+	// its usage.
+	Cost *float64
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// MetricsRecorder records metrics about calls to the API (see WithMetricsRecorder), e.g. to export them as Prometheus
+// counters and histograms.
+type MetricsRecorder interface {
+	// RecordRequest is called after each call to the API completes. It is called synchronously, so it should not block.
+	RecordRequest(ctx context.Context, m *RequestMetrics)
+}
+
+// MetricsRecorderFunc is an adapter which allows the use of an ordinary function as a MetricsRecorder.
+type MetricsRecorderFunc func(ctx context.Context, m *RequestMetrics)
+
+// RecordRequest implements the MetricsRecorder interface by calling f(ctx, m).
+func (f MetricsRecorderFunc) RecordRequest(ctx context.Context, m *RequestMetrics) {
+	f(ctx, m)
+}
+
+// requestLogger logs a summary of each call to the API (see WithLogger).
+type requestLogger interface {
+	logRequest(req *http.Request, m *RequestMetrics)
+}
+
+// recordMetrics reports a call to |req| which started at |start| to the Client's MetricsRecorder, logger, and
+// UsageTracker, if any.
+// |b| is the response body, from which the token usage is parsed, and |cost| is its cost, if known.
+func (c *Client) recordMetrics(req *http.Request, rc *requestConfig, start time.Time, resp *http.Response, b []byte, cost *float64, err error) {
+	if c.metrics == nil && c.logger == nil && c.usage == nil {
+		return
+	}
+
+	var m = newRequestMetrics(req, rc, start, resp, err)
+	m.Cost = cost
+	if len(b) > 0 {
+		var v struct {
+			Usage *Usage `json:"usage"`
+		}
+		if json.Unmarshal(b, &v) == nil {
+			m.Usage = v.Usage
+		}
+	}
+
+	c.reportMetrics(req, rc, m)
+}
+
+// newRequestMetrics returns the *RequestMetrics of a call to |req| which started at |start|, without its usage.
+func newRequestMetrics(req *http.Request, rc *requestConfig, start time.Time, resp *http.Response, err error) *RequestMetrics {
+	var m = &RequestMetrics{
+		Endpoint: rc.route,
+		Method:   req.Method,
+		Model:    rc.model,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+
+	var apiErr *APIError
+	switch {
+	case resp != nil:
+		m.StatusCode = resp.StatusCode
+		m.RequestID = resp.Header.Get(requestIDHeader)
+	case errors.As(err, &apiErr):
+		m.StatusCode = apiErr.StatusCode
+		m.RequestID = apiErr.RequestID
+	}
+
+	return m
+}
+
+// reportMetrics reports |m| to the Client's MetricsRecorder, logger, and UsageTracker, if any.
+func (c *Client) reportMetrics(req *http.Request, rc *requestConfig, m *RequestMetrics) {
+	if c.usage != nil {
+		c.usage.Record(m.Model, rc.user, m.Usage, m.Cost)
+	}
+
+	if c.metrics != nil {
+		c.metrics.RecordRequest(req.Context(), m)
+	}
+
+	if c.logger != nil {
+		c.logger.logRequest(req, m)
+	}
+}
+
+// streamMetrics reports the metrics of a streamed response once, when the stream reports its usage or its body is
+// closed, whichever comes first, so that the usage of streams which include it is recorded like that of other
+// responses.
+type streamMetrics struct {
+	once sync.Once
+	c    *Client
+	req  *http.Request
+	rc   *requestConfig
+	m    *RequestMetrics
+}
+
+// report reports the metrics of the stream with the token usage |u|, which is nil if the stream did not report it.
+func (sm *streamMetrics) report(u *Usage) {
+	sm.once.Do(func() {
+		if u != nil {
+			sm.m.Usage = u
+			if v, ok := sm.c.pricing.Cost(sm.m.Model, u); ok {
+				sm.m.Cost = &v
+			}
+		}
+		sm.c.reportMetrics(sm.req, sm.rc, sm.m)
+	})
+}
+
+// routePath returns |route| without its query string.
+func routePath(route string) string {
+	var p, _, _ = strings.Cut(route, "?")
+	return p
+}
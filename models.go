@@ -0,0 +1,88 @@
+package openai
+
+import (
+	"context"
+	"path"
+
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+// Model describes a model available for use with the API.
+type Model struct {
+	ResponseMeta
+
+	// ID is the model identifier, which can be referenced in the API endpoints.
+	ID     string         `json:"id"`
+	Object objects.Object `json:"object"`
+	// Created is the Unix timestamp (in seconds) when the model was created.
+	Created uint64 `json:"created"`
+	// OwnedBy is the organization that owns the model.
+	OwnedBy    string             `json:"owned_by"`
+	Permission []*ModelPermission `json:"permission,omitempty"`
+	Root       string             `json:"root,omitempty"`
+	Parent     *string            `json:"parent,omitempty"`
+}
+
+// ModelPermission describes the permissions granted on a Model.
+type ModelPermission struct {
+	ID                 string         `json:"id"`
+	Object             objects.Object `json:"object"`
+	Created            uint64         `json:"created"`
+	AllowCreateEngine  bool           `json:"allow_create_engine"`
+	AllowSampling      bool           `json:"allow_sampling"`
+	AllowLogprobs      bool           `json:"allow_logprobs"`
+	AllowSearchIndices bool           `json:"allow_search_indices"`
+	AllowView          bool           `json:"allow_view"`
+	AllowFineTuning    bool           `json:"allow_fine_tuning"`
+	Organization       string         `json:"organization"`
+	Group              *string        `json:"group"`
+	IsBlocking         bool           `json:"is_blocking"`
+}
+
+// ListModels lists the currently available models, and provides basic information about each one such as the owner
+// and availability.
+func (c *Client) ListModels(ctx context.Context, opts ...RequestOption) (*List[*Model], error) {
+	var res, err = c.get(ctx, routes.Models, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*Model]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveModel retrieves a model instance, providing basic information about the model such as the owner and
+// permissioning.
+func (c *Client) RetrieveModel(ctx context.Context, id string, opts ...RequestOption) (*Model, error) {
+	var res, err = c.get(ctx, path.Join(routes.Models, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var m = &Model{}
+	if err = res.decode(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DeleteModel deletes a fine-tuned model. You must have the Owner role in your organization to delete a model.
+func (c *Client) DeleteModel(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.Models, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
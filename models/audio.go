@@ -0,0 +1,43 @@
+package models
+
+// Audio represents all models available for use with the audio endpoints.
+type Audio int
+
+const (
+	// UnknownAudio represents and invalid Audio model.
+	UnknownAudio Audio = iota
+	// Whisper1 is a general-purpose speech recognition model. It is trained on a large dataset of diverse audio and
+	// can perform multilingual speech recognition as well as speech translation and language identification.
+	Whisper1
+)
+
+// String implements the fmt.Stringer interface.
+func (a Audio) String() string {
+	return audioToString[a]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a Audio) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to Unknown.
+func (a *Audio) UnmarshalText(b []byte) error {
+	if val, ok := stringToAudio[(string(b))]; ok {
+		*a = val
+		return nil
+	}
+
+	*a = UnknownAudio
+
+	return nil
+}
+
+var audioToString = map[Audio]string{
+	Whisper1: "whisper-1",
+}
+
+var stringToAudio = map[string]Audio{
+	"whisper-1": Whisper1,
+}
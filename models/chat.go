@@ -0,0 +1,93 @@
+package models
+
+// Chat represents all models available for use with the chat based endpoints (e.g. Assistants).
+type Chat int
+
+const (
+	// UnknownChat represents and invalid Chat model.
+	UnknownChat Chat = iota
+	// GPT4o is OpenAI's high-intelligence flagship model for complex, multi-step tasks.
+	//
+	// Supports up to 128,000 tokens.
+	GPT4o
+	// GPT4oMini is OpenAI's affordable and intelligent small model for fast, lightweight tasks.
+	//
+	// Supports up to 128,000 tokens.
+	GPT4oMini
+	// GPT4Turbo is the latest GPT-4 Turbo model with vision capabilities.
+	//
+	// Supports up to 128,000 tokens.
+	GPT4Turbo
+	// GPT4 is the original GPT-4 model.
+	//
+	// Supports up to 8,192 tokens.
+	GPT4
+	// GPT35Turbo is a fast, inexpensive model for simple tasks.
+	//
+	// Supports up to 16,385 tokens.
+	GPT35Turbo
+	// O1 is a reasoning model trained to think before it answers, for complex, multi-step problems.
+	//
+	// Supports up to 200,000 tokens.
+	O1
+	// O1Mini is a fast and affordable reasoning model, specialized in coding, math, and science.
+	//
+	// Supports up to 128,000 tokens.
+	O1Mini
+	// O3Mini is a small reasoning model, with support for tools and structured outputs.
+	//
+	// Supports up to 200,000 tokens.
+	O3Mini
+	// GPT4oAudioPreview is a GPT-4o model which accepts audio inputs and generates audio outputs in chat
+	// completions.
+	//
+	// Supports up to 128,000 tokens.
+	GPT4oAudioPreview
+)
+
+// String implements the fmt.Stringer interface.
+func (c Chat) String() string {
+	return chatToString[c]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Chat) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to Unknown.
+func (c *Chat) UnmarshalText(b []byte) error {
+	if val, ok := stringToChat[(string(b))]; ok {
+		*c = val
+		return nil
+	}
+
+	*c = UnknownChat
+
+	return nil
+}
+
+var chatToString = map[Chat]string{
+	GPT4o:             "gpt-4o",
+	GPT4oMini:         "gpt-4o-mini",
+	GPT4Turbo:         "gpt-4-turbo",
+	GPT4:              "gpt-4",
+	GPT35Turbo:        "gpt-3.5-turbo",
+	O1:                "o1",
+	O1Mini:            "o1-mini",
+	O3Mini:            "o3-mini",
+	GPT4oAudioPreview: "gpt-4o-audio-preview",
+}
+
+var stringToChat = map[string]Chat{
+	"gpt-4o":               GPT4o,
+	"gpt-4o-mini":          GPT4oMini,
+	"gpt-4-turbo":          GPT4Turbo,
+	"gpt-4":                GPT4,
+	"gpt-3.5-turbo":        GPT35Turbo,
+	"o1":                   O1,
+	"o1-mini":              O1Mini,
+	"o3-mini":              O3Mini,
+	"gpt-4o-audio-preview": GPT4oAudioPreview,
+}
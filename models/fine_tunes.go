@@ -19,6 +19,19 @@ const (
 	//  GPT-3 series, and lowest cost. It is an older version of the GPT-3
 	// models and is intended to be used with the fine-tuning endpoints.
 	Ada
+
+	// Babbage002 is the replacement for the GPT-3 ada and babbage base models, for use with the fine-tuning jobs
+	// endpoints.
+	Babbage002
+	// Davinci002 is the replacement for the GPT-3 curie and davinci base models, for use with the fine-tuning jobs
+	// endpoints.
+	Davinci002
+	// GPT35Turbo0125 is a snapshot of the GPT-3.5 Turbo model, for use with the fine-tuning jobs endpoints.
+	GPT35Turbo0125
+	// GPT4oMini20240718 is a snapshot of the GPT-4o mini model, for use with the fine-tuning jobs endpoints.
+	GPT4oMini20240718
+	// GPT4o20240806 is a snapshot of the GPT-4o model, for use with the fine-tuning jobs endpoints.
+	GPT4o20240806
 )
 
 // String implements the fmt.Stringer interface.
@@ -49,6 +62,12 @@ var fineTuneToString = map[FineTune]string{
 	Curie:   "curie",
 	Ada:     "ada",
 	Babbage: "babbage",
+
+	Babbage002:        "babbage-002",
+	Davinci002:        "davinci-002",
+	GPT35Turbo0125:    "gpt-3.5-turbo-0125",
+	GPT4oMini20240718: "gpt-4o-mini-2024-07-18",
+	GPT4o20240806:     "gpt-4o-2024-08-06",
 }
 
 var stringToFineTune = map[string]FineTune{
@@ -56,6 +75,12 @@ var stringToFineTune = map[string]FineTune{
 	"curie":   Curie,
 	"ada":     Ada,
 	"babbage": Babbage,
+
+	"babbage-002":            Babbage002,
+	"davinci-002":            Davinci002,
+	"gpt-3.5-turbo-0125":     GPT35Turbo0125,
+	"gpt-4o-mini-2024-07-18": GPT4oMini20240718,
+	"gpt-4o-2024-08-06":      GPT4o20240806,
 }
 
 // FineTunedModel represents the name of a fine-tuned model which was
@@ -0,0 +1,51 @@
+package models
+
+// Image represents all models available for use with the images endpoints.
+type Image int
+
+const (
+	// UnknownImage represents an invalid Image model.
+	UnknownImage Image = iota
+	// DALLE2 is the second generation DALL·E model. It is the only model which supports image variations.
+	DALLE2
+	// DALLE3 is the third generation DALL·E model, which generates higher quality images from longer prompts.
+	DALLE3
+	// GPTImage1 is a natively multimodal image generation model, which supports transparent backgrounds and follows
+	// instructions more closely.
+	GPTImage1
+)
+
+// String implements the fmt.Stringer interface.
+func (i Image) String() string {
+	return imageToString[i]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (i Image) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to Unknown.
+func (i *Image) UnmarshalText(b []byte) error {
+	if val, ok := stringToImage[(string(b))]; ok {
+		*i = val
+		return nil
+	}
+
+	*i = UnknownImage
+
+	return nil
+}
+
+var imageToString = map[Image]string{
+	DALLE2:    "dall-e-2",
+	DALLE3:    "dall-e-3",
+	GPTImage1: "gpt-image-1",
+}
+
+var stringToImage = map[string]Image{
+	"dall-e-2":    DALLE2,
+	"dall-e-3":    DALLE3,
+	"gpt-image-1": GPTImage1,
+}
@@ -0,0 +1,46 @@
+package models
+
+// Speech represents all models available for use with the audio/speech endpoint.
+type Speech int
+
+const (
+	// UnknownSpeech represents and invalid Speech model.
+	UnknownSpeech Speech = iota
+	// TTS1 is the latest text to speech model, optimized for speed.
+	TTS1
+	// TTS1HD is the latest text to speech model, optimized for quality.
+	TTS1HD
+)
+
+// String implements the fmt.Stringer interface.
+func (s Speech) String() string {
+	return speechToString[s]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Speech) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to Unknown.
+func (s *Speech) UnmarshalText(b []byte) error {
+	if val, ok := stringToSpeech[(string(b))]; ok {
+		*s = val
+		return nil
+	}
+
+	*s = UnknownSpeech
+
+	return nil
+}
+
+var speechToString = map[Speech]string{
+	TTS1:   "tts-1",
+	TTS1HD: "tts-1-hd",
+}
+
+var stringToSpeech = map[string]Speech{
+	"tts-1":    TTS1,
+	"tts-1-hd": TTS1HD,
+}
@@ -2,22 +2,28 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/fabiustech/openai/models"
-
 	"github.com/fabiustech/openai/routes"
 )
 
 // ModerationRequest contains all relevant fields for requests to the moderations endpoint.
 type ModerationRequest struct {
-	// Input is the input text to classify.
-	Input string `json:"input,omitempty"`
+	// Input is the text to classify. To classify multiple inputs in a single request, pass a slice of length > 1; the
+	// results are returned in the same order.
+	Input []string `json:"input"`
 	// Model specifies the model to use for moderation.
 	// Defaults to models.TextModerationLatest.
 	Model models.Moderation `json:"model,omitempty"`
 }
 
+// model returns the model of the request (see WithModelRoute).
+func (mr *ModerationRequest) model() string {
+	return mr.Model.String()
+}
+
 // Result represents one of possible moderation results.
 type Result struct {
 	Categories     *ResultCategories     `json:"categories"`
@@ -36,6 +42,33 @@ type ResultCategories struct {
 	ViolenceGraphic bool `json:"violence/graphic"`
 }
 
+// Flagged returns the names of the categories which are flagged in |c| (e.g. "hate/threatening"), as used by the API.
+func (c *ResultCategories) Flagged() []string {
+	if c == nil {
+		return nil
+	}
+
+	var categories []string
+	for _, cat := range []struct {
+		name    string
+		flagged bool
+	}{
+		{"hate", c.Hate},
+		{"hate/threatening", c.HateThreatening},
+		{"self-harm", c.SelfHarm},
+		{"sexual", c.Sexual},
+		{"sexual/minors", c.SexualMinors},
+		{"violence", c.Violence},
+		{"violence/graphic", c.ViolenceGraphic},
+	} {
+		if cat.flagged {
+			categories = append(categories, cat.name)
+		}
+	}
+
+	return categories
+}
+
 // ResultCategoryScores represents CategoryScores of Result.
 type ResultCategoryScores struct {
 	Hate            float32 `json:"hate"`
@@ -49,22 +82,55 @@ type ResultCategoryScores struct {
 
 // ModerationResponse represents a response structure for moderation API.
 type ModerationResponse struct {
+	ResponseMeta
+
 	ID      string   `json:"id"`
 	Model   string   `json:"model"`
 	Results []Result `json:"results"`
 }
 
-// CreateModeration classifies if text violates OpenAI's Content Policy.
-func (c *Client) CreateModeration(ctx context.Context, mr *ModerationRequest) (*ModerationResponse, error) {
-	var b, err = c.post(ctx, routes.Moderations, mr)
+// FlaggedInput is an input of a ModerationRequest which was flagged.
+type FlaggedInput struct {
+	// Index is the index of the input in ModerationRequest.Input (and of its result in ModerationResponse.Results).
+	Index int
+	// Categories are the names of the categories the input was flagged for (see ResultCategories.Flagged).
+	Categories []string
+}
+
+// Flagged returns the inputs which were flagged, in order, along with the categories they were flagged for. It returns
+// nil if no input was flagged.
+func (r *ModerationResponse) Flagged() []*FlaggedInput {
+	var flagged []*FlaggedInput
+	for i, res := range r.Results {
+		if res.Flagged {
+			flagged = append(flagged, &FlaggedInput{Index: i, Categories: res.Categories.Flagged()})
+		}
+	}
+
+	return flagged
+}
+
+// CreateModeration classifies if text violates OpenAI's Content Policy. The response contains one result for each
+// input of |mr|, in the same order.
+func (c *Client) CreateModeration(ctx context.Context, mr *ModerationRequest, opts ...RequestOption) (*ModerationResponse, error) {
+	if len(mr.Input) == 0 {
+		return nil, errors.New("openai: at least one moderation input is required")
+	}
+
+	var res, err = c.post(ctx, routes.Moderations, mr, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp = &ModerationResponse{}
-	if err = json.Unmarshal(b, resp); err != nil {
+	if err = res.decode(resp); err != nil {
 		return nil, err
 	}
 
+	// The results are matched to the inputs by position, which is only possible if there is one for each.
+	if len(resp.Results) != len(mr.Input) {
+		return nil, fmt.Errorf("openai: moderation returned %d results for %d inputs", len(resp.Results), len(mr.Input))
+	}
+
 	return resp, nil
 }
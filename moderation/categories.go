@@ -0,0 +1,70 @@
+// Package moderation contains the enum values which represent the categories
+// of the moderations endpoint and the decisions of a moderation Policy.
+package moderation
+
+// Category represents the enum values for the categories the moderations endpoint classifies text into.
+type Category int
+
+const (
+	// CategoryInvalid represents an invalid Category option.
+	CategoryInvalid Category = iota
+	// CategoryHate is content that expresses, incites, or promotes hate based on a protected attribute.
+	CategoryHate
+	// CategoryHateThreatening is hateful content that also includes violence or serious harm towards the targeted
+	// group.
+	CategoryHateThreatening
+	// CategorySelfHarm is content that promotes, encourages, or depicts acts of self-harm.
+	CategorySelfHarm
+	// CategorySexual is content meant to arouse sexual excitement, or that promotes sexual services.
+	CategorySexual
+	// CategorySexualMinors is sexual content that includes an individual who is under 18 years old.
+	CategorySexualMinors
+	// CategoryViolence is content that promotes or glorifies violence, or celebrates the suffering of others.
+	CategoryViolence
+	// CategoryViolenceGraphic is violent content that depicts death, violence, or serious physical injury in extreme
+	// graphic detail.
+	CategoryViolenceGraphic
+)
+
+// String implements the fmt.Stringer interface.
+func (c Category) String() string {
+	return categoryToString[c]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |c| to CategoryInvalid.
+func (c *Category) UnmarshalText(b []byte) error {
+	if val, ok := stringToCategory[(string(b))]; ok {
+		*c = val
+		return nil
+	}
+
+	*c = CategoryInvalid
+
+	return nil
+}
+
+var categoryToString = map[Category]string{
+	CategoryHate:            "hate",
+	CategoryHateThreatening: "hate/threatening",
+	CategorySelfHarm:        "self-harm",
+	CategorySexual:          "sexual",
+	CategorySexualMinors:    "sexual/minors",
+	CategoryViolence:        "violence",
+	CategoryViolenceGraphic: "violence/graphic",
+}
+
+var stringToCategory = map[string]Category{
+	"hate":             CategoryHate,
+	"hate/threatening": CategoryHateThreatening,
+	"self-harm":        CategorySelfHarm,
+	"sexual":           CategorySexual,
+	"sexual/minors":    CategorySexualMinors,
+	"violence":         CategoryViolence,
+	"violence/graphic": CategoryViolenceGraphic,
+}
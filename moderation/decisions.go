@@ -0,0 +1,51 @@
+package moderation
+
+// Decision represents the enum values for the verdict of a moderation Policy. Decisions are ordered by severity, so
+// the more severe of two decisions is the greater.
+type Decision int
+
+const (
+	// DecisionInvalid represents an invalid Decision option.
+	DecisionInvalid Decision = iota
+	// DecisionAllow means the content may be used.
+	DecisionAllow
+	// DecisionReview means the content should be reviewed (e.g. by a person) before it is used.
+	DecisionReview
+	// DecisionBlock means the content must not be used.
+	DecisionBlock
+)
+
+// String implements the fmt.Stringer interface.
+func (d Decision) String() string {
+	return decisionToString[d]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Decision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |d| to DecisionInvalid.
+func (d *Decision) UnmarshalText(b []byte) error {
+	if val, ok := stringToDecision[(string(b))]; ok {
+		*d = val
+		return nil
+	}
+
+	*d = DecisionInvalid
+
+	return nil
+}
+
+var decisionToString = map[Decision]string{
+	DecisionAllow:  "allow",
+	DecisionReview: "review",
+	DecisionBlock:  "block",
+}
+
+var stringToDecision = map[string]Decision{
+	"allow":  DecisionAllow,
+	"review": DecisionReview,
+	"block":  DecisionBlock,
+}
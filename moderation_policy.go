@@ -0,0 +1,116 @@
+package openai
+
+import (
+	"github.com/fabiustech/openai/moderation"
+)
+
+// moderationCategories contains every category of the moderations endpoint, in the order they are reported.
+var moderationCategories = []moderation.Category{
+	moderation.CategoryHate,
+	moderation.CategoryHateThreatening,
+	moderation.CategorySelfHarm,
+	moderation.CategorySexual,
+	moderation.CategorySexualMinors,
+	moderation.CategoryViolence,
+	moderation.CategoryViolenceGraphic,
+}
+
+// Score returns the score of |category| in |s|, or 0 if it is not a valid category.
+func (s *ResultCategoryScores) Score(category moderation.Category) float32 {
+	if s == nil {
+		return 0
+	}
+
+	switch category {
+	case moderation.CategoryHate:
+		return s.Hate
+	case moderation.CategoryHateThreatening:
+		return s.HateThreatening
+	case moderation.CategorySelfHarm:
+		return s.SelfHarm
+	case moderation.CategorySexual:
+		return s.Sexual
+	case moderation.CategorySexualMinors:
+		return s.SexualMinors
+	case moderation.CategoryViolence:
+		return s.Violence
+	case moderation.CategoryViolenceGraphic:
+		return s.ViolenceGraphic
+	default:
+		return 0
+	}
+}
+
+// Threshold contains the scores of a category at which a Policy reviews or blocks content. A threshold of 0 is not
+// applied, so that a category can be reviewed but never blocked, or vice versa.
+type Threshold struct {
+	// Review is the minimum score for which content is reviewed.
+	Review float32
+	// Block is the minimum score for which content is blocked. It takes precedence over Review.
+	Block float32
+}
+
+// Policy decides whether content is allowed, reviewed, or blocked from the category scores of its moderation Result,
+// rather than the Flagged field, whose thresholds are set by OpenAI and may change.
+type Policy struct {
+	// Default is the threshold of the categories which are not in Categories.
+	Default Threshold
+	// Categories contains the thresholds of specific categories, which override Default.
+	Categories map[moderation.Category]Threshold
+}
+
+// Verdict is the decision of a Policy about a moderation Result.
+type Verdict struct {
+	Decision moderation.Decision
+	// Categories are the categories whose scores reached the threshold of the Decision, in order. It is empty if the
+	// content is allowed.
+	Categories []moderation.Category
+}
+
+// threshold returns the threshold of |category|.
+func (p *Policy) threshold(category moderation.Category) Threshold {
+	if t, ok := p.Categories[category]; ok {
+		return t
+	}
+
+	return p.Default
+}
+
+// Evaluate returns the verdict of |p| about |r|: content is blocked if the score of any category reaches its Block
+// threshold, otherwise reviewed if the score of any category reaches its Review threshold, and otherwise allowed.
+func (p *Policy) Evaluate(r *Result) *Verdict {
+	var v = &Verdict{Decision: moderation.DecisionAllow}
+	for _, category := range moderationCategories {
+		var t, score = p.threshold(category), r.CategoryScores.Score(category)
+
+		var d moderation.Decision
+		switch {
+		case t.Block > 0 && score >= t.Block:
+			d = moderation.DecisionBlock
+		case t.Review > 0 && score >= t.Review:
+			d = moderation.DecisionReview
+		default:
+			continue
+		}
+
+		// Only the categories which caused the most severe decision are reported.
+		if d > v.Decision {
+			v.Decision, v.Categories = d, nil
+		}
+		if d == v.Decision {
+			v.Categories = append(v.Categories, category)
+		}
+	}
+
+	return v
+}
+
+// EvaluateAll returns the verdicts of |p| about each result of |resp|, in the order of the inputs of the request.
+func (p *Policy) EvaluateAll(resp *ModerationResponse) []*Verdict {
+	var verdicts = make([]*Verdict, len(resp.Results))
+	for i := range resp.Results {
+		verdicts[i] = p.Evaluate(&resp.Results[i])
+	}
+
+	return verdicts
+}
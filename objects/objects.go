@@ -28,6 +28,68 @@ const (
 	// Engine represents an engine.
 	// Deprecated: use Model instead.
 	Engine
+	// FineTuningJob is a fine-tuning job.
+	FineTuningJob
+	// FineTuningJobEvent is a status update for a fine-tuning job.
+	FineTuningJobEvent
+	// ModelPermission is a permission granted on a model.
+	ModelPermission
+	// Assistant is an assistant.
+	Assistant
+	// AssistantDeleted is a deleted assistant.
+	AssistantDeleted
+	// VectorStore is a vector store.
+	VectorStore
+	// VectorStoreDeleted is a deleted vector store.
+	VectorStoreDeleted
+	// VectorStoreFile is a file attached to a vector store.
+	VectorStoreFile
+	// VectorStoreFileDeleted is a file removed from a vector store.
+	VectorStoreFileDeleted
+	// VectorStoreFileBatch is a batch of files attached to a vector store.
+	VectorStoreFileBatch
+	// Upload is a multipart file upload.
+	Upload
+	// UploadPart is a part of a multipart file upload.
+	UploadPart
+	// Page is a page of results from an organization endpoint.
+	Page
+	// Bucket is a time bucket of organization usage or costs.
+	Bucket
+	// CostsResult is an aggregated costs result.
+	CostsResult
+	// Project is a project within an organization.
+	Project
+	// ProjectUser is a user within a project.
+	ProjectUser
+	// ProjectUserDeleted is a user removed from a project.
+	ProjectUserDeleted
+	// Invite is an invite to join an organization.
+	Invite
+	// InviteDeleted is a deleted invite.
+	InviteDeleted
+	// ProjectServiceAccount is a service account within a project.
+	ProjectServiceAccount
+	// ProjectServiceAccountDeleted is a deleted service account.
+	ProjectServiceAccountDeleted
+	// ProjectServiceAccountAPIKey is the API key of a newly created service account.
+	ProjectServiceAccountAPIKey
+	// ProjectAPIKey is an API key within a project.
+	ProjectAPIKey
+	// ProjectAPIKeyDeleted is a deleted project API key.
+	ProjectAPIKeyDeleted
+	// ChatCompletion is a chat completion.
+	ChatCompletion
+	// ChatCompletionChunk is a chunk of a streamed chat completion.
+	ChatCompletionChunk
+	// ChatCompletionDeleted is a deleted stored chat completion.
+	ChatCompletionDeleted
+	// FineTuningJobCheckpoint is a checkpoint of a fine-tuning job.
+	FineTuningJobCheckpoint
+	// CheckpointPermission is a permission granted on a fine-tuned model checkpoint.
+	CheckpointPermission
+	// Batch is a batch of API requests.
+	Batch
 )
 
 // String implements the fmt.Stringer interface.
@@ -54,27 +116,89 @@ func (o *Object) UnmarshalText(b []byte) error {
 }
 
 var objectToString = map[Object]string{
-	Model:          "model",
-	List:           "list",
-	TextCompletion: "text_completion",
-	CodeCompletion: "code_completion",
-	Edit:           "edit",
-	Embedding:      "embedding",
-	File:           "file",
-	FineTune:       "fine-tune",
-	FineTimeEvent:  "fine-tune-event",
-	Engine:         "engine",
+	Model:                        "model",
+	List:                         "list",
+	TextCompletion:               "text_completion",
+	CodeCompletion:               "code_completion",
+	Edit:                         "edit",
+	Embedding:                    "embedding",
+	File:                         "file",
+	FineTune:                     "fine-tune",
+	FineTimeEvent:                "fine-tune-event",
+	Engine:                       "engine",
+	FineTuningJob:                "fine_tuning.job",
+	FineTuningJobEvent:           "fine_tuning.job.event",
+	ModelPermission:              "model_permission",
+	Assistant:                    "assistant",
+	AssistantDeleted:             "assistant.deleted",
+	VectorStore:                  "vector_store",
+	VectorStoreDeleted:           "vector_store.deleted",
+	VectorStoreFile:              "vector_store.file",
+	VectorStoreFileDeleted:       "vector_store.file.deleted",
+	VectorStoreFileBatch:         "vector_store.files_batch",
+	Upload:                       "upload",
+	UploadPart:                   "upload.part",
+	Page:                         "page",
+	Bucket:                       "bucket",
+	CostsResult:                  "organization.costs.result",
+	Project:                      "organization.project",
+	ProjectUser:                  "organization.project.user",
+	ProjectUserDeleted:           "organization.project.user.deleted",
+	Invite:                       "organization.invite",
+	InviteDeleted:                "organization.invite.deleted",
+	ProjectServiceAccount:        "organization.project.service_account",
+	ProjectServiceAccountDeleted: "organization.project.service_account.deleted",
+	ProjectServiceAccountAPIKey:  "organization.project.service_account.api_key",
+	ProjectAPIKey:                "organization.project.api_key",
+	ProjectAPIKeyDeleted:         "organization.project.api_key.deleted",
+	ChatCompletion:               "chat.completion",
+	ChatCompletionChunk:          "chat.completion.chunk",
+	ChatCompletionDeleted:        "chat.completion.deleted",
+	FineTuningJobCheckpoint:      "fine_tuning.job.checkpoint",
+	CheckpointPermission:         "checkpoint.permission",
+	Batch:                        "batch",
 }
 
 var stringToObject = map[string]Object{
-	"model":           Model,
-	"list":            List,
-	"text_completion": TextCompletion,
-	"code_completion": CodeCompletion,
-	"edit":            Edit,
-	"embedding":       Embedding,
-	"file":            File,
-	"fine-tune":       FineTune,
-	"fine-tune-event": FineTimeEvent,
-	"engine":          Engine,
+	"model":                                Model,
+	"list":                                 List,
+	"text_completion":                      TextCompletion,
+	"code_completion":                      CodeCompletion,
+	"edit":                                 Edit,
+	"embedding":                            Embedding,
+	"file":                                 File,
+	"fine-tune":                            FineTune,
+	"fine-tune-event":                      FineTimeEvent,
+	"engine":                               Engine,
+	"fine_tuning.job":                      FineTuningJob,
+	"fine_tuning.job.event":                FineTuningJobEvent,
+	"model_permission":                     ModelPermission,
+	"assistant":                            Assistant,
+	"assistant.deleted":                    AssistantDeleted,
+	"vector_store":                         VectorStore,
+	"vector_store.deleted":                 VectorStoreDeleted,
+	"vector_store.file":                    VectorStoreFile,
+	"vector_store.file.deleted":            VectorStoreFileDeleted,
+	"vector_store.files_batch":             VectorStoreFileBatch,
+	"upload":                               Upload,
+	"upload.part":                          UploadPart,
+	"page":                                 Page,
+	"bucket":                               Bucket,
+	"organization.costs.result":            CostsResult,
+	"organization.project":                 Project,
+	"organization.project.user":            ProjectUser,
+	"organization.project.user.deleted":    ProjectUserDeleted,
+	"organization.invite":                  Invite,
+	"organization.invite.deleted":          InviteDeleted,
+	"organization.project.service_account": ProjectServiceAccount,
+	"organization.project.service_account.deleted": ProjectServiceAccountDeleted,
+	"organization.project.service_account.api_key": ProjectServiceAccountAPIKey,
+	"organization.project.api_key":                 ProjectAPIKey,
+	"organization.project.api_key.deleted":         ProjectAPIKeyDeleted,
+	"chat.completion":                              ChatCompletion,
+	"chat.completion.chunk":                        ChatCompletionChunk,
+	"chat.completion.deleted":                      ChatCompletionDeleted,
+	"fine_tuning.job.checkpoint":                   FineTuningJobCheckpoint,
+	"checkpoint.permission":                        CheckpointPermission,
+	"batch":                                        Batch,
 }
@@ -0,0 +1,6 @@
+// Package openaimock provides a mock implementation of the openai.API interface for use in tests.
+//
+// The mock is generated from the interface; run go generate after adding methods to the openai package.
+package openaimock
+
+//go:generate go run ./internal/mockgen -src ../api.go -out mock.go
@@ -0,0 +1,197 @@
+// Command mockgen generates the openaimock.Client mock from the openai.API interface. It is run by go generate.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const openaiImport = "github.com/fabiustech/openai"
+
+func main() {
+	var src = flag.String("src", "../api.go", "the file which declares the openai.API interface")
+	var out = flag.String("out", "mock.go", "the file to write the mock to")
+	flag.Parse()
+
+	var b, err = generate(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile(*out, b, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of a mock implementing every method of the interfaces declared in |src|.
+func generate(src string) ([]byte, error) {
+	var fset = token.NewFileSet()
+	var f, err = parser.ParseFile(fset, src, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the names of imported packages to their paths, so that the mock imports exactly what it uses.
+	var paths = map[string]string{"openai": openaiImport}
+	for _, imp := range f.Imports {
+		var p = strings.Trim(imp.Path.Value, `"`)
+		paths[p[strings.LastIndex(p, "/")+1:]] = p
+	}
+
+	var used = map[string]bool{"openai": true}
+	var methods []*ast.Field
+	ast.Inspect(f, func(n ast.Node) bool {
+		if it, ok := n.(*ast.InterfaceType); ok {
+			for _, m := range it.Methods.List {
+				if _, ok := m.Type.(*ast.FuncType); ok {
+					methods = append(methods, m)
+				}
+			}
+		}
+		return true
+	})
+
+	var fields, funcs bytes.Buffer
+	for _, m := range methods {
+		var name = m.Names[0].Name
+		var ft = qualify(m.Type, used).(*ast.FuncType)
+		var sig = strings.TrimPrefix(exprString(fset, ft), "func")
+
+		fmt.Fprintf(&fields, "\t// %sFunc is called by %s, if set.\n\t%sFunc func%s\n", name, name, name, sig)
+
+		fmt.Fprintf(&funcs, "\n// %s implements the openai.API interface.\n", name)
+		fmt.Fprintf(&funcs, "func (c *Client) %s%s %s {\n", name, params(fset, ft), results(fset, ft))
+		fmt.Fprintf(&funcs, "\tif c.%sFunc != nil {\n\t\treturn c.%sFunc(%s)\n\t}\n\treturn\n}\n", name, name, args(ft))
+	}
+
+	// Group the standard library imports before all others.
+	var std, other []string
+	for pkg := range used {
+		var p = fmt.Sprintf("%q", paths[pkg])
+		if strings.Contains(p, ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	var imports = append(std, "")
+	imports = append(imports, other...)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package openaimock\n\nimport (\n\t%s\n)\n\n", strings.Join(imports, "\n\t"))
+	fmt.Fprintf(&buf, "var _ openai.API = (*Client)(nil)\n\n")
+	fmt.Fprintf(&buf, "// Client is a mock implementation of openai.API. Each method calls the corresponding Func field if it is set,\n")
+	fmt.Fprintf(&buf, "// and otherwise does nothing and returns zero values. The zero value is ready to use.\n")
+	fmt.Fprintf(&buf, "type Client struct {\n%s}\n%s", fields.String(), funcs.String())
+
+	return format.Source(buf.Bytes())
+}
+
+// qualify returns a copy of |e| in which identifiers declared in package openai are qualified with the package name.
+// The package of every qualified identifier is recorded in |used|.
+func qualify(e ast.Expr, used map[string]bool) ast.Expr {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return &ast.SelectorExpr{X: ast.NewIdent("openai"), Sel: ast.NewIdent(t.Name)}
+		}
+		return t
+	case *ast.SelectorExpr:
+		used[t.X.(*ast.Ident).Name] = true
+		return t
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(t.X, used)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualify(t.Elt, used)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(t.Key, used), Value: qualify(t.Value, used)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(t.Elt, used)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: qualify(t.X, used), Index: qualify(t.Index, used)}
+	case *ast.IndexListExpr:
+		var indices []ast.Expr
+		for _, i := range t.Indices {
+			indices = append(indices, qualify(i, used))
+		}
+		return &ast.IndexListExpr{X: qualify(t.X, used), Indices: indices}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(t.Params, used), Results: qualifyFields(t.Results, used)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: t.Dir, Value: qualify(t.Value, used)}
+	default:
+		return e
+	}
+}
+
+// qualifyFields applies qualify to the type of each field in |fl|.
+func qualifyFields(fl *ast.FieldList, used map[string]bool) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+
+	var out = &ast.FieldList{}
+	for _, f := range fl.List {
+		out.List = append(out.List, &ast.Field{Names: f.Names, Type: qualify(f.Type, used)})
+	}
+
+	return out
+}
+
+// params returns the parameter list of |ft|.
+func params(fset *token.FileSet, ft *ast.FuncType) string {
+	return exprString(fset, &ast.FuncType{Params: ft.Params})[len("func"):]
+}
+
+// results returns the result list of |ft| with every result named _, so that a bare return yields zero values.
+func results(fset *token.FileSet, ft *ast.FuncType) string {
+	if ft.Results == nil {
+		return ""
+	}
+
+	var fl = &ast.FieldList{}
+	for _, f := range ft.Results.List {
+		fl.List = append(fl.List, &ast.Field{Names: []*ast.Ident{ast.NewIdent("_")}, Type: f.Type})
+	}
+
+	return strings.TrimPrefix(exprString(fset, &ast.FuncType{Params: fl}), "func")
+}
+
+// args returns the arguments which forward the parameters of |ft| to another function of the same type.
+func args(ft *ast.FuncType) string {
+	var a []string
+	for _, f := range ft.Params.List {
+		for _, n := range f.Names {
+			if _, ok := f.Type.(*ast.Ellipsis); ok {
+				a = append(a, n.Name+"...")
+			} else {
+				a = append(a, n.Name)
+			}
+		}
+	}
+
+	return strings.Join(a, ", ")
+}
+
+// exprString returns the source of |n|.
+func exprString(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, n); err != nil {
+		log.Fatal(err)
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,948 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package openaimock
+
+import (
+	"context"
+	"io"
+
+	"github.com/fabiustech/openai"
+	"github.com/fabiustech/openai/models"
+)
+
+var _ openai.API = (*Client)(nil)
+
+// Client is a mock implementation of openai.API. Each method calls the corresponding Func field if it is set,
+// and otherwise does nothing and returns zero values. The zero value is ready to use.
+type Client struct {
+	// CreateProjectFunc is called by CreateProject, if set.
+	CreateProjectFunc func(ctx context.Context, pr *openai.ProjectRequest, opts ...openai.RequestOption) (*openai.Project, error)
+	// ListProjectsFunc is called by ListProjects, if set.
+	ListProjectsFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Project], error)
+	// RetrieveProjectFunc is called by RetrieveProject, if set.
+	RetrieveProjectFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Project, error)
+	// ModifyProjectFunc is called by ModifyProject, if set.
+	ModifyProjectFunc func(ctx context.Context, id string, pr *openai.ProjectRequest, opts ...openai.RequestOption) (*openai.Project, error)
+	// ArchiveProjectFunc is called by ArchiveProject, if set.
+	ArchiveProjectFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Project, error)
+	// ListProjectUsersFunc is called by ListProjectUsers, if set.
+	ListProjectUsersFunc func(ctx context.Context, projectID string, opts ...openai.RequestOption) (*openai.List[*openai.ProjectUser], error)
+	// CreateProjectUserFunc is called by CreateProjectUser, if set.
+	CreateProjectUserFunc func(ctx context.Context, projectID string, ur *openai.ProjectUserRequest, opts ...openai.RequestOption) (*openai.ProjectUser, error)
+	// RetrieveProjectUserFunc is called by RetrieveProjectUser, if set.
+	RetrieveProjectUserFunc func(ctx context.Context, projectID, userID string, opts ...openai.RequestOption) (*openai.ProjectUser, error)
+	// ModifyProjectUserFunc is called by ModifyProjectUser, if set.
+	ModifyProjectUserFunc func(ctx context.Context, projectID, userID string, ur *openai.ProjectUserRequest, opts ...openai.RequestOption) (*openai.ProjectUser, error)
+	// DeleteProjectUserFunc is called by DeleteProjectUser, if set.
+	DeleteProjectUserFunc func(ctx context.Context, projectID, userID string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// ListInvitesFunc is called by ListInvites, if set.
+	ListInvitesFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Invite], error)
+	// CreateInviteFunc is called by CreateInvite, if set.
+	CreateInviteFunc func(ctx context.Context, ir *openai.InviteRequest, opts ...openai.RequestOption) (*openai.Invite, error)
+	// RetrieveInviteFunc is called by RetrieveInvite, if set.
+	RetrieveInviteFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Invite, error)
+	// DeleteInviteFunc is called by DeleteInvite, if set.
+	DeleteInviteFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// ListServiceAccountsFunc is called by ListServiceAccounts, if set.
+	ListServiceAccountsFunc func(ctx context.Context, projectID string, opts ...openai.RequestOption) (*openai.List[*openai.ServiceAccount], error)
+	// CreateServiceAccountFunc is called by CreateServiceAccount, if set.
+	CreateServiceAccountFunc func(ctx context.Context, projectID string, sr *openai.ServiceAccountRequest, opts ...openai.RequestOption) (*openai.ServiceAccount, error)
+	// RetrieveServiceAccountFunc is called by RetrieveServiceAccount, if set.
+	RetrieveServiceAccountFunc func(ctx context.Context, projectID, serviceAccountID string, opts ...openai.RequestOption) (*openai.ServiceAccount, error)
+	// DeleteServiceAccountFunc is called by DeleteServiceAccount, if set.
+	DeleteServiceAccountFunc func(ctx context.Context, projectID, serviceAccountID string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// ListProjectAPIKeysFunc is called by ListProjectAPIKeys, if set.
+	ListProjectAPIKeysFunc func(ctx context.Context, projectID string, opts ...openai.RequestOption) (*openai.List[*openai.ProjectAPIKey], error)
+	// RetrieveProjectAPIKeyFunc is called by RetrieveProjectAPIKey, if set.
+	RetrieveProjectAPIKeyFunc func(ctx context.Context, projectID, keyID string, opts ...openai.RequestOption) (*openai.ProjectAPIKey, error)
+	// DeleteProjectAPIKeyFunc is called by DeleteProjectAPIKey, if set.
+	DeleteProjectAPIKeyFunc func(ctx context.Context, projectID, keyID string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// GetCostsFunc is called by GetCosts, if set.
+	GetCostsFunc func(ctx context.Context, cr *openai.CostsRequest, opts ...openai.RequestOption) (*openai.CostsPage, error)
+	// ListAuditLogsFunc is called by ListAuditLogs, if set.
+	ListAuditLogsFunc func(ctx context.Context, ar *openai.AuditLogsRequest, opts ...openai.RequestOption) (*openai.List[*openai.AuditLog], error)
+	// CreateAssistantFunc is called by CreateAssistant, if set.
+	CreateAssistantFunc func(ctx context.Context, ar *openai.AssistantRequest, opts ...openai.RequestOption) (*openai.Assistant, error)
+	// ListAssistantsFunc is called by ListAssistants, if set.
+	ListAssistantsFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Assistant], error)
+	// RetrieveAssistantFunc is called by RetrieveAssistant, if set.
+	RetrieveAssistantFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Assistant, error)
+	// ModifyAssistantFunc is called by ModifyAssistant, if set.
+	ModifyAssistantFunc func(ctx context.Context, id string, ar *openai.AssistantRequest, opts ...openai.RequestOption) (*openai.Assistant, error)
+	// DeleteAssistantFunc is called by DeleteAssistant, if set.
+	DeleteAssistantFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateTranscriptionFunc is called by CreateTranscription, if set.
+	CreateTranscriptionFunc func(ctx context.Context, tr *openai.TranscriptionRequest, opts ...openai.RequestOption) (*openai.TranscriptionResponse, error)
+	// CreateSpeechFunc is called by CreateSpeech, if set.
+	CreateSpeechFunc func(ctx context.Context, sr *openai.SpeechRequest, opts ...openai.RequestOption) (io.ReadCloser, error)
+	// CreateBatchFunc is called by CreateBatch, if set.
+	CreateBatchFunc func(ctx context.Context, br *openai.BatchRequest, opts ...openai.RequestOption) (*openai.Batch, error)
+	// RetrieveBatchFunc is called by RetrieveBatch, if set.
+	RetrieveBatchFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Batch, error)
+	// CancelBatchFunc is called by CancelBatch, if set.
+	CancelBatchFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Batch, error)
+	// ListBatchesFunc is called by ListBatches, if set.
+	ListBatchesFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Batch], error)
+	// CreateChatCompletionFunc is called by CreateChatCompletion, if set.
+	CreateChatCompletionFunc func(ctx context.Context, cr *openai.ChatCompletionRequest[models.Chat], opts ...openai.RequestOption) (*openai.ChatCompletionResponse[models.Chat], error)
+	// CreateFineTunedChatCompletionFunc is called by CreateFineTunedChatCompletion, if set.
+	CreateFineTunedChatCompletionFunc func(ctx context.Context, cr *openai.ChatCompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (*openai.ChatCompletionResponse[models.FineTunedModel], error)
+	// CreateChatCompletionStreamFunc is called by CreateChatCompletionStream, if set.
+	CreateChatCompletionStreamFunc func(ctx context.Context, cr *openai.ChatCompletionRequest[models.Chat], opts ...openai.RequestOption) (*openai.Stream[*openai.ChatCompletionChunk[models.Chat]], error)
+	// CreateFineTunedChatCompletionStreamFunc is called by CreateFineTunedChatCompletionStream, if set.
+	CreateFineTunedChatCompletionStreamFunc func(ctx context.Context, cr *openai.ChatCompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (*openai.Stream[*openai.ChatCompletionChunk[models.FineTunedModel]], error)
+	// RetrieveChatCompletionFunc is called by RetrieveChatCompletion, if set.
+	RetrieveChatCompletionFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.ChatCompletionResponse[models.Chat], error)
+	// ListChatCompletionsFunc is called by ListChatCompletions, if set.
+	ListChatCompletionsFunc func(ctx context.Context, lr *openai.ChatCompletionsListRequest, opts ...openai.RequestOption) (*openai.List[*openai.ChatCompletionResponse[models.Chat]], error)
+	// DeleteChatCompletionFunc is called by DeleteChatCompletion, if set.
+	DeleteChatCompletionFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateCompletionFunc is called by CreateCompletion, if set.
+	CreateCompletionFunc func(ctx context.Context, cr *openai.CompletionRequest[models.Completion], opts ...openai.RequestOption) (*openai.CompletionResponse[models.Completion], error)
+	// CreateFineTunedCompletionFunc is called by CreateFineTunedCompletion, if set.
+	CreateFineTunedCompletionFunc func(ctx context.Context, cr *openai.CompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (*openai.CompletionResponse[models.FineTunedModel], error)
+	// CreateEditFunc is called by CreateEdit, if set.
+	CreateEditFunc func(ctx context.Context, er *openai.EditsRequest, opts ...openai.RequestOption) (*openai.EditsResponse, error)
+	// CreateEmbeddingsFunc is called by CreateEmbeddings, if set.
+	CreateEmbeddingsFunc func(ctx context.Context, request *openai.EmbeddingRequest, opts ...openai.RequestOption) (*openai.EmbeddingResponse, error)
+	// ListEnginesFunc is called by ListEngines, if set.
+	ListEnginesFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Engine], error)
+	// GetEngineFunc is called by GetEngine, if set.
+	GetEngineFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Engine, error)
+	// ListFilesFunc is called by ListFiles, if set.
+	ListFilesFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.File], error)
+	// UploadFileFunc is called by UploadFile, if set.
+	UploadFileFunc func(ctx context.Context, fr *openai.FileRequest, opts ...openai.RequestOption) (*openai.File, error)
+	// DeleteFileFunc is called by DeleteFile, if set.
+	DeleteFileFunc func(ctx context.Context, id string, opts ...openai.RequestOption) error
+	// RetrieveFileFunc is called by RetrieveFile, if set.
+	RetrieveFileFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.File, error)
+	// GetFileContentFunc is called by GetFileContent, if set.
+	GetFileContentFunc func(ctx context.Context, id string, w io.Writer, opts ...openai.RequestOption) error
+	// CreateFineTuneFunc is called by CreateFineTune, if set.
+	CreateFineTuneFunc func(ctx context.Context, ftr *openai.FineTuneRequest, opts ...openai.RequestOption) (*openai.FineTuneResponse, error)
+	// ListFineTunesFunc is called by ListFineTunes, if set.
+	ListFineTunesFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.FineTuneResponse], error)
+	// RetrieveFineTuneFunc is called by RetrieveFineTune, if set.
+	RetrieveFineTuneFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.FineTuneResponse, error)
+	// CancelFineTuneFunc is called by CancelFineTune, if set.
+	CancelFineTuneFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.FineTuneResponse, error)
+	// ListFineTuneEventsFunc is called by ListFineTuneEvents, if set.
+	ListFineTuneEventsFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.List[*openai.Event], error)
+	// DeleteFineTuneFunc is called by DeleteFineTune, if set.
+	DeleteFineTuneFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.FineTuneDeletionResponse, error)
+	// CreateFineTuningJobFunc is called by CreateFineTuningJob, if set.
+	CreateFineTuningJobFunc func(ctx context.Context, fr *openai.FineTuningJobRequest, opts ...openai.RequestOption) (*openai.FineTuningJob, error)
+	// ListFineTuningJobsFunc is called by ListFineTuningJobs, if set.
+	ListFineTuningJobsFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.FineTuningJob], error)
+	// RetrieveFineTuningJobFunc is called by RetrieveFineTuningJob, if set.
+	RetrieveFineTuningJobFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.FineTuningJob, error)
+	// CancelFineTuningJobFunc is called by CancelFineTuningJob, if set.
+	CancelFineTuningJobFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.FineTuningJob, error)
+	// ListFineTuningEventsFunc is called by ListFineTuningEvents, if set.
+	ListFineTuningEventsFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.List[*openai.FineTuningJobEvent], error)
+	// StreamFineTuningEventsFunc is called by StreamFineTuningEvents, if set.
+	StreamFineTuningEventsFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Stream[*openai.FineTuningJobEvent], error)
+	// ListFineTuningCheckpointsFunc is called by ListFineTuningCheckpoints, if set.
+	ListFineTuningCheckpointsFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.List[*openai.FineTuningJobCheckpoint], error)
+	// CreateCheckpointPermissionsFunc is called by CreateCheckpointPermissions, if set.
+	CreateCheckpointPermissionsFunc func(ctx context.Context, checkpoint string, pr *openai.CheckpointPermissionRequest, opts ...openai.RequestOption) (*openai.List[*openai.CheckpointPermission], error)
+	// ListCheckpointPermissionsFunc is called by ListCheckpointPermissions, if set.
+	ListCheckpointPermissionsFunc func(ctx context.Context, checkpoint, projectID string, opts ...openai.RequestOption) (*openai.List[*openai.CheckpointPermission], error)
+	// DeleteCheckpointPermissionFunc is called by DeleteCheckpointPermission, if set.
+	DeleteCheckpointPermissionFunc func(ctx context.Context, checkpoint, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateImageFunc is called by CreateImage, if set.
+	CreateImageFunc func(ctx context.Context, ir *openai.CreateImageRequest, opts ...openai.RequestOption) (*openai.ImageResponse, error)
+	// EditImageFunc is called by EditImage, if set.
+	EditImageFunc func(ctx context.Context, eir *openai.EditImageRequest, opts ...openai.RequestOption) (*openai.ImageResponse, error)
+	// ImageVariationFunc is called by ImageVariation, if set.
+	ImageVariationFunc func(ctx context.Context, vir *openai.VariationImageRequest, opts ...openai.RequestOption) (*openai.ImageResponse, error)
+	// ListModelsFunc is called by ListModels, if set.
+	ListModelsFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.Model], error)
+	// RetrieveModelFunc is called by RetrieveModel, if set.
+	RetrieveModelFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.Model, error)
+	// DeleteModelFunc is called by DeleteModel, if set.
+	DeleteModelFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateModerationFunc is called by CreateModeration, if set.
+	CreateModerationFunc func(ctx context.Context, mr *openai.ModerationRequest, opts ...openai.RequestOption) (*openai.ModerationResponse, error)
+	// CreateUploadFunc is called by CreateUpload, if set.
+	CreateUploadFunc func(ctx context.Context, ur *openai.UploadRequest, opts ...openai.RequestOption) (*openai.Upload, error)
+	// AddUploadPartFunc is called by AddUploadPart, if set.
+	AddUploadPartFunc func(ctx context.Context, uploadID string, data io.Reader, opts ...openai.RequestOption) (*openai.UploadPart, error)
+	// UploadPartsFunc is called by UploadParts, if set.
+	UploadPartsFunc func(ctx context.Context, uploadID string, r io.Reader, opts *openai.UploadPartsOptions, reqOpts ...openai.RequestOption) ([]string, error)
+	// CompleteUploadFunc is called by CompleteUpload, if set.
+	CompleteUploadFunc func(ctx context.Context, uploadID string, cr *openai.CompleteUploadRequest, opts ...openai.RequestOption) (*openai.Upload, error)
+	// CancelUploadFunc is called by CancelUpload, if set.
+	CancelUploadFunc func(ctx context.Context, uploadID string, opts ...openai.RequestOption) (*openai.Upload, error)
+	// CreateVectorStoreFunc is called by CreateVectorStore, if set.
+	CreateVectorStoreFunc func(ctx context.Context, vr *openai.VectorStoreRequest, opts ...openai.RequestOption) (*openai.VectorStore, error)
+	// ListVectorStoresFunc is called by ListVectorStores, if set.
+	ListVectorStoresFunc func(ctx context.Context, opts ...openai.RequestOption) (*openai.List[*openai.VectorStore], error)
+	// RetrieveVectorStoreFunc is called by RetrieveVectorStore, if set.
+	RetrieveVectorStoreFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.VectorStore, error)
+	// ModifyVectorStoreFunc is called by ModifyVectorStore, if set.
+	ModifyVectorStoreFunc func(ctx context.Context, id string, vr *openai.VectorStoreRequest, opts ...openai.RequestOption) (*openai.VectorStore, error)
+	// DeleteVectorStoreFunc is called by DeleteVectorStore, if set.
+	DeleteVectorStoreFunc func(ctx context.Context, id string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateVectorStoreFileFunc is called by CreateVectorStoreFile, if set.
+	CreateVectorStoreFileFunc func(ctx context.Context, vectorStoreID string, fr *openai.VectorStoreFileRequest, opts ...openai.RequestOption) (*openai.VectorStoreFile, error)
+	// ListVectorStoreFilesFunc is called by ListVectorStoreFiles, if set.
+	ListVectorStoreFilesFunc func(ctx context.Context, vectorStoreID string, opts ...openai.RequestOption) (*openai.List[*openai.VectorStoreFile], error)
+	// RetrieveVectorStoreFileFunc is called by RetrieveVectorStoreFile, if set.
+	RetrieveVectorStoreFileFunc func(ctx context.Context, vectorStoreID, fileID string, opts ...openai.RequestOption) (*openai.VectorStoreFile, error)
+	// DeleteVectorStoreFileFunc is called by DeleteVectorStoreFile, if set.
+	DeleteVectorStoreFileFunc func(ctx context.Context, vectorStoreID, fileID string, opts ...openai.RequestOption) (*openai.DeletionResponse, error)
+	// CreateVectorStoreFileBatchFunc is called by CreateVectorStoreFileBatch, if set.
+	CreateVectorStoreFileBatchFunc func(ctx context.Context, vectorStoreID string, br *openai.VectorStoreFileBatchRequest, opts ...openai.RequestOption) (*openai.VectorStoreFileBatch, error)
+	// RetrieveVectorStoreFileBatchFunc is called by RetrieveVectorStoreFileBatch, if set.
+	RetrieveVectorStoreFileBatchFunc func(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (*openai.VectorStoreFileBatch, error)
+	// CancelVectorStoreFileBatchFunc is called by CancelVectorStoreFileBatch, if set.
+	CancelVectorStoreFileBatchFunc func(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (*openai.VectorStoreFileBatch, error)
+	// ListVectorStoreFileBatchFilesFunc is called by ListVectorStoreFileBatchFiles, if set.
+	ListVectorStoreFileBatchFilesFunc func(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (*openai.List[*openai.VectorStoreFile], error)
+}
+
+// CreateProject implements the openai.API interface.
+func (c *Client) CreateProject(ctx context.Context, pr *openai.ProjectRequest, opts ...openai.RequestOption) (_ *openai.Project, _ error) {
+	if c.CreateProjectFunc != nil {
+		return c.CreateProjectFunc(ctx, pr, opts...)
+	}
+	return
+}
+
+// ListProjects implements the openai.API interface.
+func (c *Client) ListProjects(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Project], _ error) {
+	if c.ListProjectsFunc != nil {
+		return c.ListProjectsFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveProject implements the openai.API interface.
+func (c *Client) RetrieveProject(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Project, _ error) {
+	if c.RetrieveProjectFunc != nil {
+		return c.RetrieveProjectFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ModifyProject implements the openai.API interface.
+func (c *Client) ModifyProject(ctx context.Context, id string, pr *openai.ProjectRequest, opts ...openai.RequestOption) (_ *openai.Project, _ error) {
+	if c.ModifyProjectFunc != nil {
+		return c.ModifyProjectFunc(ctx, id, pr, opts...)
+	}
+	return
+}
+
+// ArchiveProject implements the openai.API interface.
+func (c *Client) ArchiveProject(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Project, _ error) {
+	if c.ArchiveProjectFunc != nil {
+		return c.ArchiveProjectFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListProjectUsers implements the openai.API interface.
+func (c *Client) ListProjectUsers(ctx context.Context, projectID string, opts ...openai.RequestOption) (_ *openai.List[*openai.ProjectUser], _ error) {
+	if c.ListProjectUsersFunc != nil {
+		return c.ListProjectUsersFunc(ctx, projectID, opts...)
+	}
+	return
+}
+
+// CreateProjectUser implements the openai.API interface.
+func (c *Client) CreateProjectUser(ctx context.Context, projectID string, ur *openai.ProjectUserRequest, opts ...openai.RequestOption) (_ *openai.ProjectUser, _ error) {
+	if c.CreateProjectUserFunc != nil {
+		return c.CreateProjectUserFunc(ctx, projectID, ur, opts...)
+	}
+	return
+}
+
+// RetrieveProjectUser implements the openai.API interface.
+func (c *Client) RetrieveProjectUser(ctx context.Context, projectID, userID string, opts ...openai.RequestOption) (_ *openai.ProjectUser, _ error) {
+	if c.RetrieveProjectUserFunc != nil {
+		return c.RetrieveProjectUserFunc(ctx, projectID, userID, opts...)
+	}
+	return
+}
+
+// ModifyProjectUser implements the openai.API interface.
+func (c *Client) ModifyProjectUser(ctx context.Context, projectID, userID string, ur *openai.ProjectUserRequest, opts ...openai.RequestOption) (_ *openai.ProjectUser, _ error) {
+	if c.ModifyProjectUserFunc != nil {
+		return c.ModifyProjectUserFunc(ctx, projectID, userID, ur, opts...)
+	}
+	return
+}
+
+// DeleteProjectUser implements the openai.API interface.
+func (c *Client) DeleteProjectUser(ctx context.Context, projectID, userID string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteProjectUserFunc != nil {
+		return c.DeleteProjectUserFunc(ctx, projectID, userID, opts...)
+	}
+	return
+}
+
+// ListInvites implements the openai.API interface.
+func (c *Client) ListInvites(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Invite], _ error) {
+	if c.ListInvitesFunc != nil {
+		return c.ListInvitesFunc(ctx, opts...)
+	}
+	return
+}
+
+// CreateInvite implements the openai.API interface.
+func (c *Client) CreateInvite(ctx context.Context, ir *openai.InviteRequest, opts ...openai.RequestOption) (_ *openai.Invite, _ error) {
+	if c.CreateInviteFunc != nil {
+		return c.CreateInviteFunc(ctx, ir, opts...)
+	}
+	return
+}
+
+// RetrieveInvite implements the openai.API interface.
+func (c *Client) RetrieveInvite(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Invite, _ error) {
+	if c.RetrieveInviteFunc != nil {
+		return c.RetrieveInviteFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// DeleteInvite implements the openai.API interface.
+func (c *Client) DeleteInvite(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteInviteFunc != nil {
+		return c.DeleteInviteFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListServiceAccounts implements the openai.API interface.
+func (c *Client) ListServiceAccounts(ctx context.Context, projectID string, opts ...openai.RequestOption) (_ *openai.List[*openai.ServiceAccount], _ error) {
+	if c.ListServiceAccountsFunc != nil {
+		return c.ListServiceAccountsFunc(ctx, projectID, opts...)
+	}
+	return
+}
+
+// CreateServiceAccount implements the openai.API interface.
+func (c *Client) CreateServiceAccount(ctx context.Context, projectID string, sr *openai.ServiceAccountRequest, opts ...openai.RequestOption) (_ *openai.ServiceAccount, _ error) {
+	if c.CreateServiceAccountFunc != nil {
+		return c.CreateServiceAccountFunc(ctx, projectID, sr, opts...)
+	}
+	return
+}
+
+// RetrieveServiceAccount implements the openai.API interface.
+func (c *Client) RetrieveServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...openai.RequestOption) (_ *openai.ServiceAccount, _ error) {
+	if c.RetrieveServiceAccountFunc != nil {
+		return c.RetrieveServiceAccountFunc(ctx, projectID, serviceAccountID, opts...)
+	}
+	return
+}
+
+// DeleteServiceAccount implements the openai.API interface.
+func (c *Client) DeleteServiceAccount(ctx context.Context, projectID, serviceAccountID string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteServiceAccountFunc != nil {
+		return c.DeleteServiceAccountFunc(ctx, projectID, serviceAccountID, opts...)
+	}
+	return
+}
+
+// ListProjectAPIKeys implements the openai.API interface.
+func (c *Client) ListProjectAPIKeys(ctx context.Context, projectID string, opts ...openai.RequestOption) (_ *openai.List[*openai.ProjectAPIKey], _ error) {
+	if c.ListProjectAPIKeysFunc != nil {
+		return c.ListProjectAPIKeysFunc(ctx, projectID, opts...)
+	}
+	return
+}
+
+// RetrieveProjectAPIKey implements the openai.API interface.
+func (c *Client) RetrieveProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...openai.RequestOption) (_ *openai.ProjectAPIKey, _ error) {
+	if c.RetrieveProjectAPIKeyFunc != nil {
+		return c.RetrieveProjectAPIKeyFunc(ctx, projectID, keyID, opts...)
+	}
+	return
+}
+
+// DeleteProjectAPIKey implements the openai.API interface.
+func (c *Client) DeleteProjectAPIKey(ctx context.Context, projectID, keyID string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteProjectAPIKeyFunc != nil {
+		return c.DeleteProjectAPIKeyFunc(ctx, projectID, keyID, opts...)
+	}
+	return
+}
+
+// GetCosts implements the openai.API interface.
+func (c *Client) GetCosts(ctx context.Context, cr *openai.CostsRequest, opts ...openai.RequestOption) (_ *openai.CostsPage, _ error) {
+	if c.GetCostsFunc != nil {
+		return c.GetCostsFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// ListAuditLogs implements the openai.API interface.
+func (c *Client) ListAuditLogs(ctx context.Context, ar *openai.AuditLogsRequest, opts ...openai.RequestOption) (_ *openai.List[*openai.AuditLog], _ error) {
+	if c.ListAuditLogsFunc != nil {
+		return c.ListAuditLogsFunc(ctx, ar, opts...)
+	}
+	return
+}
+
+// CreateAssistant implements the openai.API interface.
+func (c *Client) CreateAssistant(ctx context.Context, ar *openai.AssistantRequest, opts ...openai.RequestOption) (_ *openai.Assistant, _ error) {
+	if c.CreateAssistantFunc != nil {
+		return c.CreateAssistantFunc(ctx, ar, opts...)
+	}
+	return
+}
+
+// ListAssistants implements the openai.API interface.
+func (c *Client) ListAssistants(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Assistant], _ error) {
+	if c.ListAssistantsFunc != nil {
+		return c.ListAssistantsFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveAssistant implements the openai.API interface.
+func (c *Client) RetrieveAssistant(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Assistant, _ error) {
+	if c.RetrieveAssistantFunc != nil {
+		return c.RetrieveAssistantFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ModifyAssistant implements the openai.API interface.
+func (c *Client) ModifyAssistant(ctx context.Context, id string, ar *openai.AssistantRequest, opts ...openai.RequestOption) (_ *openai.Assistant, _ error) {
+	if c.ModifyAssistantFunc != nil {
+		return c.ModifyAssistantFunc(ctx, id, ar, opts...)
+	}
+	return
+}
+
+// DeleteAssistant implements the openai.API interface.
+func (c *Client) DeleteAssistant(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteAssistantFunc != nil {
+		return c.DeleteAssistantFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateTranscription implements the openai.API interface.
+func (c *Client) CreateTranscription(ctx context.Context, tr *openai.TranscriptionRequest, opts ...openai.RequestOption) (_ *openai.TranscriptionResponse, _ error) {
+	if c.CreateTranscriptionFunc != nil {
+		return c.CreateTranscriptionFunc(ctx, tr, opts...)
+	}
+	return
+}
+
+// CreateSpeech implements the openai.API interface.
+func (c *Client) CreateSpeech(ctx context.Context, sr *openai.SpeechRequest, opts ...openai.RequestOption) (_ io.ReadCloser, _ error) {
+	if c.CreateSpeechFunc != nil {
+		return c.CreateSpeechFunc(ctx, sr, opts...)
+	}
+	return
+}
+
+// CreateBatch implements the openai.API interface.
+func (c *Client) CreateBatch(ctx context.Context, br *openai.BatchRequest, opts ...openai.RequestOption) (_ *openai.Batch, _ error) {
+	if c.CreateBatchFunc != nil {
+		return c.CreateBatchFunc(ctx, br, opts...)
+	}
+	return
+}
+
+// RetrieveBatch implements the openai.API interface.
+func (c *Client) RetrieveBatch(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Batch, _ error) {
+	if c.RetrieveBatchFunc != nil {
+		return c.RetrieveBatchFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CancelBatch implements the openai.API interface.
+func (c *Client) CancelBatch(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Batch, _ error) {
+	if c.CancelBatchFunc != nil {
+		return c.CancelBatchFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListBatches implements the openai.API interface.
+func (c *Client) ListBatches(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Batch], _ error) {
+	if c.ListBatchesFunc != nil {
+		return c.ListBatchesFunc(ctx, opts...)
+	}
+	return
+}
+
+// CreateChatCompletion implements the openai.API interface.
+func (c *Client) CreateChatCompletion(ctx context.Context, cr *openai.ChatCompletionRequest[models.Chat], opts ...openai.RequestOption) (_ *openai.ChatCompletionResponse[models.Chat], _ error) {
+	if c.CreateChatCompletionFunc != nil {
+		return c.CreateChatCompletionFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// CreateFineTunedChatCompletion implements the openai.API interface.
+func (c *Client) CreateFineTunedChatCompletion(ctx context.Context, cr *openai.ChatCompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (_ *openai.ChatCompletionResponse[models.FineTunedModel], _ error) {
+	if c.CreateFineTunedChatCompletionFunc != nil {
+		return c.CreateFineTunedChatCompletionFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// CreateChatCompletionStream implements the openai.API interface.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, cr *openai.ChatCompletionRequest[models.Chat], opts ...openai.RequestOption) (_ *openai.Stream[*openai.ChatCompletionChunk[models.Chat]], _ error) {
+	if c.CreateChatCompletionStreamFunc != nil {
+		return c.CreateChatCompletionStreamFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// CreateFineTunedChatCompletionStream implements the openai.API interface.
+func (c *Client) CreateFineTunedChatCompletionStream(ctx context.Context, cr *openai.ChatCompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (_ *openai.Stream[*openai.ChatCompletionChunk[models.FineTunedModel]], _ error) {
+	if c.CreateFineTunedChatCompletionStreamFunc != nil {
+		return c.CreateFineTunedChatCompletionStreamFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// RetrieveChatCompletion implements the openai.API interface.
+func (c *Client) RetrieveChatCompletion(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.ChatCompletionResponse[models.Chat], _ error) {
+	if c.RetrieveChatCompletionFunc != nil {
+		return c.RetrieveChatCompletionFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListChatCompletions implements the openai.API interface.
+func (c *Client) ListChatCompletions(ctx context.Context, lr *openai.ChatCompletionsListRequest, opts ...openai.RequestOption) (_ *openai.List[*openai.ChatCompletionResponse[models.Chat]], _ error) {
+	if c.ListChatCompletionsFunc != nil {
+		return c.ListChatCompletionsFunc(ctx, lr, opts...)
+	}
+	return
+}
+
+// DeleteChatCompletion implements the openai.API interface.
+func (c *Client) DeleteChatCompletion(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteChatCompletionFunc != nil {
+		return c.DeleteChatCompletionFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateCompletion implements the openai.API interface.
+func (c *Client) CreateCompletion(ctx context.Context, cr *openai.CompletionRequest[models.Completion], opts ...openai.RequestOption) (_ *openai.CompletionResponse[models.Completion], _ error) {
+	if c.CreateCompletionFunc != nil {
+		return c.CreateCompletionFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// CreateFineTunedCompletion implements the openai.API interface.
+func (c *Client) CreateFineTunedCompletion(ctx context.Context, cr *openai.CompletionRequest[models.FineTunedModel], opts ...openai.RequestOption) (_ *openai.CompletionResponse[models.FineTunedModel], _ error) {
+	if c.CreateFineTunedCompletionFunc != nil {
+		return c.CreateFineTunedCompletionFunc(ctx, cr, opts...)
+	}
+	return
+}
+
+// CreateEdit implements the openai.API interface.
+func (c *Client) CreateEdit(ctx context.Context, er *openai.EditsRequest, opts ...openai.RequestOption) (_ *openai.EditsResponse, _ error) {
+	if c.CreateEditFunc != nil {
+		return c.CreateEditFunc(ctx, er, opts...)
+	}
+	return
+}
+
+// CreateEmbeddings implements the openai.API interface.
+func (c *Client) CreateEmbeddings(ctx context.Context, request *openai.EmbeddingRequest, opts ...openai.RequestOption) (_ *openai.EmbeddingResponse, _ error) {
+	if c.CreateEmbeddingsFunc != nil {
+		return c.CreateEmbeddingsFunc(ctx, request, opts...)
+	}
+	return
+}
+
+// ListEngines implements the openai.API interface.
+func (c *Client) ListEngines(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Engine], _ error) {
+	if c.ListEnginesFunc != nil {
+		return c.ListEnginesFunc(ctx, opts...)
+	}
+	return
+}
+
+// GetEngine implements the openai.API interface.
+func (c *Client) GetEngine(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Engine, _ error) {
+	if c.GetEngineFunc != nil {
+		return c.GetEngineFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListFiles implements the openai.API interface.
+func (c *Client) ListFiles(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.File], _ error) {
+	if c.ListFilesFunc != nil {
+		return c.ListFilesFunc(ctx, opts...)
+	}
+	return
+}
+
+// UploadFile implements the openai.API interface.
+func (c *Client) UploadFile(ctx context.Context, fr *openai.FileRequest, opts ...openai.RequestOption) (_ *openai.File, _ error) {
+	if c.UploadFileFunc != nil {
+		return c.UploadFileFunc(ctx, fr, opts...)
+	}
+	return
+}
+
+// DeleteFile implements the openai.API interface.
+func (c *Client) DeleteFile(ctx context.Context, id string, opts ...openai.RequestOption) (_ error) {
+	if c.DeleteFileFunc != nil {
+		return c.DeleteFileFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// RetrieveFile implements the openai.API interface.
+func (c *Client) RetrieveFile(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.File, _ error) {
+	if c.RetrieveFileFunc != nil {
+		return c.RetrieveFileFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// GetFileContent implements the openai.API interface.
+func (c *Client) GetFileContent(ctx context.Context, id string, w io.Writer, opts ...openai.RequestOption) (_ error) {
+	if c.GetFileContentFunc != nil {
+		return c.GetFileContentFunc(ctx, id, w, opts...)
+	}
+	return
+}
+
+// CreateFineTune implements the openai.API interface.
+func (c *Client) CreateFineTune(ctx context.Context, ftr *openai.FineTuneRequest, opts ...openai.RequestOption) (_ *openai.FineTuneResponse, _ error) {
+	if c.CreateFineTuneFunc != nil {
+		return c.CreateFineTuneFunc(ctx, ftr, opts...)
+	}
+	return
+}
+
+// ListFineTunes implements the openai.API interface.
+func (c *Client) ListFineTunes(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.FineTuneResponse], _ error) {
+	if c.ListFineTunesFunc != nil {
+		return c.ListFineTunesFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveFineTune implements the openai.API interface.
+func (c *Client) RetrieveFineTune(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.FineTuneResponse, _ error) {
+	if c.RetrieveFineTuneFunc != nil {
+		return c.RetrieveFineTuneFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CancelFineTune implements the openai.API interface.
+func (c *Client) CancelFineTune(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.FineTuneResponse, _ error) {
+	if c.CancelFineTuneFunc != nil {
+		return c.CancelFineTuneFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListFineTuneEvents implements the openai.API interface.
+func (c *Client) ListFineTuneEvents(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.List[*openai.Event], _ error) {
+	if c.ListFineTuneEventsFunc != nil {
+		return c.ListFineTuneEventsFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// DeleteFineTune implements the openai.API interface.
+func (c *Client) DeleteFineTune(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.FineTuneDeletionResponse, _ error) {
+	if c.DeleteFineTuneFunc != nil {
+		return c.DeleteFineTuneFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateFineTuningJob implements the openai.API interface.
+func (c *Client) CreateFineTuningJob(ctx context.Context, fr *openai.FineTuningJobRequest, opts ...openai.RequestOption) (_ *openai.FineTuningJob, _ error) {
+	if c.CreateFineTuningJobFunc != nil {
+		return c.CreateFineTuningJobFunc(ctx, fr, opts...)
+	}
+	return
+}
+
+// ListFineTuningJobs implements the openai.API interface.
+func (c *Client) ListFineTuningJobs(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.FineTuningJob], _ error) {
+	if c.ListFineTuningJobsFunc != nil {
+		return c.ListFineTuningJobsFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveFineTuningJob implements the openai.API interface.
+func (c *Client) RetrieveFineTuningJob(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.FineTuningJob, _ error) {
+	if c.RetrieveFineTuningJobFunc != nil {
+		return c.RetrieveFineTuningJobFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CancelFineTuningJob implements the openai.API interface.
+func (c *Client) CancelFineTuningJob(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.FineTuningJob, _ error) {
+	if c.CancelFineTuningJobFunc != nil {
+		return c.CancelFineTuningJobFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListFineTuningEvents implements the openai.API interface.
+func (c *Client) ListFineTuningEvents(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.List[*openai.FineTuningJobEvent], _ error) {
+	if c.ListFineTuningEventsFunc != nil {
+		return c.ListFineTuningEventsFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// StreamFineTuningEvents implements the openai.API interface.
+func (c *Client) StreamFineTuningEvents(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Stream[*openai.FineTuningJobEvent], _ error) {
+	if c.StreamFineTuningEventsFunc != nil {
+		return c.StreamFineTuningEventsFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ListFineTuningCheckpoints implements the openai.API interface.
+func (c *Client) ListFineTuningCheckpoints(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.List[*openai.FineTuningJobCheckpoint], _ error) {
+	if c.ListFineTuningCheckpointsFunc != nil {
+		return c.ListFineTuningCheckpointsFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateCheckpointPermissions implements the openai.API interface.
+func (c *Client) CreateCheckpointPermissions(ctx context.Context, checkpoint string, pr *openai.CheckpointPermissionRequest, opts ...openai.RequestOption) (_ *openai.List[*openai.CheckpointPermission], _ error) {
+	if c.CreateCheckpointPermissionsFunc != nil {
+		return c.CreateCheckpointPermissionsFunc(ctx, checkpoint, pr, opts...)
+	}
+	return
+}
+
+// ListCheckpointPermissions implements the openai.API interface.
+func (c *Client) ListCheckpointPermissions(ctx context.Context, checkpoint, projectID string, opts ...openai.RequestOption) (_ *openai.List[*openai.CheckpointPermission], _ error) {
+	if c.ListCheckpointPermissionsFunc != nil {
+		return c.ListCheckpointPermissionsFunc(ctx, checkpoint, projectID, opts...)
+	}
+	return
+}
+
+// DeleteCheckpointPermission implements the openai.API interface.
+func (c *Client) DeleteCheckpointPermission(ctx context.Context, checkpoint, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteCheckpointPermissionFunc != nil {
+		return c.DeleteCheckpointPermissionFunc(ctx, checkpoint, id, opts...)
+	}
+	return
+}
+
+// CreateImage implements the openai.API interface.
+func (c *Client) CreateImage(ctx context.Context, ir *openai.CreateImageRequest, opts ...openai.RequestOption) (_ *openai.ImageResponse, _ error) {
+	if c.CreateImageFunc != nil {
+		return c.CreateImageFunc(ctx, ir, opts...)
+	}
+	return
+}
+
+// EditImage implements the openai.API interface.
+func (c *Client) EditImage(ctx context.Context, eir *openai.EditImageRequest, opts ...openai.RequestOption) (_ *openai.ImageResponse, _ error) {
+	if c.EditImageFunc != nil {
+		return c.EditImageFunc(ctx, eir, opts...)
+	}
+	return
+}
+
+// ImageVariation implements the openai.API interface.
+func (c *Client) ImageVariation(ctx context.Context, vir *openai.VariationImageRequest, opts ...openai.RequestOption) (_ *openai.ImageResponse, _ error) {
+	if c.ImageVariationFunc != nil {
+		return c.ImageVariationFunc(ctx, vir, opts...)
+	}
+	return
+}
+
+// ListModels implements the openai.API interface.
+func (c *Client) ListModels(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.Model], _ error) {
+	if c.ListModelsFunc != nil {
+		return c.ListModelsFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveModel implements the openai.API interface.
+func (c *Client) RetrieveModel(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.Model, _ error) {
+	if c.RetrieveModelFunc != nil {
+		return c.RetrieveModelFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// DeleteModel implements the openai.API interface.
+func (c *Client) DeleteModel(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteModelFunc != nil {
+		return c.DeleteModelFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateModeration implements the openai.API interface.
+func (c *Client) CreateModeration(ctx context.Context, mr *openai.ModerationRequest, opts ...openai.RequestOption) (_ *openai.ModerationResponse, _ error) {
+	if c.CreateModerationFunc != nil {
+		return c.CreateModerationFunc(ctx, mr, opts...)
+	}
+	return
+}
+
+// CreateUpload implements the openai.API interface.
+func (c *Client) CreateUpload(ctx context.Context, ur *openai.UploadRequest, opts ...openai.RequestOption) (_ *openai.Upload, _ error) {
+	if c.CreateUploadFunc != nil {
+		return c.CreateUploadFunc(ctx, ur, opts...)
+	}
+	return
+}
+
+// AddUploadPart implements the openai.API interface.
+func (c *Client) AddUploadPart(ctx context.Context, uploadID string, data io.Reader, opts ...openai.RequestOption) (_ *openai.UploadPart, _ error) {
+	if c.AddUploadPartFunc != nil {
+		return c.AddUploadPartFunc(ctx, uploadID, data, opts...)
+	}
+	return
+}
+
+// UploadParts implements the openai.API interface.
+func (c *Client) UploadParts(ctx context.Context, uploadID string, r io.Reader, opts *openai.UploadPartsOptions, reqOpts ...openai.RequestOption) (_ []string, _ error) {
+	if c.UploadPartsFunc != nil {
+		return c.UploadPartsFunc(ctx, uploadID, r, opts, reqOpts...)
+	}
+	return
+}
+
+// CompleteUpload implements the openai.API interface.
+func (c *Client) CompleteUpload(ctx context.Context, uploadID string, cr *openai.CompleteUploadRequest, opts ...openai.RequestOption) (_ *openai.Upload, _ error) {
+	if c.CompleteUploadFunc != nil {
+		return c.CompleteUploadFunc(ctx, uploadID, cr, opts...)
+	}
+	return
+}
+
+// CancelUpload implements the openai.API interface.
+func (c *Client) CancelUpload(ctx context.Context, uploadID string, opts ...openai.RequestOption) (_ *openai.Upload, _ error) {
+	if c.CancelUploadFunc != nil {
+		return c.CancelUploadFunc(ctx, uploadID, opts...)
+	}
+	return
+}
+
+// CreateVectorStore implements the openai.API interface.
+func (c *Client) CreateVectorStore(ctx context.Context, vr *openai.VectorStoreRequest, opts ...openai.RequestOption) (_ *openai.VectorStore, _ error) {
+	if c.CreateVectorStoreFunc != nil {
+		return c.CreateVectorStoreFunc(ctx, vr, opts...)
+	}
+	return
+}
+
+// ListVectorStores implements the openai.API interface.
+func (c *Client) ListVectorStores(ctx context.Context, opts ...openai.RequestOption) (_ *openai.List[*openai.VectorStore], _ error) {
+	if c.ListVectorStoresFunc != nil {
+		return c.ListVectorStoresFunc(ctx, opts...)
+	}
+	return
+}
+
+// RetrieveVectorStore implements the openai.API interface.
+func (c *Client) RetrieveVectorStore(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.VectorStore, _ error) {
+	if c.RetrieveVectorStoreFunc != nil {
+		return c.RetrieveVectorStoreFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// ModifyVectorStore implements the openai.API interface.
+func (c *Client) ModifyVectorStore(ctx context.Context, id string, vr *openai.VectorStoreRequest, opts ...openai.RequestOption) (_ *openai.VectorStore, _ error) {
+	if c.ModifyVectorStoreFunc != nil {
+		return c.ModifyVectorStoreFunc(ctx, id, vr, opts...)
+	}
+	return
+}
+
+// DeleteVectorStore implements the openai.API interface.
+func (c *Client) DeleteVectorStore(ctx context.Context, id string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteVectorStoreFunc != nil {
+		return c.DeleteVectorStoreFunc(ctx, id, opts...)
+	}
+	return
+}
+
+// CreateVectorStoreFile implements the openai.API interface.
+func (c *Client) CreateVectorStoreFile(ctx context.Context, vectorStoreID string, fr *openai.VectorStoreFileRequest, opts ...openai.RequestOption) (_ *openai.VectorStoreFile, _ error) {
+	if c.CreateVectorStoreFileFunc != nil {
+		return c.CreateVectorStoreFileFunc(ctx, vectorStoreID, fr, opts...)
+	}
+	return
+}
+
+// ListVectorStoreFiles implements the openai.API interface.
+func (c *Client) ListVectorStoreFiles(ctx context.Context, vectorStoreID string, opts ...openai.RequestOption) (_ *openai.List[*openai.VectorStoreFile], _ error) {
+	if c.ListVectorStoreFilesFunc != nil {
+		return c.ListVectorStoreFilesFunc(ctx, vectorStoreID, opts...)
+	}
+	return
+}
+
+// RetrieveVectorStoreFile implements the openai.API interface.
+func (c *Client) RetrieveVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...openai.RequestOption) (_ *openai.VectorStoreFile, _ error) {
+	if c.RetrieveVectorStoreFileFunc != nil {
+		return c.RetrieveVectorStoreFileFunc(ctx, vectorStoreID, fileID, opts...)
+	}
+	return
+}
+
+// DeleteVectorStoreFile implements the openai.API interface.
+func (c *Client) DeleteVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...openai.RequestOption) (_ *openai.DeletionResponse, _ error) {
+	if c.DeleteVectorStoreFileFunc != nil {
+		return c.DeleteVectorStoreFileFunc(ctx, vectorStoreID, fileID, opts...)
+	}
+	return
+}
+
+// CreateVectorStoreFileBatch implements the openai.API interface.
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, br *openai.VectorStoreFileBatchRequest, opts ...openai.RequestOption) (_ *openai.VectorStoreFileBatch, _ error) {
+	if c.CreateVectorStoreFileBatchFunc != nil {
+		return c.CreateVectorStoreFileBatchFunc(ctx, vectorStoreID, br, opts...)
+	}
+	return
+}
+
+// RetrieveVectorStoreFileBatch implements the openai.API interface.
+func (c *Client) RetrieveVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (_ *openai.VectorStoreFileBatch, _ error) {
+	if c.RetrieveVectorStoreFileBatchFunc != nil {
+		return c.RetrieveVectorStoreFileBatchFunc(ctx, vectorStoreID, batchID, opts...)
+	}
+	return
+}
+
+// CancelVectorStoreFileBatch implements the openai.API interface.
+func (c *Client) CancelVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (_ *openai.VectorStoreFileBatch, _ error) {
+	if c.CancelVectorStoreFileBatchFunc != nil {
+		return c.CancelVectorStoreFileBatchFunc(ctx, vectorStoreID, batchID, opts...)
+	}
+	return
+}
+
+// ListVectorStoreFileBatchFiles implements the openai.API interface.
+func (c *Client) ListVectorStoreFileBatchFiles(ctx context.Context, vectorStoreID, batchID string, opts ...openai.RequestOption) (_ *openai.List[*openai.VectorStoreFile], _ error) {
+	if c.ListVectorStoreFileBatchFilesFunc != nil {
+		return c.ListVectorStoreFileBatchFilesFunc(ctx, vectorStoreID, batchID, opts...)
+	}
+	return
+}
@@ -0,0 +1,226 @@
+package openaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// Mode determines whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeAuto replays the cassette if it exists, and records a new one otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord sends all requests to the real API and records the interactions, replacing any existing cassette.
+	ModeRecord
+	// ModeReplay serves all requests from the cassette, and fails requests which were not recorded.
+	ModeReplay
+)
+
+// ErrNotRecorded is returned by a replaying Recorder for requests which have no recorded interaction.
+var ErrNotRecorded = errors.New("openaitest: no recorded interaction for request")
+
+// sensitiveHeaders are removed from recorded interactions.
+var sensitiveHeaders = []string{
+	"Authorization", "Api-Key", "Cookie", "Set-Cookie", "Openai-Organization", "Openai-Project", "Proxy-Authorization",
+}
+
+// secretPattern matches API keys in recorded bodies.
+var secretPattern = regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`)
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a sanitized request within an Interaction.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a sanitized response within an Interaction. Streamed responses are recorded in full, so they
+// replay as a single burst of server-sent events.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper which records interactions with the API to a cassette file, and replays them
+// deterministically, e.g. in CI. Use it with openai.WithHTTPClient:
+//
+//	var rec, err = openaitest.NewRecorder("testdata/embeddings.json", openaitest.ModeAuto, nil)
+//	...
+//	defer rec.Save()
+//	var client = openai.NewClient(token, openai.WithHTTPClient(&http.Client{Transport: rec}))
+//
+// Credentials are removed from recorded headers, and API keys are redacted from bodies. Requests are matched on their
+// method, URL, and body; identical requests are replayed in the order in which they were recorded.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	// replayed marks the interactions which have already been replayed.
+	replayed []bool
+}
+
+// NewRecorder returns a *Recorder for the cassette at |path|. Recorded requests are sent with |next|, or with
+// http.DefaultTransport if |next| is nil.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	var r = &Recorder{path: path, mode: mode, next: next}
+
+	var b, err = os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && mode != ModeReplay:
+		r.mode = ModeRecord
+		return r, nil
+	case err != nil:
+		return nil, err
+	case mode == ModeRecord:
+		return r, nil
+	}
+
+	if err = json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("openaitest: invalid cassette %s: %w", path, err)
+	}
+	r.mode = ModeReplay
+	r.replayed = make([]bool, len(r.interactions))
+
+	return r, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body, err = readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rr = RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: sanitizeHeader(req.Header),
+		Body:   secretPattern.ReplaceAllString(string(body), "sk-REDACTED"),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, &rr)
+	}
+
+	var resp *http.Response
+	if resp, err = r.next.RoundTrip(req); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var b []byte
+	if b, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, &Interaction{
+		Request: rr,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: sanitizeHeader(resp.Header),
+			Body:   secretPattern.ReplaceAllString(string(b), "sk-REDACTED"),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	return resp, nil
+}
+
+// replay returns the first recorded response to |rr| which has not yet been replayed.
+func (r *Recorder) replay(req *http.Request, rr *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.interactions {
+		if r.replayed[i] || in.Request.Method != rr.Method || in.Request.URL != rr.URL || in.Request.Body != rr.Body {
+			continue
+		}
+		r.replayed[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, rr.Method, rr.URL)
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	var b, err = json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// readBody returns the body of |req|, replacing it so that it can still be sent.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	var b, err = io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(b))
+
+	return b, nil
+}
+
+// sanitizeHeader returns a copy of |h| without any sensitive headers.
+func sanitizeHeader(h http.Header) http.Header {
+	var out = h.Clone()
+	for _, k := range sensitiveHeaders {
+		out.Del(k)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
@@ -0,0 +1,96 @@
+package openaitest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fabiustech/openai"
+)
+
+func TestRecorder(t *testing.T) {
+	var s = NewServer()
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "models/gpt-4o", JSON(&openai.Model{ID: "gpt-4o"}))
+	s.Handle(http.MethodGet, "fine_tuning/jobs/ftjob-abc123/events", Stream(
+		&openai.FineTuningJobEvent{Message: "Step 1"},
+		&openai.FineTuningJobEvent{Message: "Step 2"},
+	))
+
+	var path = filepath.Join(t.TempDir(), "cassette.json")
+	var ctx = context.Background()
+
+	// Record against the fake server.
+	var rec, err = NewRecorder(path, ModeAuto, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder error: %v", err)
+	}
+	var client = s.Client(openai.WithHTTPClient(&http.Client{Transport: rec}))
+	if _, err = client.RetrieveModel(ctx, "gpt-4o"); err != nil {
+		t.Fatalf("RetrieveModel error: %v", err)
+	}
+	if got := readEvents(t, client); got != "Step 1,Step 2" {
+		t.Fatalf("unexpected recorded events: %s", got)
+	}
+	if err = rec.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	var b []byte
+	if b, err = os.ReadFile(path); err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if strings.Contains(string(b), "test-token") || strings.Contains(string(b), "Authorization") {
+		t.Fatalf("cassette contains credentials: %s", b)
+	}
+
+	// Replay without the server.
+	s.Close()
+	if rec, err = NewRecorder(path, ModeAuto, nil); err != nil {
+		t.Fatalf("NewRecorder error: %v", err)
+	}
+	client = s.Client(openai.WithHTTPClient(&http.Client{Transport: rec}))
+
+	var m *openai.Model
+	if m, err = client.RetrieveModel(ctx, "gpt-4o"); err != nil || m.ID != "gpt-4o" {
+		t.Fatalf("unexpected replayed model %+v, error: %v", m, err)
+	}
+	if got := readEvents(t, client); got != "Step 1,Step 2" {
+		t.Fatalf("unexpected replayed events: %s", got)
+	}
+
+	if _, err = client.RetrieveModel(ctx, "gpt-4o"); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("expected ErrNotRecorded once the interaction is replayed, got: %v", err)
+	}
+}
+
+// readEvents returns the messages of the streamed fine-tuning events of ftjob-abc123, joined with commas.
+func readEvents(t *testing.T, client *openai.Client) string {
+	t.Helper()
+
+	var stream, err = client.StreamFineTuningEvents(context.Background(), "ftjob-abc123")
+	if err != nil {
+		t.Fatalf("StreamFineTuningEvents error: %v", err)
+	}
+	defer stream.Close()
+
+	var messages []string
+	for {
+		var e, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+		messages = append(messages, e.Message)
+	}
+
+	return strings.Join(messages, ",")
+}
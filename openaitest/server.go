@@ -0,0 +1,255 @@
+// Package openaitest provides an in-process fake of the OpenAI API for integration tests. Responses are programmed per
+// route, and may inject latency, errors, and streamed server-sent events, so tests never need to reach the real API.
+package openaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fabiustech/openai"
+)
+
+// basePath is the path prefix of all routes served by the fake.
+const basePath = "/v1/"
+
+// Response is a canned response served by a Server.
+type Response struct {
+	// Status is the HTTP status code of the response.
+	// Defaults to 200.
+	Status int
+	// Header contains additional response headers.
+	Header http.Header
+	// Body is the body of the response. A []byte or string is sent as is; any other value is encoded as JSON.
+	Body any
+	// Events, if set, are streamed as server-sent events (each encoded as JSON) followed by a data: [DONE] message,
+	// instead of sending Body.
+	Events []any
+	// EventInterval is the delay between streamed Events.
+	EventInterval time.Duration
+	// Latency is the delay before the response is sent.
+	Latency time.Duration
+}
+
+// JSON returns a Response which sends |v| encoded as JSON.
+func JSON(v any) Response {
+	return Response{Body: v}
+}
+
+// Error returns a Response with status code |status| and an OpenAI style error of type |typ| with |message|.
+func Error(status int, typ, message string) Response {
+	return Response{
+		Status: status,
+		Body: map[string]any{
+			"error": map[string]any{"message": message, "type": typ, "param": nil, "code": nil},
+		},
+	}
+}
+
+// Stream returns a Response which streams each of |events| as a server-sent event.
+func Stream(events ...any) Response {
+	return Response{Events: events}
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	// Route is the route of the request, relative to /v1/ (e.g. "embeddings").
+	Route  string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// Decode unmarshals the JSON body of |r| into |v|.
+func (r *Request) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a fake OpenAI API server. Its zero value is not usable; use NewServer.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string][]Response
+	requests  []*Request
+	latency   time.Duration
+}
+
+// NewServer starts and returns a new Server. Routes without a programmed response return a 404 error. The caller
+// should call Close when finished, to shut it down.
+func NewServer() *Server {
+	var s = &Server{responses: map[string][]Response{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns an *openai.Client which sends requests to |s|. |opts| are applied after the base URL is set.
+func (s *Server) Client(opts ...openai.Option) *openai.Client {
+	return openai.NewClient("test-token", append([]openai.Option{openai.WithBaseURL(s.URL + basePath)}, opts...)...)
+}
+
+// Handle programs the responses to requests with |method| to |route| (e.g. "POST", "embeddings"). Responses are
+// served in order; the last is repeated for all subsequent requests. This allows, for example, a transient error to
+// be injected before a successful response. Handle replaces any responses previously programmed for the route.
+func (s *Server) Handle(method, route string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[key(method, route)] = responses
+}
+
+// SetLatency delays every response by |d|, in addition to the Latency of the response.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Requests returns the requests received by |s|, in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// key returns the key of the responses programmed for |method| and |route|.
+func key(method, route string) string {
+	return method + " " + strings.Trim(route, "/")
+}
+
+// next records |r| and returns the response to send, and the total latency to inject.
+func (s *Server) next(r *Request) (Response, time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+
+	var k = key(r.Method, r.Route)
+	var rs = s.responses[k]
+	if len(rs) == 0 {
+		return Response{}, s.latency, false
+	}
+
+	var resp = rs[0]
+	if len(rs) > 1 {
+		s.responses[k] = rs[1:]
+	}
+
+	return resp, s.latency + resp.Latency, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body, _ = io.ReadAll(r.Body)
+	var req = &Request{
+		Method: r.Method,
+		Route:  strings.TrimPrefix(r.URL.Path, basePath),
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+
+	var resp, latency, ok = s.next(req)
+	if !ok {
+		resp = Error(http.StatusNotFound, "invalid_request_error", fmt.Sprintf("Unknown request URL: %s %s", r.Method, r.URL.Path))
+	}
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	if resp.Events != nil {
+		writeEvents(w, r, resp)
+		return
+	}
+
+	var b, err = encode(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	var status = resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// writeEvents streams the Events of |resp| as server-sent events.
+func writeEvents(w http.ResponseWriter, r *http.Request, resp Response) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	var status = resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+
+	var flusher, _ = w.(http.Flusher)
+	for i, e := range resp.Events {
+		if i > 0 && resp.EventInterval > 0 {
+			select {
+			case <-time.After(resp.EventInterval):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		var b, err = encode(e)
+		if err != nil {
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", b)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	_, _ = io.WriteString(w, "data: [DONE]\n\n")
+}
+
+// encode returns |v| as is if it is a []byte or string, and encoded as JSON otherwise.
+func encode(v any) ([]byte, error) {
+	switch t := v.(type) {
+	case nil:
+		return []byte("{}"), nil
+	case []byte:
+		return t, nil
+	case string:
+		return []byte(t), nil
+	default:
+		var buf bytes.Buffer
+		var enc = json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+	}
+}
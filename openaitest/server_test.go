@@ -0,0 +1,69 @@
+package openaitest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fabiustech/openai"
+)
+
+func TestServer(t *testing.T) {
+	var s = NewServer()
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "models/gpt-4o",
+		Error(http.StatusServiceUnavailable, "server_error", "overloaded"),
+		JSON(&openai.Model{ID: "gpt-4o"}),
+	)
+
+	var client = s.Client(openai.WithRetry(&openai.RetryConfig{InitialBackoff: time.Millisecond}))
+	var ctx = context.Background()
+
+	var m, err = client.RetrieveModel(ctx, "gpt-4o")
+	if err != nil {
+		t.Fatalf("RetrieveModel error: %v", err)
+	}
+	if m.ID != "gpt-4o" || len(s.Requests()) != 2 {
+		t.Fatalf("unexpected model %+v after %d requests", m, len(s.Requests()))
+	}
+
+	if _, err = client.ListFiles(ctx); !errors.Is(err, openai.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unprogrammed route, got: %v", err)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	var s = NewServer()
+	defer s.Close()
+
+	s.Handle(http.MethodGet, "fine_tuning/jobs/ftjob-abc123/events", Stream(
+		&openai.FineTuningJobEvent{Message: "Step 1"},
+		&openai.FineTuningJobEvent{Message: "Step 2"},
+	))
+
+	var stream, err = s.Client().StreamFineTuningEvents(context.Background(), "ftjob-abc123")
+	if err != nil {
+		t.Fatalf("StreamFineTuningEvents error: %v", err)
+	}
+	defer stream.Close()
+
+	var messages []string
+	for {
+		var e, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+		messages = append(messages, e.Message)
+	}
+
+	if len(messages) != 2 || messages[1] != "Step 2" {
+		t.Fatalf("unexpected events: %v", messages)
+	}
+}
@@ -0,0 +1,322 @@
+package openai
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// Option configures optional behavior of a Client.
+type Option func(c *Client)
+
+// WithTokenProvider authenticates requests with bearer tokens supplied by |p| rather than a static API key (the token
+// passed to NewClient is ignored). Tokens are cached and refreshed automatically shortly before they expire. For Azure
+// clients, this enables Azure AD (Microsoft Entra ID) authentication in place of the resource's API key.
+func WithTokenProvider(p TokenProvider) Option {
+	return func(c *Client) {
+		c.tokens = &cachedTokenProvider{provider: p}
+	}
+}
+
+// WithOrganization sets the ID of the organization which requests are made on behalf of, sent as the
+// OpenAI-Organization header. Usage is billed to this organization. This is only necessary for API keys which belong to
+// multiple organizations.
+func WithOrganization(id string) Option {
+	return func(c *Client) {
+		c.orgID = &id
+	}
+}
+
+// WithProject sets the ID of the project which requests are made on behalf of, sent as the OpenAI-Project header. Usage
+// is billed to this project. This is only necessary for API keys which can access multiple projects.
+func WithProject(id string) Option {
+	return func(c *Client) {
+		c.projectID = &id
+	}
+}
+
+// WithAdminKey sets the admin API key used to authenticate requests to the organization administration endpoints
+// (projects, users, invites, costs, audit logs, checkpoint permissions, etc.). Admin keys cannot be used for
+// non-administration endpoints, so the Client continues to use its regular token for all other requests.
+func WithAdminKey(key string) Option {
+	return func(c *Client) {
+		c.adminKey = key
+	}
+}
+
+// WithRetry enables the automatic retry of requests which fail with a connection reset or a timeout, or for which the
+// API responds with a 429, 500, 502, or 503 status code (see RetryConfig). If |rc| is nil, the default RetryConfig is
+// used.
+func WithRetry(rc *RetryConfig) Option {
+	return func(c *Client) {
+		if rc == nil {
+			rc = &RetryConfig{}
+		}
+		c.retry = rc
+		c.retryBudget = rc.MaxElapsed
+	}
+}
+
+// WithRetryPolicy enables the retry of failed requests according to |p|, which replaces the default classification
+// and delay logic of WithRetry.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+		c.retryBudget = 0
+	}
+}
+
+// WithRateLimit limits the rate at which requests are sent to the API to |rl|, so that bulk workloads are throttled on
+// the client rather than rejected by the server with 429 errors. Requests (including retries) block until they can be
+// sent without exceeding the limit, or until their context is done.
+func WithRateLimit(rl RateLimit) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rl)
+	}
+}
+
+// WithMaxInFlight limits the number of requests which may be in flight at once to |n|, so that applications which fan
+// out many goroutines through a single Client do not exhaust sockets or trip burst limits. Additional requests block
+// until a slot is available or their context is done. A streamed response occupies its slot until it is closed.
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.inFlight = make(semaphore, n)
+		}
+	}
+}
+
+// WithAPIKeys spreads requests across several API |keys|, e.g. to increase the throughput of a high-traffic service,
+// instead of the key passed to NewClient. Keys which hit quota or rate limit errors are temporarily demoted. If |cfg|
+// is nil, the default KeyPoolConfig is used. It has no effect if |keys| is empty.
+func WithAPIKeys(keys []string, cfg *KeyPoolConfig) Option {
+	return func(c *Client) {
+		if len(keys) > 0 {
+			c.keys = newKeyPool(keys, cfg)
+		}
+	}
+}
+
+// WithBaseURLs sends requests to the first healthy of |urls| (e.g. a primary gateway followed by a regional fallback),
+// like WithBaseURL. If a request fails with a 5xx response, a timeout, or a connection error, it is sent to the next
+// base URL immediately, and the failed base URL is skipped until its cooldown has elapsed. If |cfg| is nil, the default
+// FailoverConfig is used. If any of |urls| is invalid, every request fails with the parse error.
+func WithBaseURLs(urls []string, cfg *FailoverConfig) Option {
+	return func(c *Client) {
+		var parsed = make([]*url.URL, len(urls))
+		for i, u := range urls {
+			if parsed[i], c.baseURLErr = url.Parse(u); c.baseURLErr != nil {
+				return
+			}
+		}
+
+		if len(parsed) > 0 {
+			c.baseURL = parsed[0]
+			c.failover = newFailover(parsed, cfg)
+		}
+	}
+}
+
+// WithCodec encodes request bodies and decodes responses with |codec| rather than the encoding/json package, e.g. to
+// reduce the CPU time spent decoding large embeddings or logprobs payloads.
+func WithCodec(codec Codec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// WithCompression gzip compresses JSON request bodies (e.g. large embeddings batches or long prompts) and requests gzip
+// compressed responses, to reduce bandwidth. Bodies smaller than the minimum size of |cfg| are sent uncompressed. If
+// |cfg| is nil, the default CompressionConfig is used. The server must accept gzip encoded requests, as the OpenAI API
+// does.
+func WithCompression(cfg *CompressionConfig) Option {
+	return func(c *Client) {
+		c.compression = newCompression(cfg)
+	}
+}
+
+// WithCompatibilityMode relaxes the requirements of the OpenAI API, so that the Client can be used with self-hosted
+// servers which implement a compatible API (e.g. Ollama, vLLM, or LM Studio) by setting WithBaseURL. In compatibility
+// mode, requests are not validated or adapted for the OpenAI models they name (e.g. reasoning models), and no
+// Authorization header is sent if the API key is empty. Use the fine-tuned endpoints (e.g.
+// CreateFineTunedChatCompletion), or WithRequestModel, to name models which are not constants of the models package.
+func WithCompatibilityMode() Option {
+	return func(c *Client) {
+		c.compat = true
+	}
+}
+
+// WithModelRoute sends the requests for models matching |pattern| to |target| rather than this Client, so that a
+// single Client can serve models from several providers (e.g. OpenAI, the Azure OpenAI Service, and an internal
+// proxy). |pattern| is matched against the model of the request with path.Match (e.g. "gpt-4o*" or
+// "llama-*"), and fine-tuned models also match by their base model. Routes are tried in the order they were added, and
+// the first match wins; requests which match no route, or have no model, are sent by this Client. A malformed
+// |pattern| matches no model. Routing applies to JSON requests; file uploads and requests without a body are always
+// sent by this Client. Requests are routed at most once: a request routed to |target| is sent by |target|, even if it
+// matches one of its own routes. A nil |target| is ignored.
+func WithModelRoute(pattern string, target *Client) Option {
+	return func(c *Client) {
+		if target != nil {
+			c.routes = append(c.routes, &modelRoute{pattern: pattern, client: target})
+		}
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker which opens after consecutive 5xx responses, timeouts, or connection
+// errors. While open, requests fail immediately with ErrCircuitOpen rather than waiting on a degraded API. If |cfg| is
+// nil, the default CircuitBreakerConfig is used.
+func WithCircuitBreaker(cfg *CircuitBreakerConfig) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(cfg)
+	}
+}
+
+// WithHeader sets the header |key| to |value| on every request, e.g. to authenticate with a corporate gateway. Custom
+// headers replace those set by the Client, and may themselves be replaced per request with WithRequestHeader.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.header == nil {
+			c.header = http.Header{}
+		}
+		c.header.Set(key, value)
+	}
+}
+
+// WithHeaderFunc calls |f| with the context of every request and sets the returned headers on the request, e.g. to
+// propagate tracing headers. Headers returned by |f| replace those set with WithHeader.
+func WithHeaderFunc(f func(ctx context.Context) http.Header) Option {
+	return func(c *Client) {
+		c.headerFunc = f
+	}
+}
+
+// WithHTTPClient sends requests with a copy of |hc| rather than http.DefaultClient. A nil |hc| is ignored. If a TLS
+// configuration was set with WithTLSConfig, it is applied to a copy of the transport of |hc|.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc == nil {
+			return
+		}
+
+		var cp = *hc
+		c.httpClient = &cp
+		if c.tlsConfig != nil {
+			// The transport of |hc| may be shared, so it is not modified.
+			if t, ok := cp.Transport.(*http.Transport); ok && t != http.DefaultTransport {
+				cp.Transport = t.Clone()
+			}
+			c.transport().TLSClientConfig = c.tlsConfig
+		}
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API, e.g. to trust a custom CA bundle, present a
+// client certificate to a mutual-TLS gateway, or require a minimum TLS version. If the Client's *http.Client (see
+// WithHTTPClient) does not use an *http.Transport, its transport is replaced. The configuration is kept if
+// WithHTTPClient is applied afterwards.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+		c.transport().TLSClientConfig = cfg
+	}
+}
+
+// WithTransportConfig tunes the connection pool, timeouts, HTTP/2, and DNS caching of the transport used to connect to
+// the API (see TransportConfig). If the Client's *http.Client (see WithHTTPClient) does not use an *http.Transport, its
+// transport is replaced. A nil |cfg| is ignored, leaving the transport's defaults.
+func WithTransportConfig(cfg *TransportConfig) Option {
+	return func(c *Client) {
+		if cfg == nil {
+			return
+		}
+		cfg.apply(c.transport())
+	}
+}
+
+// WithMetricsRecorder reports the endpoint, model, status, duration, and token usage of every call to |r|.
+func WithMetricsRecorder(r MetricsRecorder) Option {
+	return func(c *Client) {
+		c.metrics = r
+	}
+}
+
+// WithPricing computes the cost of every response which reports its token usage from |p| (e.g. DefaultPricing). The
+// cost is set on the ResponseMeta of the response, and reported to the MetricsRecorder and logger, if any.
+func WithPricing(p Pricing) Option {
+	return func(c *Client) {
+		c.pricing = p
+	}
+}
+
+// WithUsageTracker adds the token usage (and cost, see WithPricing) of every response to |t|. A single UsageTracker may
+// be shared by several Clients.
+func WithUsageTracker(t *UsageTracker) Option {
+	return func(c *Client) {
+		c.usage = t
+	}
+}
+
+// WithContextValidation rejects requests whose prompt plus max_tokens exceed the context window of their model in |w|
+// (e.g. DefaultContextWindows) with a *ContextLengthError, instead of sending them and receiving a 400 error. Prompt
+// text is counted with the tokenizer package; register the encodings of the models in use for exact counts.
+func WithContextValidation(w ContextWindows) Option {
+	return func(c *Client) {
+		c.contextWindows = w
+	}
+}
+
+// WithCache returns cached responses to identical deterministic requests from |store| (e.g. an LRUCache), to avoid
+// paying for them again. Completion and chat completion requests are only cached if their temperature is 0; embeddings
+// requests are always cached. Responses expire after |ttl|, or never if it is 0. Responses are returned from the cache
+// with their ResponseMeta.Cached set.
+func WithCache(store CacheStore, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = &responseCache{store: store, ttl: ttl}
+	}
+}
+
+// WithSemanticCache returns cached responses to completion and chat completion requests whose prompt is similar to that
+// of a prior request with otherwise identical parameters, according to |cfg| (which may be nil). Each cacheable request
+// first embeds its prompt, which costs an embeddings request but may save a far more expensive completion on repetitive
+// workloads. Responses are returned from the cache with their ResponseMeta.Cached set.
+func WithSemanticCache(cfg *SemanticCacheConfig) Option {
+	return func(c *Client) {
+		if cfg == nil {
+			cfg = &SemanticCacheConfig{}
+		}
+
+		var sc = &semanticCache{store: cfg.Store, model: cfg.Model, threshold: cfg.Threshold}
+		if sc.store == nil {
+			sc.store = NewMemorySemanticStore(1000)
+		}
+		if sc.model == models.Unknown {
+			sc.model = models.AdaEmbeddingV2
+		}
+		if sc.threshold == 0 {
+			sc.threshold = 0.95
+		}
+		c.semanticCache = sc
+	}
+}
+
+// WithDebug dumps every raw HTTP request and response, including their bodies, to |w|. Credentials (e.g. the
+// Authorization header) are masked. Streamed responses are dumped frame by frame as they are read. This is intended for
+// diagnosing errors such as 400s caused by malformed requests; dumps may contain sensitive prompt data.
+func WithDebug(w io.Writer) Option {
+	return func(c *Client) {
+		c.debug = &debugWriter{w: w}
+	}
+}
+
+// WithBaseURL sends requests to |u| (e.g. "https://gateway.example.com/openai/v1") rather than the OpenAI API. The
+// route of each endpoint is appended to the path of |u|. If |u| is invalid, every request fails with the parse error.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL, c.baseURLErr = url.Parse(u)
+	}
+}
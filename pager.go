@@ -0,0 +1,133 @@
+package openai
+
+import (
+	"context"
+	"reflect"
+)
+
+// maxPageLimit is the maximum number of objects which can be requested per page.
+const maxPageLimit = 100
+
+// PageFunc fetches a page of a list endpoint, paginated by |opts| (see WithListOptions). Methods such as
+// Client.ListFiles can be used as is; endpoints with other parameters can be wrapped in a closure.
+type PageFunc[T any] func(ctx context.Context, opts ...RequestOption) (*List[T], error)
+
+// AutoPager iterates over the objects of a list endpoint which supports cursor pagination, fetching subsequent pages
+// as needed:
+//
+//	var pager = openai.NewAutoPager(ctx, client.ListFiles, nil, 0)
+//	for pager.Next() {
+//		var f = pager.Current()
+//		...
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+//
+// An AutoPager is not safe for concurrent use.
+type AutoPager[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	opts  ListOptions
+	// limit is the maximum number of objects to return, or 0 for no limit.
+	limit int
+
+	page []T
+	// i is the index of the current object within page.
+	i int
+	// n is the number of objects returned so far.
+	n    int
+	more bool
+	err  error
+}
+
+// NewAutoPager returns an *AutoPager which fetches pages with |fetch|, starting from the page described by |opts|,
+// which may be nil. Iteration stops once |limit| objects have been returned, unless |limit| is 0.
+func NewAutoPager[T any](ctx context.Context, fetch PageFunc[T], opts *ListOptions, limit int) *AutoPager[T] {
+	var p = &AutoPager[T]{ctx: ctx, fetch: fetch, limit: limit, i: -1, more: true}
+	if opts != nil {
+		p.opts = *opts
+	}
+
+	return p
+}
+
+// Next advances to the next object, fetching the next page if the current one is exhausted. It returns false once
+// there are no more objects, the limit has been reached, or an error occurs (see Err).
+func (p *AutoPager[T]) Next() bool {
+	if p.err != nil || (p.limit > 0 && p.n >= p.limit) {
+		return false
+	}
+
+	for p.i+1 >= len(p.page) {
+		if !p.more {
+			return false
+		}
+		if p.err = p.fetchPage(); p.err != nil {
+			return false
+		}
+	}
+
+	p.i++
+	p.n++
+
+	return true
+}
+
+// Current returns the current object. It is only valid after a call to Next returns true.
+func (p *AutoPager[T]) Current() T {
+	return p.page[p.i]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (p *AutoPager[T]) Err() error {
+	return p.err
+}
+
+// fetchPage fetches the page after the current one.
+func (p *AutoPager[T]) fetchPage() error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+
+	var opts = p.opts
+	// Avoid fetching objects beyond the limit.
+	if remaining := p.limit - p.n; p.limit > 0 && remaining < maxPageLimit && (opts.Limit == 0 || remaining < opts.Limit) {
+		opts.Limit = remaining
+	}
+
+	var l, err = p.fetch(p.ctx, WithListOptions(&opts))
+	if err != nil {
+		return err
+	}
+
+	p.page, p.i = l.Data, -1
+	p.more = l.HasMore && len(l.Data) > 0
+	if !p.more {
+		return nil
+	}
+
+	p.opts.After = l.LastID
+	if p.opts.After == "" {
+		p.opts.After = objectID(l.Data[len(l.Data)-1])
+	}
+	// A page without a cursor cannot be followed.
+	p.more = p.opts.After != ""
+
+	return nil
+}
+
+// objectID returns the ID field of |v|, a struct or a pointer to one, or "" if it has none.
+func objectID(v any) string {
+	var rv = reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var f = rv.FieldByName("ID")
+	if f.Kind() != reflect.String {
+		return ""
+	}
+
+	return f.String()
+}
@@ -0,0 +1,30 @@
+//go:build go1.23
+
+package openai
+
+import "iter"
+
+// All returns an iterator over the remaining objects of the pager:
+//
+//	for f, err := range openai.NewAutoPager(ctx, client.ListFiles, nil, 0).All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The iterator stops after yielding an error.
+func (p *AutoPager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.Current(), nil) {
+				return
+			}
+		}
+
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
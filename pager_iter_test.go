@@ -0,0 +1,35 @@
+//go:build go1.23
+
+package openai
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAutoPagerAll(t *testing.T) {
+	var queries []string
+	var ts = pagedFilesServer(45, &queries)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+
+	var ids []string
+	for f, err := range NewAutoPager(context.Background(), client.ListFiles, nil, 0).All() {
+		if err != nil {
+			t.Fatalf("AutoPager error: %v", err)
+		}
+		ids = append(ids, f.ID)
+		if len(ids) == 25 {
+			break
+		}
+	}
+
+	if len(ids) != 25 || ids[24] != "file-25" {
+		t.Fatalf("expected file-1 to file-25, got %v", ids)
+	}
+	if want := []string{"", "after=file-20"}; !reflect.DeepEqual(queries, want) {
+		t.Fatalf("expected queries %v, got %v", want, queries)
+	}
+}
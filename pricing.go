@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ModelPrice is the price of a model, in US dollars per million tokens.
+type ModelPrice struct {
+	// Input is the price of prompt tokens.
+	Input float64
+	// Output is the price of completion tokens.
+	Output float64
+}
+
+// Cost returns the cost of |u| in US dollars.
+func (p ModelPrice) Cost(u *Usage) float64 {
+	if u == nil {
+		return 0
+	}
+
+	return (float64(u.PromptTokens)*p.Input + float64(u.CompletionTokens)*p.Output) / 1e6
+}
+
+// Pricing maps model names to their prices. Models are matched by their longest prefix in the table, so that dated
+// snapshots (e.g. "gpt-4o-2024-08-06") use the price of their alias.
+type Pricing map[string]ModelPrice
+
+// DefaultPricing contains OpenAI's published prices for standard (non-batch) requests. Prices change over time, so
+// callers which rely on them for budgeting should copy and update the table as needed.
+var DefaultPricing = Pricing{
+	"gpt-4o":                 {Input: 2.50, Output: 10.00},
+	"gpt-4o-2024-05-13":      {Input: 5.00, Output: 15.00},
+	"gpt-4o-mini":            {Input: 0.15, Output: 0.60},
+	"gpt-4-turbo":            {Input: 10.00, Output: 30.00},
+	"gpt-4-0125-preview":     {Input: 10.00, Output: 30.00},
+	"gpt-4-1106-preview":     {Input: 10.00, Output: 30.00},
+	"gpt-4":                  {Input: 30.00, Output: 60.00},
+	"gpt-4-32k":              {Input: 60.00, Output: 120.00},
+	"gpt-3.5-turbo":          {Input: 0.50, Output: 1.50},
+	"gpt-3.5-turbo-instruct": {Input: 1.50, Output: 2.00},
+	"o1":                     {Input: 15.00, Output: 60.00},
+	"o1-mini":                {Input: 1.10, Output: 4.40},
+	"o3-mini":                {Input: 1.10, Output: 4.40},
+	"davinci-002":            {Input: 2.00, Output: 2.00},
+	"babbage-002":            {Input: 0.40, Output: 0.40},
+	"text-embedding-3-small": {Input: 0.02},
+	"text-embedding-3-large": {Input: 0.13},
+	"text-embedding-ada-002": {Input: 0.10},
+	"omni-moderation":        {},
+	"text-moderation":        {},
+	"ft:gpt-4o":              {Input: 3.75, Output: 15.00},
+	"ft:gpt-4o-mini":         {Input: 0.30, Output: 1.20},
+	"ft:gpt-3.5-turbo":       {Input: 3.00, Output: 6.00},
+	"ft:davinci-002":         {Input: 12.00, Output: 12.00},
+	"ft:babbage-002":         {Input: 1.60, Output: 1.60},
+}
+
+// Lookup returns the price of |model|, and whether it was found.
+func (p Pricing) Lookup(model string) (ModelPrice, bool) {
+	return longestPrefix(p, model)
+}
+
+// Cost returns the cost of |u| for |model| in US dollars, and whether the price of |model| is known.
+func (p Pricing) Cost(model string, u *Usage) (float64, bool) {
+	var price, ok = p.Lookup(model)
+	if !ok || u == nil {
+		return 0, false
+	}
+
+	return price.Cost(u), true
+}
+
+// Estimate returns the estimated cost in US dollars of a request to |model| with |promptTokens|, which generates
+// |completionTokens| (e.g. its MaxTokens, for an upper bound), and whether the price of |model| is known. Prompt tokens
+// can be counted with the tokenizer package.
+func (p Pricing) Estimate(model string, promptTokens, completionTokens int) (float64, bool) {
+	return p.Cost(model, &Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	})
+}
+
+// responseCost returns the cost of the response body |b| to a request for |model|, or nil if it is unknown. The model
+// reported in the response, if any, takes precedence over |model|.
+func (p Pricing) responseCost(b []byte, model string) *float64 {
+	var v struct {
+		Model string `json:"model"`
+		Usage *Usage `json:"usage"`
+	}
+	if json.Unmarshal(b, &v) != nil || v.Usage == nil {
+		return nil
+	}
+
+	if v.Model != "" {
+		model = v.Model
+	}
+
+	var cost, ok = p.Cost(model, v.Usage)
+	if !ok {
+		return nil
+	}
+
+	return &cost
+}
+
+// longestPrefix returns the value of the longest key in |m| which is a prefix of |model|, and whether there is one.
+func longestPrefix[V any](m map[string]V, model string) (V, bool) {
+	if v, ok := m[model]; ok {
+		return v, true
+	}
+
+	var best string
+	var found bool
+	for name := range m {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best, found = name, true
+		}
+	}
+
+	return m[best], found
+}
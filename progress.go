@@ -0,0 +1,53 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc is called as the body of a request is sent, with the number of bytes sent so far and the total size of
+// the body, or -1 if it is unknown (see WithUploadProgress).
+type ProgressFunc func(sent, total int64)
+
+// progressBody is a request body which reports the progress of reading it, and stops being read once its context is
+// done.
+type progressBody struct {
+	io.ReadCloser
+	ctx      context.Context
+	progress ProgressFunc
+	sent     int64
+	total    int64
+}
+
+// Read implements the io.Reader interface.
+func (b *progressBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var n, err = b.ReadCloser.Read(p)
+	if n > 0 {
+		b.sent += int64(n)
+		b.progress(b.sent, b.total)
+	}
+
+	return n, err
+}
+
+// withProgress returns |send|, wrapped so that the progress of sending the body of each attempt is reported to
+// |progress|.
+func withProgress(send func(req *http.Request) (*http.Response, error), progress ProgressFunc) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = &progressBody{
+				ReadCloser: req.Body,
+				ctx:        req.Context(),
+				progress:   progress,
+				total:      req.ContentLength,
+			}
+		}
+
+		return send(req)
+	}
+}
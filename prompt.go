@@ -0,0 +1,99 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Prompt represents the prompt(s) to generate completions for. The API accepts prompts encoded as a string, array of
+// strings, array of tokens, or array of token arrays; use TextPrompt, TextPrompts, TokenPrompt, or TokenPrompts
+// (respectively) to construct a Prompt of the desired form.
+type Prompt struct {
+	text   []string
+	tokens [][]int
+	// batch is true if the prompt is encoded as an array of prompts.
+	batch bool
+}
+
+// TextPrompt returns a *Prompt containing the single prompt |s|.
+func TextPrompt(s string) *Prompt {
+	return &Prompt{text: []string{s}}
+}
+
+// TextPrompts returns a *Prompt containing a batch of prompts, one for each of |s|.
+func TextPrompts(s ...string) *Prompt {
+	return &Prompt{text: s, batch: true}
+}
+
+// TokenPrompt returns a *Prompt containing a single pre-tokenized prompt.
+func TokenPrompt(tokens ...int) *Prompt {
+	return &Prompt{tokens: [][]int{tokens}}
+}
+
+// TokenPrompts returns a *Prompt containing a batch of pre-tokenized prompts.
+func TokenPrompts(tokens ...[]int) *Prompt {
+	return &Prompt{tokens: tokens, batch: true}
+}
+
+// Text returns the text prompts contained in |p|. It returns nil if |p| is nil or contains tokens.
+func (p *Prompt) Text() []string {
+	if p == nil {
+		return nil
+	}
+
+	return p.text
+}
+
+// Tokens returns the token prompts contained in |p|. It returns nil if |p| is nil or contains text.
+func (p *Prompt) Tokens() [][]int {
+	if p == nil {
+		return nil
+	}
+
+	return p.tokens
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Prompt) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.tokens != nil && p.batch:
+		return json.Marshal(p.tokens)
+	case p.tokens != nil:
+		return json.Marshal(p.tokens[0])
+	case p.batch:
+		return json.Marshal(p.text)
+	case len(p.text) == 1:
+		return json.Marshal(p.text[0])
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Prompt) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*p = Prompt{text: []string{s}}
+		return nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err == nil {
+		*p = Prompt{text: ss, batch: true}
+		return nil
+	}
+
+	var t []int
+	if err := json.Unmarshal(b, &t); err == nil {
+		*p = Prompt{tokens: [][]int{t}}
+		return nil
+	}
+
+	var tt [][]int
+	if err := json.Unmarshal(b, &tt); err == nil {
+		*p = Prompt{tokens: tt, batch: true}
+		return nil
+	}
+
+	return fmt.Errorf("openai: invalid prompt: %s", b)
+}
@@ -0,0 +1,117 @@
+package openai
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// ErrUnsupported is returned by a Provider which does not support an operation (see ProviderFuncs).
+var ErrUnsupported = errors.New("openai: operation not supported by provider")
+
+// ChatStream is a stream of chat completion chunks. It is implemented by *Stream[*ChatCompletionChunk[T]].
+type ChatStream interface {
+	// Recv returns the next chunk of the stream, or io.EOF once the stream has been terminated.
+	Recv() (*ChatCompletionChunk[models.FineTunedModel], error)
+	// Close closes the stream.
+	Close() error
+}
+
+var _ ChatStream = (*Stream[*ChatCompletionChunk[models.FineTunedModel]])(nil)
+
+// Provider is a provider-agnostic interface to the core operations of a language model API. It is implemented by
+// *Client, and, via ProviderFuncs, can be implemented by adapters for other providers, so that application code (and
+// its tests) can switch between vendors without changing types. Models are named by strings (e.g.
+// models.FineTunedModel("gpt-4o")) rather than by the constants of the models package, as other providers do not
+// share them. Providers other than *Client may ignore |opts|.
+type Provider interface {
+	// Complete creates a completion for |cr|.
+	Complete(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error)
+	// Chat creates a chat completion for |cr|.
+	Chat(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error)
+	// Stream creates a streamed chat completion for |cr|.
+	Stream(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (ChatStream, error)
+	// Embed returns the embedding of each of |input| created by |model|, in order.
+	Embed(ctx context.Context, model string, input []string, opts ...RequestOption) ([][]float64, error)
+}
+
+var _ Provider = (*Client)(nil)
+
+// Complete implements the Provider interface. It is equivalent to CreateFineTunedCompletion.
+func (c *Client) Complete(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error) {
+	return c.CreateFineTunedCompletion(ctx, cr, opts...)
+}
+
+// Chat implements the Provider interface. It is equivalent to CreateFineTunedChatCompletion.
+func (c *Client) Chat(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error) {
+	return c.CreateFineTunedChatCompletion(ctx, cr, opts...)
+}
+
+// Stream implements the Provider interface. It is equivalent to CreateFineTunedChatCompletionStream.
+func (c *Client) Stream(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (ChatStream, error) {
+	var s, err = c.CreateFineTunedChatCompletionStream(ctx, cr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Embed implements the Provider interface. |model| need not be one of the constants of the models package (see
+// WithRequestModel).
+func (c *Client) Embed(ctx context.Context, model string, input []string, opts ...RequestOption) ([][]float64, error) {
+	var resp, err = c.CreateEmbeddings(ctx, &EmbeddingRequest{Input: input}, append(opts, WithRequestModel(model))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Vectors(), nil
+}
+
+// ProviderFuncs adapts functions to the Provider interface, e.g. to wrap the client of another provider, or to stub a
+// Provider in tests. Each method calls the corresponding function, or returns ErrUnsupported if it is nil.
+type ProviderFuncs struct {
+	CompleteFunc func(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error)
+	ChatFunc     func(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error)
+	StreamFunc   func(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (ChatStream, error)
+	EmbedFunc    func(ctx context.Context, model string, input []string, opts ...RequestOption) ([][]float64, error)
+}
+
+var _ Provider = (*ProviderFuncs)(nil)
+
+// Complete implements the Provider interface.
+func (p *ProviderFuncs) Complete(ctx context.Context, cr *CompletionRequest[models.FineTunedModel], opts ...RequestOption) (*CompletionResponse[models.FineTunedModel], error) {
+	if p.CompleteFunc == nil {
+		return nil, ErrUnsupported
+	}
+
+	return p.CompleteFunc(ctx, cr, opts...)
+}
+
+// Chat implements the Provider interface.
+func (p *ProviderFuncs) Chat(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (*ChatCompletionResponse[models.FineTunedModel], error) {
+	if p.ChatFunc == nil {
+		return nil, ErrUnsupported
+	}
+
+	return p.ChatFunc(ctx, cr, opts...)
+}
+
+// Stream implements the Provider interface.
+func (p *ProviderFuncs) Stream(ctx context.Context, cr *ChatCompletionRequest[models.FineTunedModel], opts ...RequestOption) (ChatStream, error) {
+	if p.StreamFunc == nil {
+		return nil, ErrUnsupported
+	}
+
+	return p.StreamFunc(ctx, cr, opts...)
+}
+
+// Embed implements the Provider interface.
+func (p *ProviderFuncs) Embed(ctx context.Context, model string, input []string, opts ...RequestOption) ([][]float64, error) {
+	if p.EmbedFunc == nil {
+		return nil, ErrUnsupported
+	}
+
+	return p.EmbedFunc(ctx, model, input, opts...)
+}
@@ -0,0 +1,86 @@
+package openai
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimits contains the values of the x-ratelimit-* headers returned by the API. Fields are zero if the
+// corresponding header was absent or malformed.
+type RateLimits struct {
+	// LimitRequests is the maximum number of requests permitted before exhausting the rate limit.
+	LimitRequests int
+	// LimitTokens is the maximum number of tokens permitted before exhausting the rate limit.
+	LimitTokens int
+	// RemainingRequests is the number of requests remaining before exhausting the rate limit.
+	RemainingRequests int
+	// RemainingTokens is the number of tokens remaining before exhausting the rate limit.
+	RemainingTokens int
+	// ResetRequests is the time until the request rate limit resets to its initial state.
+	ResetRequests time.Duration
+	// ResetTokens is the time until the token rate limit resets to its initial state.
+	ResetTokens time.Duration
+}
+
+// parseRateLimits parses the x-ratelimit-* headers from |h|.
+func parseRateLimits(h http.Header) RateLimits {
+	var atoi = func(k string) int {
+		var n, _ = strconv.Atoi(h.Get(k))
+		return n
+	}
+
+	var duration = func(k string) time.Duration {
+		var d, _ = time.ParseDuration(h.Get(k))
+		return d
+	}
+
+	return RateLimits{
+		LimitRequests:     atoi("x-ratelimit-limit-requests"),
+		LimitTokens:       atoi("x-ratelimit-limit-tokens"),
+		RemainingRequests: atoi("x-ratelimit-remaining-requests"),
+		RemainingTokens:   atoi("x-ratelimit-remaining-tokens"),
+		ResetRequests:     duration("x-ratelimit-reset-requests"),
+		ResetTokens:       duration("x-ratelimit-reset-tokens"),
+	}
+}
+
+// parseRetryAfter returns the delay requested by the retry-after-ms or Retry-After headers of |h|, or 0 if neither is
+// present. Retry-After may be either a number of seconds or an HTTP date.
+func parseRetryAfter(h http.Header) time.Duration {
+	if ms, err := strconv.ParseFloat(h.Get("retry-after-ms"), 64); err == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+
+	var v = h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.ParseFloat(v, 64); err == nil && s > 0 {
+		return time.Duration(s * float64(time.Second))
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// RateLimitError is returned when the API responds with a 429 status code. It wraps the underlying *APIError, so
+// errors.As(err, &apiErr) and errors.Is(err, ErrRateLimit) both continue to work.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is the delay requested by the server before retrying. It is 0 if the server did not specify one.
+	RetryAfter time.Duration
+	// Limits contains the rate limit state at the time of the error.
+	Limits RateLimits
+}
+
+// Unwrap returns the underlying *APIError.
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// bytesPerToken is the approximate number of bytes of English text per token, used to estimate the number of tokens
+// consumed by a request.
+const bytesPerToken = 4
+
+// RateLimit configures client-side rate limiting (see WithRateLimit). A zero field disables the corresponding limit.
+type RateLimit struct {
+	// RequestsPerMinute is the maximum number of requests sent per minute.
+	RequestsPerMinute int
+	// TokensPerMinute is the maximum number of tokens sent per minute. The number of tokens in a request is estimated
+	// from the size of its body (roughly 4 bytes per token), so this limit should be set conservatively.
+	TokensPerMinute int
+}
+
+// rateLimiter enforces a RateLimit using a pair of token buckets.
+type rateLimiter struct {
+	requests, tokens *bucket
+}
+
+// newRateLimiter returns a *rateLimiter which enforces |rl|.
+func newRateLimiter(rl RateLimit) *rateLimiter {
+	return &rateLimiter{
+		requests: newBucket(rl.RequestsPerMinute),
+		tokens:   newBucket(rl.TokensPerMinute),
+	}
+}
+
+// wait blocks until |req| may be sent without exceeding the rate limit, or until its context is done.
+func (l *rateLimiter) wait(req *http.Request) error {
+	var ctx = req.Context()
+	if err := l.requests.wait(ctx, 1); err != nil {
+		return err
+	}
+
+	if req.ContentLength <= 0 {
+		return nil
+	}
+
+	return l.tokens.wait(ctx, float64(req.ContentLength)/bytesPerToken)
+}
+
+// bucket is a token bucket which is refilled continuously at a fixed rate, up to its capacity. A nil *bucket imposes
+// no limit.
+type bucket struct {
+	mu sync.Mutex
+	// capacity is the maximum number of tokens, and rate is the number of tokens added per second.
+	capacity, rate float64
+	// tokens is the number of tokens available at |last|. It is negative if callers are waiting on reserved tokens.
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full *bucket which holds |perMinute| tokens and is refilled over a minute, or nil if |perMinute|
+// is not positive.
+func newBucket(perMinute int) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return &bucket{
+		capacity: float64(perMinute),
+		rate:     float64(perMinute) / time.Minute.Seconds(),
+		tokens:   float64(perMinute),
+		last:     time.Now(),
+	}
+}
+
+// wait reserves |n| tokens and blocks until they are available or |ctx| is done. |n| is capped at the capacity of the
+// bucket so that oversized requests are not blocked forever. If |ctx| is done first, the reservation is returned.
+func (b *bucket) wait(ctx context.Context, n float64) error {
+	if b == nil {
+		return nil
+	}
+
+	if n > b.capacity {
+		n = b.capacity
+	}
+
+	var delay = b.reserve(n)
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		b.mu.Lock()
+		b.tokens += n
+		b.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// reserve removes |n| tokens from the bucket and returns how long the caller must wait until they are available.
+func (b *bucket) reserve(n float64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var now = time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,54 @@
+// Package reasoning contains the enum values which configure
+// how reasoning models think before they respond.
+package reasoning
+
+// Effort represents the enum values for the effort which reasoning
+// models spend reasoning before responding.
+type Effort int
+
+const (
+	// EffortInvalid represents an invalid Effort option.
+	EffortInvalid Effort = iota
+	// EffortLow favors speed and economical token usage.
+	EffortLow
+	// EffortMedium balances speed and reasoning accuracy.
+	EffortMedium
+	// EffortHigh favors more complete reasoning, at the cost of more
+	// tokens and slower responses.
+	EffortHigh
+)
+
+// String implements the fmt.Stringer interface.
+func (e Effort) String() string {
+	return effortToString[e]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e Effort) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |e| to EffortInvalid.
+func (e *Effort) UnmarshalText(b []byte) error {
+	if val, ok := stringToEffort[(string(b))]; ok {
+		*e = val
+		return nil
+	}
+
+	*e = EffortInvalid
+
+	return nil
+}
+
+var effortToString = map[Effort]string{
+	EffortLow:    "low",
+	EffortMedium: "medium",
+	EffortHigh:   "high",
+}
+
+var stringToEffort = map[string]Effort{
+	"low":    EffortLow,
+	"medium": EffortMedium,
+	"high":   EffortHigh,
+}
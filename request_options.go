@@ -0,0 +1,141 @@
+package openai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption configures a single request, overriding the defaults of the Client. Every endpoint method accepts a
+// variadic list of RequestOptions.
+type RequestOption func(rc *requestConfig)
+
+// requestConfig contains the settings for a single request. It is initialized from the Client and then modified by
+// any RequestOptions.
+type requestConfig struct {
+	timeout     time.Duration
+	header      http.Header
+	baseURL     *url.URL
+	retry       RetryPolicy
+	retryBudget time.Duration
+	// route is the route of the request, without its query string.
+	route string
+	// model is the model of the request, used to route it to an Azure deployment and to record metrics.
+	model string
+	// modelOverride replaces the model of a JSON request (see WithRequestModel).
+	modelOverride string
+	// query is added to the query string of the request (see WithListOptions).
+	query url.Values
+	// progress is called as the body of the request is sent (see WithUploadProgress).
+	progress ProgressFunc
+	// user is the end user of the request, used to aggregate usage (see WithUsageTracker).
+	user string
+	// failover is set if the request is sent to the first healthy of the Client's base URLs (see WithBaseURLs).
+	failover bool
+	// pooledKey is set if the request is authenticated with a key from the Client's key pool.
+	pooledKey bool
+	// err is set if a RequestOption is invalid, and is returned before the request is sent.
+	err error
+}
+
+// newRequestConfig returns the *requestConfig for a request made with |opts|.
+func (c *Client) newRequestConfig(opts []RequestOption) *requestConfig {
+	var rc = &requestConfig{
+		header:      http.Header{},
+		baseURL:     c.baseURL,
+		err:         c.baseURLErr,
+		retry:       c.retry,
+		retryBudget: c.retryBudget,
+		failover:    c.failover != nil && c.azure == nil,
+	}
+
+	for _, opt := range opts {
+		opt(rc)
+	}
+
+	return rc
+}
+
+// WithRequestTimeout sets a deadline of |d| for the request, including any retries. For streamed responses, the
+// deadline also covers reading the stream.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(rc *requestConfig) {
+		rc.timeout = d
+	}
+}
+
+// WithRequestHeader sets the header |key| to |value| on the request, replacing any value set by the Client.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(rc *requestConfig) {
+		rc.header.Set(key, value)
+	}
+}
+
+// WithRequestBaseURL sends the request to |u| (e.g. "https://gateway.example.com/openai/v1") rather than the OpenAI
+// API. The route of the endpoint is appended to the path of |u|.
+func WithRequestBaseURL(u string) RequestOption {
+	return func(rc *requestConfig) {
+		rc.baseURL, rc.err = url.Parse(u)
+		rc.failover = false
+	}
+}
+
+// WithRequestRetryPolicy retries the request according to |p|, replacing the RetryPolicy of the Client. If |p| is nil,
+// the request is not retried.
+func WithRequestRetryPolicy(p RetryPolicy) RequestOption {
+	return func(rc *requestConfig) {
+		rc.retry = p
+		rc.retryBudget = 0
+	}
+}
+
+// WithListOptions paginates the results of a list endpoint with |lo|, e.g. ListFiles or ListFineTuningJobs. Use the
+// LastID of the returned List as |lo|.After to fetch the next page while HasMore is true.
+func WithListOptions(lo *ListOptions) RequestOption {
+	return func(rc *requestConfig) {
+		if rc.query == nil {
+			rc.query = url.Values{}
+		}
+		for k, v := range lo.values() {
+			rc.query[k] = v
+		}
+	}
+}
+
+// WithUploadProgress calls |fn| as the body of the request is sent, e.g. to report the progress of uploading a large
+// file with UploadFile or CreateTranscription. The total is the size of the encoded form, which slightly exceeds that
+// of the file. |fn| is called on the goroutine which sends the request, and counts bytes as they are written to the
+// connection, not as they are acknowledged by the server. If the request is retried, progress starts again from 0; with
+// UploadParts, the progress of each part is reported separately. If the context of the request is cancelled, the
+// upload stops immediately. The form is encoded in memory before it is sent, so reading the file is not reported, and
+// the whole file is held in memory for the duration of the request; use UploadParts for files too large for that.
+func WithUploadProgress(fn ProgressFunc) RequestOption {
+	return func(rc *requestConfig) {
+		rc.progress = fn
+	}
+}
+
+// WithRequestModel sends the request for |model| rather than the model set on it, e.g. to use a model which is not one
+// of the constants of the models package with a self-hosted server (see WithCompatibilityMode). It applies to JSON
+// requests only; the model of a file upload cannot be replaced.
+func WithRequestModel(model string) RequestOption {
+	return func(rc *requestConfig) {
+		rc.modelOverride = model
+	}
+}
+
+// replaceJSONModel returns the JSON encoded request body |b| with its "model" field set to |model|.
+func replaceJSONModel(b []byte, model string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if fields["model"], err = json.Marshal(model); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
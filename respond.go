@@ -0,0 +1,142 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/fabiustech/openai/models"
+)
+
+var (
+	// ErrRefusal is matched (via errors.Is) by a *RefusalError.
+	ErrRefusal = errors.New("openai: model refused to respond")
+	// ErrTruncated is returned by Respond if the model stopped generating before completing its response, e.g.
+	// because it reached max_tokens; the partial response cannot be decoded.
+	ErrTruncated = errors.New("openai: response truncated")
+	// ErrInvalidJSON is returned by RespondJSON if the model repeatedly fails to produce valid JSON.
+	ErrInvalidJSON = errors.New("openai: response is not valid JSON")
+)
+
+// RefusalError is returned by Respond when the model refuses to respond in the requested format, e.g. for safety
+// reasons.
+type RefusalError struct {
+	// Refusal is the refusal message generated by the model.
+	Refusal string
+}
+
+// Error implements the error interface.
+func (e *RefusalError) Error() string {
+	return "openai: model refused to respond: " + e.Refusal
+}
+
+// Is reports whether |target| is ErrRefusal.
+func (e *RefusalError) Is(target error) bool {
+	return target == ErrRefusal
+}
+
+// Respond sends the conversation in |req|, which is not modified, with a strict ResponseFormat derived from T (see
+// ResponseFormatFor), and decodes the model's response into a T:
+//
+//	type Answer struct {
+//		City    string `json:"city"`
+//		Country string `json:"country"`
+//	}
+//	var answer, err = openai.Respond[Answer](ctx, client, &openai.ChatCompletionRequest[models.Chat]{
+//		Model:    models.GPT4o,
+//		Messages: []*openai.ChatMessage{openai.UserMessage("Where is the Eiffel Tower?")},
+//	})
+//
+// If |req| already has a ResponseFormat, it is used as is. A refusal is returned as a *RefusalError, and a response
+// which was cut short as ErrTruncated.
+func Respond[T any](ctx context.Context, api ChatAPI, req *ChatCompletionRequest[models.Chat], opts ...RequestOption) (T, error) {
+	var v T
+
+	var cr = *req
+	if cr.ResponseFormat == nil {
+		var err error
+		if cr.ResponseFormat, err = ResponseFormatFor[T](formatName(reflect.TypeOf(&v).Elem())); err != nil {
+			return v, err
+		}
+	}
+
+	var content, err = respond(ctx, api, &cr, opts...)
+	if err != nil {
+		return v, err
+	}
+
+	if err = json.Unmarshal([]byte(content), &v); err != nil {
+		return v, fmt.Errorf("openai: decoding response into %T: %w", v, err)
+	}
+
+	return v, nil
+}
+
+// RespondJSON sends the conversation in |req|, which is not modified, in JSON mode (see ResponseFormatJSONObject),
+// and returns the JSON generated by the model. If the model's response is not valid JSON, the request is retried
+// once before returning ErrInvalidJSON. As with Respond, a refusal is returned as a *RefusalError, and a response
+// which was cut short as ErrTruncated.
+func RespondJSON(ctx context.Context, api ChatAPI, req *ChatCompletionRequest[models.Chat], opts ...RequestOption) (json.RawMessage, error) {
+	var cr = *req
+	if cr.ResponseFormat == nil {
+		cr.ResponseFormat = ResponseFormatJSONObject
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		var content, err = respond(ctx, api, &cr, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if json.Valid([]byte(content)) {
+			return json.RawMessage(content), nil
+		}
+	}
+
+	return nil, ErrInvalidJSON
+}
+
+// respond sends |cr| with |api|, and returns the content of the first choice of the response.
+func respond(ctx context.Context, api ChatAPI, cr *ChatCompletionRequest[models.Chat], opts ...RequestOption) (string, error) {
+	var resp, err = api.CreateChatCompletion(ctx, cr, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 || resp.Choices[0].Message == nil {
+		return "", errors.New("openai: response has no choices")
+	}
+
+	var choice = resp.Choices[0]
+	switch {
+	case choice.Message.Refusal != "":
+		return "", &RefusalError{Refusal: choice.Message.Refusal}
+	case choice.FinishReason == "length":
+		return "", ErrTruncated
+	}
+
+	return choice.Message.Content, nil
+}
+
+// invalidNameChars matches the characters which are not allowed in a response format name.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// formatName returns the response format name of |t|: its type name with any invalid characters replaced, or
+// "response" for unnamed types.
+func formatName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var name = invalidNameChars.ReplaceAllString(t.Name(), "_")
+	if name == "" {
+		return "response"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+
+	return name
+}
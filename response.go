@@ -0,0 +1,66 @@
+package openai
+
+import (
+	"net/http"
+)
+
+// requestIDHeader is the header in which the API returns the unique ID of each request.
+const requestIDHeader = "x-request-id"
+
+// ResponseMeta contains metadata about the HTTP response from which a value was decoded. It is embedded in the
+// top-level response types of the API; it is empty for values which were not returned directly from a request (e.g.
+// the elements of a List).
+type ResponseMeta struct {
+	// RequestID is the unique ID assigned to the request by OpenAI. Include it when contacting support.
+	RequestID string `json:"-"`
+	// Cost is the cost of the request in US dollars, computed from its token usage (see WithPricing). It is nil if the
+	// Client has no Pricing, or if the response has no usage or its model has no price.
+	Cost *float64 `json:"-"`
+	// Cached is true if the response was returned from a cache (see WithCache and WithSemanticCache) rather than by the
+	// API. Cached responses have no RequestID or Cost.
+	Cached bool `json:"-"`
+}
+
+// meta returns a pointer to |m|, allowing response.decode to populate it.
+func (m *ResponseMeta) meta() *ResponseMeta {
+	return m
+}
+
+// response is a successful response from the API.
+type response struct {
+	body      []byte
+	requestID string
+	cost      *float64
+	cached    bool
+	// codec decodes the body. If nil, StdCodec is used.
+	codec Codec
+}
+
+// newResponse returns a *response containing the body |b| of |resp|.
+func newResponse(resp *http.Response, b []byte) *response {
+	return &response{
+		body:      b,
+		requestID: resp.Header.Get(requestIDHeader),
+	}
+}
+
+// decode unmarshals the response body into |v|. If |v| embeds ResponseMeta, it is populated as well.
+func (r *response) decode(v any) error {
+	if err := codecOrDefault(r.codec).Unmarshal(r.body, v); err != nil {
+		return err
+	}
+	r.setMeta(v)
+
+	return nil
+}
+
+// setMeta populates the ResponseMeta of |v|, if it embeds one. It is called by decode, and directly for responses
+// whose body is not JSON.
+func (r *response) setMeta(v any) {
+	if m, ok := v.(interface{ meta() *ResponseMeta }); ok {
+		var meta = m.meta()
+		meta.RequestID = r.requestID
+		meta.Cost = r.cost
+		meta.Cached = r.cached
+	}
+}
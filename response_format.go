@@ -0,0 +1,157 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fabiustech/openai/jsonschema"
+)
+
+// ResponseFormat specifies the format of the content generated by the model. Use ResponseFormatText,
+// ResponseFormatJSONObject, or ResponseFormatJSONSchema (or, for a Go type, ResponseFormatFor).
+type ResponseFormat struct {
+	typ    string
+	schema *JSONSchema
+}
+
+var (
+	// ResponseFormatText means the model generates plain text. This is the default.
+	ResponseFormatText = &ResponseFormat{typ: "text"}
+	// ResponseFormatJSONObject enables JSON mode, which ensures the message generated by the model is valid JSON. The
+	// model must also be instructed to produce JSON, via a system or user message which mentions "JSON".
+	ResponseFormatJSONObject = &ResponseFormat{typ: "json_object"}
+)
+
+// JSONSchema describes the JSON Schema which the content generated by the model must match (see Structured Outputs).
+type JSONSchema struct {
+	// Name is the name of the response format. Must be a-z, A-Z, 0-9, or contain underscores and dashes, with a maximum
+	// length of 64.
+	Name string `json:"name"`
+	// Description is a description of what the response format is for, used by the model to determine how to respond
+	// in the format.
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON Schema of the response.
+	Schema any `json:"schema,omitempty"`
+	// Strict enables strict schema adherence: the model always follows the exact schema. Only a subset of JSON Schema
+	// is supported in strict mode; notably, every object must set additionalProperties to false and require all of its
+	// properties.
+	// Defaults to false.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ResponseFormatJSONSchema returns a *ResponseFormat which constrains the model to generate JSON matching |s|.
+func ResponseFormatJSONSchema(s *JSONSchema) *ResponseFormat {
+	return &ResponseFormat{typ: "json_schema", schema: s}
+}
+
+// ResponseFormatFor returns a *ResponseFormat named |name| which constrains the model to generate JSON matching the
+// strict JSON Schema of T (see the jsonschema package). Optional fields of T (those tagged omitempty) are made
+// nullable, as strict mode requires every property.
+func ResponseFormatFor[T any](name string) (*ResponseFormat, error) {
+	var schema, err = jsonschema.For[T](&jsonschema.Options{Strict: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return ResponseFormatJSONSchema(&JSONSchema{Name: name, Schema: schema, Strict: true}), nil
+}
+
+// JSONSchema returns the JSON Schema of |rf|, or nil if it does not have one.
+func (rf *ResponseFormat) JSONSchema() *JSONSchema {
+	if rf == nil {
+		return nil
+	}
+
+	return rf.schema
+}
+
+// responseFormat is the JSON encoding of a ResponseFormat.
+type responseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (rf *ResponseFormat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&responseFormat{Type: rf.typ, JSONSchema: rf.schema})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (rf *ResponseFormat) UnmarshalJSON(b []byte) error {
+	var v responseFormat
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*rf = ResponseFormat{typ: v.Type, schema: v.JSONSchema}
+
+	return nil
+}
+
+// formatNamePattern matches valid response format names.
+var formatNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// validate returns an error if |rf| would be rejected by the API for a conversation of |messages|.
+func (rf *ResponseFormat) validate(messages []*ChatMessage) error {
+	if rf == nil {
+		return nil
+	}
+
+	if rf.typ == "json_object" {
+		for _, m := range messages {
+			if strings.Contains(strings.ToLower(m.Text()), "json") {
+				return nil
+			}
+		}
+		return errors.New("openai: json_object response format requires a message which mentions JSON")
+	}
+
+	if rf.typ != "json_schema" {
+		return nil
+	}
+
+	if rf.schema == nil {
+		return errors.New("openai: json_schema response format has no schema")
+	}
+	if !formatNamePattern.MatchString(rf.schema.Name) {
+		return fmt.Errorf("openai: invalid response format name %q", rf.schema.Name)
+	}
+
+	if s, ok := rf.schema.Schema.(*jsonschema.Schema); ok && rf.schema.Strict {
+		return validateStrict(s, "#")
+	}
+
+	return nil
+}
+
+// validateStrict returns an error if |s|, at |path|, does not meet the requirements of strict mode: every object must
+// disallow additional properties, and require all of its properties.
+func validateStrict(s *jsonschema.Schema, path string) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Properties != nil {
+		if s.AdditionalProperties != false {
+			return fmt.Errorf("openai: strict schema object %s must set additionalProperties to false", path)
+		}
+
+		var required = map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+
+		for name, p := range s.Properties {
+			if !required[name] {
+				return fmt.Errorf("openai: strict schema object %s must require property %q", path, name)
+			}
+			if err := validateStrict(p, path+"/properties/"+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validateStrict(s.Items, path+"/items")
+}
@@ -0,0 +1,148 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 8 * time.Second
+)
+
+// RetryPolicy decides whether, and after what delay, a failed request should be retried (see WithRetryPolicy).
+type RetryPolicy interface {
+	// ShouldRetry is called after the |attempt|th attempt (starting at 1) of a request fails with |err|. |resp| is the
+	// response returned by the API, or nil if the request failed without one (e.g. due to a connection error); its
+	// body has already been read and closed, and the error it contained is returned as |err|. ShouldRetry returns the
+	// delay before the next attempt and true if the request should be retried.
+	ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool)
+}
+
+// RetryPolicyFunc is an adapter which allows the use of an ordinary function as a RetryPolicy.
+type RetryPolicyFunc func(resp *http.Response, err error, attempt int) (time.Duration, bool)
+
+// ShouldRetry implements the RetryPolicy interface by calling f(resp, err, attempt).
+func (f RetryPolicyFunc) ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	return f(resp, err, attempt)
+}
+
+// RetryConfig configures the automatic retry of failed requests (see WithRetry). It is the default RetryPolicy.
+// Requests are retried if they fail with a connection reset or a timeout, or if the API responds with a 429 (unless the
+// quota of the account is exhausted), 500, 502, or 503 status code. The delay between attempts grows exponentially with
+// jitter, unless the server requests a specific delay with a Retry-After header.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Defaults to 3.
+	MaxAttempts int
+	// MaxElapsed is the maximum amount of time to spend on a request, including all attempts and the delays between
+	// them. A retry is not attempted if its delay would exceed the budget.
+	// Defaults to no limit.
+	MaxElapsed time.Duration
+	// InitialBackoff is the delay before the first retry.
+	// Defaults to 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between attempts.
+	// Defaults to 8s.
+	MaxBackoff time.Duration
+}
+
+// ShouldRetry implements the RetryPolicy interface.
+func (rc *RetryConfig) ShouldRetry(_ *http.Response, err error, attempt int) (time.Duration, bool) {
+	var attempts = rc.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+
+	if attempt >= attempts || !retryable(err) {
+		return 0, false
+	}
+
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) && rlErr.RetryAfter > 0 {
+		return rlErr.RetryAfter, true
+	}
+
+	return rc.backoff(attempt), true
+}
+
+// backoff returns the delay before the retry following |attempt| attempts: an exponentially increasing delay, capped at
+// MaxBackoff, of which up to half is random jitter.
+func (rc *RetryConfig) backoff(attempt int) time.Duration {
+	var initial, limit = rc.InitialBackoff, rc.MaxBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	if limit <= 0 {
+		limit = defaultMaxBackoff
+	}
+
+	var d = initial
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryable returns true if |err| is a retryable *APIError, or a transient transport error: a connection reset, a
+// connection closed mid-response, or a timeout. Other transport errors (e.g. TLS or DNS failures) are not retried.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// rewind prepares |req| to be sent again. It returns false if the body of |req| cannot be replayed.
+func rewind(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+
+	if req.GetBody == nil {
+		return false
+	}
+
+	var body, err = req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+
+	return true
+}
+
+// sleep blocks for |d| or until |ctx| is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	var t = time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+// Package roles contains the enum values which represent the roles
+// of the authors of messages in a chat conversation.
+package roles
+
+// Role represents the enum values for the author of a chat message.
+type Role int
+
+const (
+	// Invalid represents an invalid Role option.
+	Invalid Role = iota
+	// System is the role of messages which instruct the model how to behave.
+	System
+	// User is the role of messages written by the end user.
+	User
+	// Assistant is the role of messages generated by the model.
+	Assistant
+	// Tool is the role of messages which return the result of a tool call to the model.
+	Tool
+	// Developer is the role of messages which instruct the model how to behave, replacing System for reasoning
+	// models. Instructions in developer messages take precedence over user messages.
+	Developer
+)
+
+// String implements the fmt.Stringer interface.
+func (r Role) String() string {
+	return roleToString[r]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Role) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |r| to Invalid.
+func (r *Role) UnmarshalText(b []byte) error {
+	if val, ok := stringToRole[(string(b))]; ok {
+		*r = val
+		return nil
+	}
+
+	*r = Invalid
+
+	return nil
+}
+
+var roleToString = map[Role]string{
+	System:    "system",
+	User:      "user",
+	Assistant: "assistant",
+	Tool:      "tool",
+	Developer: "developer",
+}
+
+var stringToRole = map[string]Role{
+	"system":    System,
+	"user":      User,
+	"assistant": Assistant,
+	"tool":      Tool,
+	"developer": Developer,
+}
@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"path"
+	"strings"
+)
+
+// modelRoute sends the requests for models matching pattern to client.
+type modelRoute struct {
+	pattern string
+	client  *Client
+}
+
+// match reports whether |model| matches the pattern of the route. Fine-tuned models (e.g. "ft:gpt-4o-mini:org::id")
+// also match by their base model.
+func (r *modelRoute) match(model string) bool {
+	if ok, _ := path.Match(r.pattern, model); ok {
+		return true
+	}
+	if !strings.HasPrefix(model, "ft:") {
+		return false
+	}
+
+	var base, _, _ = strings.Cut(strings.TrimPrefix(model, "ft:"), ":")
+	var ok, _ = path.Match(r.pattern, base)
+
+	return ok
+}
+
+// modelRequest is a request which can be routed by its model (see WithModelRoute).
+type modelRequest interface {
+	model() string
+}
+
+// routed returns the Client which sends |payload| with |rc|: the Client of the first route matching its model, or |c|
+// if no route matches (see WithModelRoute).
+func (c *Client) routed(payload any, rc *requestConfig) *Client {
+	if len(c.routes) == 0 {
+		return c
+	}
+
+	var model = rc.modelOverride
+	if mr, ok := payload.(modelRequest); ok && model == "" {
+		model = mr.model()
+	}
+	if model == "" {
+		return c
+	}
+	for _, r := range c.routes {
+		if r.match(model) {
+			return r.client
+		}
+	}
+
+	return c
+}
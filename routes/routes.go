@@ -1,7 +1,32 @@
 // Package routes contains constants for all OpenAI endpoint routes.
 package routes
 
+import (
+	"strings"
+)
+
 const (
+	audioBase = "audio/"
+
+	// Assistants is the route for the assistants endpoint.
+	// https://platform.openai.com/docs/api-reference/assistants
+	Assistants = "assistants"
+
+	// AudioTranscriptions is the route for the create transcription endpoint.
+	// https://platform.openai.com/docs/api-reference/audio/create
+	AudioTranscriptions = audioBase + "transcriptions"
+	// AudioSpeech is the route for the create speech endpoint.
+	// https://platform.openai.com/docs/api-reference/audio/createSpeech
+	AudioSpeech = audioBase + "speech"
+
+	// Batches is the route for the batches endpoint.
+	// https://platform.openai.com/docs/api-reference/batch
+	Batches = "batches"
+
+	// ChatCompletions is the route for the chat completions endpoint.
+	// https://platform.openai.com/docs/api-reference/chat
+	ChatCompletions = "chat/completions"
+
 	// Completions is the route for the completions endpoint.
 	// https://beta.openai.com/docs/api-reference/completions
 	Completions = "completions"
@@ -25,6 +50,13 @@ const (
 	// https://beta.openai.com/docs/api-reference/fine-tunes
 	FineTunes = "fines-tunes"
 
+	// FineTuningJobs is the route for the fine-tuning jobs endpoint.
+	// https://platform.openai.com/docs/api-reference/fine-tuning
+	FineTuningJobs = "fine_tuning/jobs"
+	// FineTuningCheckpoints is the route for the fine-tuning checkpoint permissions endpoints.
+	// https://platform.openai.com/docs/api-reference/fine-tuning/create-permission
+	FineTuningCheckpoints = "fine_tuning/checkpoints"
+
 	imagesBase = "images/"
 
 	// ImageGenerations is the route for the create images endpoint.
@@ -37,7 +69,59 @@ const (
 	// https://beta.openai.com/docs/api-reference/images/create-variation
 	ImageVariations = imagesBase + "variations"
 
+	// Models is the route for the models endpoint.
+	// https://platform.openai.com/docs/api-reference/models
+	Models = "models"
+
 	// Moderations is the route for the moderations endpoint.
 	// https://beta.openai.com/docs/api-reference/moderations
 	Moderations = "moderations"
+
+	organizationBase = "organization/"
+
+	// OrganizationCosts is the route for the organization costs endpoint.
+	// https://platform.openai.com/docs/api-reference/usage/costs
+	OrganizationCosts = organizationBase + "costs"
+	// OrganizationAuditLogs is the route for the organization audit logs endpoint.
+	// https://platform.openai.com/docs/api-reference/audit-logs
+	OrganizationAuditLogs = organizationBase + "audit_logs"
+	// OrganizationProjects is the route for the organization projects endpoint.
+	// https://platform.openai.com/docs/api-reference/projects
+	OrganizationProjects = organizationBase + "projects"
+	// OrganizationInvites is the route for the organization invites endpoint.
+	// https://platform.openai.com/docs/api-reference/invite
+	OrganizationInvites = organizationBase + "invites"
+
+	// Uploads is the route for the uploads endpoint.
+	// https://platform.openai.com/docs/api-reference/uploads
+	Uploads = "uploads"
+
+	// VectorStores is the route for the vector stores endpoint.
+	// https://platform.openai.com/docs/api-reference/vector-stores
+	VectorStores = "vector_stores"
 )
+
+// assistantsBeta is the value of the OpenAI-Beta header required by the Assistants API.
+const assistantsBeta = "assistants=v2"
+
+// beta maps the top level routes which require an OpenAI-Beta header to the header's value.
+var beta = map[string]string{
+	Assistants:   assistantsBeta,
+	VectorStores: assistantsBeta,
+}
+
+// Beta returns the value of the OpenAI-Beta header required by |route|, if any.
+func Beta(route string) (string, bool) {
+	var base, _, _ = strings.Cut(route, "?")
+	base, _, _ = strings.Cut(base, "/")
+
+	var v, ok = beta[base]
+
+	return v, ok
+}
+
+// Admin returns true if |route| is an organization administration route, or a checkpoint permissions route, which must
+// be authenticated with an admin API key.
+func Admin(route string) bool {
+	return strings.HasPrefix(route, organizationBase) || strings.HasPrefix(route, FineTuningCheckpoints+"/")
+}
@@ -0,0 +1,221 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// ErrMaxTurns is returned by Runner.Run if the model has not produced a final answer within the maximum number of
+// turns.
+var ErrMaxTurns = errors.New("openai: maximum number of turns reached without a final answer")
+
+// ToolFunc executes a call to a tool with the JSON encoded |arguments| generated by the model, and returns the result
+// to send back to the model.
+type ToolFunc func(ctx context.Context, arguments string) (string, error)
+
+// registeredTool is a tool registered with a Runner.
+type registeredTool struct {
+	tool *Tool
+	fn   ToolFunc
+}
+
+// Runner runs a chat conversation in which the model may call Go functions registered as tools: it sends the request,
+// executes any tool calls in the response, appends their results to the conversation, and repeats until the model
+// produces a final answer. Tools are registered with Register or RegisterFunc; a Runner should not be modified while
+// it is running.
+type Runner struct {
+	// MaxTurns is the maximum number of chat completion requests made by a single Run.
+	// Defaults to 10.
+	MaxTurns int
+	// ToolTimeout limits the duration of each tool call. Its context is canceled when it expires.
+	// Defaults to no timeout.
+	ToolTimeout time.Duration
+
+	api   ChatAPI
+	tools map[string]*registeredTool
+	order []string
+}
+
+// NewRunner returns a *Runner which sends chat completion requests with |api| (e.g. a *Client).
+func NewRunner(api ChatAPI) *Runner {
+	return &Runner{api: api, tools: map[string]*registeredTool{}}
+}
+
+// Register registers the function described by |f|, which is executed by |fn|. Registering a function with an existing
+// name replaces it.
+func (r *Runner) Register(f *FunctionDefinition, fn ToolFunc) {
+	if _, ok := r.tools[f.Name]; !ok {
+		r.order = append(r.order, f.Name)
+	}
+
+	r.tools[f.Name] = &registeredTool{tool: FunctionTool(f), fn: fn}
+}
+
+// RegisterFunc registers |fn| with |r| as the function named |name|, described by |description|. Its parameters are
+// described by the JSON Schema of T (see NewFunctionTool), and the arguments generated by the model are decoded with
+// DecodeArguments. The result of |fn| is sent to the model as is if it is a string, and encoded as JSON otherwise.
+func RegisterFunc[T any](r *Runner, name, description string, fn func(ctx context.Context, args T) (any, error)) error {
+	var tool, err = NewFunctionTool[T](name, description)
+	if err != nil {
+		return err
+	}
+
+	r.Register(tool.Function, func(ctx context.Context, arguments string) (string, error) {
+		var args, err = DecodeArguments[T](&FunctionCall{Name: name, Arguments: arguments})
+		if err != nil {
+			return "", err
+		}
+
+		var res any
+		if res, err = fn(ctx, args); err != nil {
+			return "", err
+		}
+
+		if s, ok := res.(string); ok {
+			return s, nil
+		}
+
+		var b []byte
+		if b, err = json.Marshal(res); err != nil {
+			return "", err
+		}
+
+		return string(b), nil
+	})
+
+	return nil
+}
+
+// RunResult is the result of a Runner.Run.
+type RunResult struct {
+	// Messages is the full conversation, including the request's messages, the tool calls and their results, and the
+	// final answer.
+	Messages []*ChatMessage
+	// Response is the final response from the model.
+	Response *ChatCompletionResponse[models.Chat]
+	// Turns is the number of chat completion requests made.
+	Turns int
+	// Usage is the total token usage of all requests.
+	Usage Usage
+}
+
+// Answer returns the content of the final answer.
+func (rr *RunResult) Answer() string {
+	if len(rr.Messages) == 0 {
+		return ""
+	}
+
+	return rr.Messages[len(rr.Messages)-1].Content
+}
+
+// Run runs the conversation in |req| until the model produces a final answer, making the registered tools available
+// to it in addition to any Tools of |req|, which is not modified. A registered tool replaces any function of the same
+// name in the Tools of |req|. Errors returned by tools (including unknown tools, invalid arguments, and panics) are
+// sent to the model as the result of the call, so it can recover. If the conversation exceeds MaxTurns, the partial
+// result is returned with ErrMaxTurns.
+func (r *Runner) Run(ctx context.Context, req *ChatCompletionRequest[models.Chat], opts ...RequestOption) (*RunResult, error) {
+	var cr = *req
+	cr.Messages = append([]*ChatMessage(nil), req.Messages...)
+	cr.Tools = nil
+	for _, t := range req.Tools {
+		if t.Function == nil || r.tools[t.Function.Name] == nil {
+			cr.Tools = append(cr.Tools, t)
+		}
+	}
+	for _, name := range r.order {
+		cr.Tools = append(cr.Tools, r.tools[name].tool)
+	}
+
+	var maxTurns = r.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = 10
+	}
+
+	var result = &RunResult{}
+	for result.Turns < maxTurns {
+		var resp, err = r.api.CreateChatCompletion(ctx, &cr, opts...)
+		if err != nil {
+			result.Messages = cr.Messages
+			return result, err
+		}
+		result.Turns++
+		result.Response = resp
+		result.Usage.add(resp.Usage)
+
+		if len(resp.Choices) == 0 || resp.Choices[0].Message == nil {
+			result.Messages = cr.Messages
+			return result, errors.New("openai: chat completion response has no message")
+		}
+
+		var msg = resp.Choices[0].Message
+		cr.Messages = append(cr.Messages, msg)
+		if len(msg.ToolCalls) == 0 {
+			result.Messages = cr.Messages
+			return result, nil
+		}
+
+		cr.Messages = append(cr.Messages, r.call(ctx, msg.ToolCalls)...)
+		// Requiring a tool call would otherwise loop until MaxTurns, so later turns let the model choose.
+		if cr.ToolChoice != nil && *cr.ToolChoice != *ToolChoiceNone {
+			cr.ToolChoice = nil
+		}
+	}
+
+	result.Messages = cr.Messages
+
+	return result, ErrMaxTurns
+}
+
+// call executes |calls| concurrently, and returns the messages containing their results, in order.
+func (r *Runner) call(ctx context.Context, calls []*ToolCall) []*ChatMessage {
+	var results = make([]*ChatMessage, len(calls))
+
+	var wg sync.WaitGroup
+	for i, tc := range calls {
+		wg.Add(1)
+		go func(i int, tc *ToolCall) {
+			defer wg.Done()
+
+			var content, err = r.execute(ctx, tc)
+			if err != nil {
+				content = fmt.Sprintf("error: %v", err)
+			}
+			results[i] = ToolMessage(tc.ID, content)
+		}(i, tc)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// execute executes the tool call |tc|. A panic in the tool is returned as an error, rather than crashing the process.
+func (r *Runner) execute(ctx context.Context, tc *ToolCall) (content string, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("tool panicked: %v", p)
+		}
+	}()
+
+	if tc.Function == nil {
+		return "", errors.New("unsupported tool call")
+	}
+
+	var t, ok = r.tools[tc.Function.Name]
+	if !ok {
+		return "", fmt.Errorf("unknown function %q", tc.Function.Name)
+	}
+
+	if r.ToolTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.ToolTimeout)
+		defer cancel()
+	}
+
+	return t.fn(ctx, tc.Function.Arguments)
+}
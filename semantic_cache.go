@@ -0,0 +1,209 @@
+package openai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fabiustech/openai/models"
+	"github.com/fabiustech/openai/routes"
+)
+
+// SemanticStore stores responses keyed by the embedding of the prompt which produced them (see WithSemanticCache).
+// Implementations must be safe for concurrent use.
+type SemanticStore interface {
+	// Lookup returns the value stored in |namespace| whose vector is most similar to |vector|, if its cosine similarity
+	// is at least |threshold|.
+	Lookup(ctx context.Context, namespace string, vector []float64, threshold float64) ([]byte, bool, error)
+	// Store adds |value| to |namespace| under |vector|.
+	Store(ctx context.Context, namespace string, vector []float64, value []byte) error
+}
+
+// semanticEntry is a value stored in a MemorySemanticStore.
+type semanticEntry struct {
+	namespace string
+	vector    []float64
+	value     []byte
+}
+
+// MemorySemanticStore is an in-memory SemanticStore which holds a bounded number of entries, evicting the oldest.
+type MemorySemanticStore struct {
+	max int
+
+	mu      sync.RWMutex
+	entries []*semanticEntry
+}
+
+// NewMemorySemanticStore returns a *MemorySemanticStore which holds up to |maxEntries| entries, or an unbounded number
+// if |maxEntries| is 0.
+func NewMemorySemanticStore(maxEntries int) *MemorySemanticStore {
+	return &MemorySemanticStore{max: maxEntries}
+}
+
+// Lookup implements the SemanticStore interface.
+func (s *MemorySemanticStore) Lookup(_ context.Context, namespace string, vector []float64, threshold float64) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var best *semanticEntry
+	var score float64
+	for _, e := range s.entries {
+		if e.namespace != namespace {
+			continue
+		}
+		if sim := CosineSimilarity(vector, e.vector); sim >= threshold && (best == nil || sim > score) {
+			best, score = e, sim
+		}
+	}
+
+	if best == nil {
+		return nil, false, nil
+	}
+
+	return best.value, true, nil
+}
+
+// Store implements the SemanticStore interface.
+func (s *MemorySemanticStore) Store(_ context.Context, namespace string, vector []float64, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, &semanticEntry{namespace: namespace, vector: vector, value: value})
+	if s.max > 0 && len(s.entries) > s.max {
+		s.entries = s.entries[len(s.entries)-s.max:]
+	}
+
+	return nil
+}
+
+// SemanticCacheConfig configures a semantic response cache (see WithSemanticCache).
+type SemanticCacheConfig struct {
+	// Store stores the cached responses.
+	// Defaults to a MemorySemanticStore holding up to 1,000 entries.
+	Store SemanticStore
+	// Model is the model used to embed prompts.
+	// Defaults to models.AdaEmbeddingV2.
+	Model models.Embedding
+	// Threshold is the minimum cosine similarity of a prior prompt for its response to be returned.
+	// Defaults to 0.95.
+	Threshold float64
+}
+
+// semanticCache returns cached responses to prompts similar to prior ones.
+type semanticCache struct {
+	store     SemanticStore
+	model     models.Embedding
+	threshold float64
+}
+
+// semanticCacheRoutes are the routes whose responses are cached, mapped to the field of their requests which contains
+// the prompt.
+var semanticCacheRoutes = map[string]string{
+	routes.ChatCompletions: "messages",
+	routes.Completions:     "prompt",
+}
+
+// read returns the cached response to |req| to |path| if there is one, and otherwise sends it with |next| and caches
+// the response. Requests whose prompt cannot be cached, or which fail to be embedded, are sent as is.
+func (s *semanticCache) read(c *Client, path string, req *http.Request, rc *requestConfig, next readFunc) (*response, error) {
+	var prompt, namespace, ok = c.semanticKey(path, req)
+	if !ok {
+		return next(req, rc)
+	}
+
+	var ctx = req.Context()
+	var emb, err = c.CreateEmbeddings(ctx, &EmbeddingRequest{Input: []string{prompt}, Model: s.model})
+	if err != nil || len(emb.Vectors()) != 1 {
+		return next(req, rc)
+	}
+	var vector = emb.Vectors()[0]
+
+	if b, hit, err := s.store.Lookup(ctx, namespace, vector, s.threshold); err == nil && hit {
+		return &response{body: b, cached: true, codec: c.codec}, nil
+	}
+
+	var res *response
+	if res, err = next(req, rc); err != nil {
+		return nil, err
+	}
+	_ = s.store.Store(ctx, namespace, vector, res.body)
+
+	return res, nil
+}
+
+// promptText returns the text of the JSON encoded prompt |b|: either a string, or chat messages, which are joined one
+// per line, prefixed with their role. It returns "" for any other prompt (e.g. tokens).
+func promptText(b json.RawMessage) string {
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s
+	}
+
+	var messages []*ChatMessage
+	if json.Unmarshal(b, &messages) != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, m := range messages {
+		// Messages with images cannot be compared by their text alone.
+		if !m.textOnly() {
+			return ""
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", m.Role, m.Text())
+	}
+
+	return sb.String()
+}
+
+// semanticKey returns the prompt of |req| to |path|, and the namespace of its cached responses: a hash of the
+// organization and project, and all other fields of the request, so that only requests with identical parameters (by
+// the same organization and project) share responses. It returns false if |path| is not cached, or if the request does
+// not contain a single text prompt.
+func (c *Client) semanticKey(path string, req *http.Request) (string, string, bool) {
+	var field, ok = semanticCacheRoutes[path]
+	if !ok {
+		return "", "", false
+	}
+
+	var b, err = requestBody(req)
+	if err != nil {
+		return "", "", false
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(b, &fields); err != nil {
+		return "", "", false
+	}
+
+	var prompt = promptText(fields[field])
+	if prompt == "" {
+		return "", "", false
+	}
+	delete(fields, field)
+
+	// Maps are marshaled with sorted keys, so the namespace does not depend on the order of fields.
+	if b, err = json.Marshal(fields); err != nil {
+		return "", "", false
+	}
+
+	var h = sha256.New()
+	_, _ = io.WriteString(h, path+"\n")
+	if c.orgID != nil {
+		_, _ = io.WriteString(h, *c.orgID)
+	}
+	_, _ = io.WriteString(h, "\n")
+	if c.projectID != nil {
+		_, _ = io.WriteString(h, *c.projectID)
+	}
+	_, _ = io.WriteString(h, "\n")
+	_, _ = h.Write(b)
+
+	return prompt, hex.EncodeToString(h.Sum(nil)), true
+}
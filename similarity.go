@@ -0,0 +1,101 @@
+package openai
+
+import (
+	"math"
+	"sort"
+)
+
+// DotProduct returns the dot product of |a| and |b|. Vectors of different lengths are compared over the length of the
+// shorter. OpenAI embeddings are normalized to length 1, so their dot product equals their cosine similarity.
+func DotProduct(a, b []float64) float64 {
+	if len(b) < len(a) {
+		a = a[:len(b)]
+	}
+
+	var sum float64
+	for i, v := range a {
+		sum += v * b[i]
+	}
+
+	return sum
+}
+
+// CosineSimilarity returns the cosine similarity of |a| and |b|, from -1 (opposite) to 1 (identical direction). It
+// returns 0 if either vector is zero.
+func CosineSimilarity(a, b []float64) float64 {
+	var na, nb = Norm(a), Norm(b)
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	return DotProduct(a, b) / (na * nb)
+}
+
+// Norm returns the Euclidean length of |v|.
+func Norm(v []float64) float64 {
+	return math.Sqrt(DotProduct(v, v))
+}
+
+// Normalize returns a copy of |v| scaled to length 1, or a copy of |v| if it is zero.
+func Normalize(v []float64) []float64 {
+	var out = make([]float64, len(v))
+	copy(out, v)
+
+	var n = Norm(v)
+	if n == 0 {
+		return out
+	}
+
+	for i := range out {
+		out[i] /= n
+	}
+
+	return out
+}
+
+// Neighbor is a vector found by TopK.
+type Neighbor struct {
+	// Index is the index of the vector in the slice which was searched.
+	Index int
+	// Score is the cosine similarity of the vector to the query.
+	Score float64
+}
+
+// TopK returns the |k| vectors of |vectors| most similar to |query| by cosine similarity, most similar first. It
+// searches exhaustively, which is fast enough for up to tens of thousands of vectors.
+func TopK(query []float64, vectors [][]float64, k int) []*Neighbor {
+	if k <= 0 {
+		return nil
+	}
+
+	var neighbors = make([]*Neighbor, len(vectors))
+	for i, v := range vectors {
+		neighbors[i] = &Neighbor{Index: i, Score: CosineSimilarity(query, v)}
+	}
+
+	sort.SliceStable(neighbors, func(i, j int) bool {
+		return neighbors[i].Score > neighbors[j].Score
+	})
+
+	if k < len(neighbors) {
+		neighbors = neighbors[:k]
+	}
+
+	return neighbors
+}
+
+// Vectors returns the embedding vectors of |r|, in the order of the request's inputs.
+func (r *EmbeddingResponse) Vectors() [][]float64 {
+	if r.List == nil {
+		return nil
+	}
+
+	var vectors = make([][]float64, len(r.Data))
+	for _, e := range r.Data {
+		if e.Index >= 0 && e.Index < len(vectors) {
+			vectors[e.Index] = e.Embedding
+		}
+	}
+
+	return vectors
+}
@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// maxEventLineSize is the maximum length of a line of a server-sent event stream. Lines are buffered in full, so the
+// limit only guards against unbounded memory use; events with large payloads (e.g. audio) are well below it.
+const maxEventLineSize = 16 << 20
+
+// sseScanner reads the data of server-sent events, as specified by
+// https://html.spec.whatwg.org/multipage/server-sent-events.html. Lines may end with "\r\n", "\n", or "\r". The data
+// lines of an event are joined with a newline, and all other fields, and comments (e.g. ": ping" heartbeats), are
+// ignored. Lines are not copied, and the data of each event is accumulated in a buffer which is reused across events,
+// so scanning allocates nothing once the buffers have grown to fit the largest event.
+type sseScanner struct {
+	scanner *bufio.Scanner
+	data    []byte
+}
+
+// newSSEScanner returns an *sseScanner which reads events from |r|.
+func newSSEScanner(r io.Reader) *sseScanner {
+	var s = bufio.NewScanner(r)
+	s.Buffer(make([]byte, 4096), maxEventLineSize)
+	s.Split(scanEventLines)
+
+	return &sseScanner{scanner: s}
+}
+
+// next returns the data of the next event, which is only valid until the next call. It returns io.EOF once the stream
+// ends. If the stream ends without the blank line which terminates an event, the partial event is returned.
+func (s *sseScanner) next() ([]byte, error) {
+	s.data = s.data[:0]
+
+	// An event with no data lines is not dispatched, so an empty data line must be tracked separately from |s.data|.
+	var ok bool
+	for s.scanner.Scan() {
+		var line = s.scanner.Bytes()
+		if len(line) == 0 {
+			if ok {
+				return s.data, nil
+			}
+			continue
+		}
+
+		var field, value, _ = bytes.Cut(line, []byte(":"))
+		if string(field) != "data" {
+			// Comments have an empty field name, and other fields (event, id, and retry) are not used.
+			continue
+		}
+		// A single space after the colon is not part of the value.
+		value = bytes.TrimPrefix(value, []byte(" "))
+
+		if ok {
+			s.data = append(s.data, '\n')
+		}
+		s.data = append(s.data, value...)
+		ok = true
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if ok {
+		return s.data, nil
+	}
+
+	return nil, io.EOF
+}
+
+// scanEventLines is a bufio.SplitFunc which splits a server-sent event stream into lines, without their line endings.
+func scanEventLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	var i = bytes.IndexAny(data, "\r\n")
+	switch {
+	case i < 0:
+		if atEOF {
+			return len(data), data, nil
+		}
+		// Request more data.
+		return 0, nil, nil
+	case data[i] == '\n':
+		return i + 1, data[:i], nil
+	case i+1 < len(data):
+		if data[i+1] == '\n' {
+			return i + 2, data[:i], nil
+		}
+		return i + 1, data[:i], nil
+	case atEOF:
+		return i + 1, data[:i], nil
+	default:
+		// A trailing "\r" may be followed by a "\n" which has not yet been read.
+		return 0, nil, nil
+	}
+}
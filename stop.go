@@ -0,0 +1,53 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxStopSequences is the maximum number of stop sequences accepted by the API.
+const maxStopSequences = 4
+
+// StopSequences contains up to 4 sequences where the API will stop generating further tokens. A single sequence is
+// encoded as a string, while multiple sequences are encoded as an array of strings.
+type StopSequences []string
+
+// Stop returns StopSequences containing |s|.
+func Stop(s ...string) StopSequences {
+	return s
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s StopSequences) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+
+	return json.Marshal([]string(s))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *StopSequences) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*s = StopSequences{single}
+		return nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*s = ss
+
+	return nil
+}
+
+// validate returns an error if |s| contains more sequences than the API accepts.
+func (s StopSequences) validate() error {
+	if len(s) > maxStopSequences {
+		return fmt.Errorf("openai: too many stop sequences: got %d, maximum is %d", len(s), maxStopSequences)
+	}
+
+	return nil
+}
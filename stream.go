@@ -0,0 +1,218 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+)
+
+var (
+	doneData = []byte("[DONE]")
+	// errorField is contained in every error event.
+	errorField = []byte(`"error"`)
+)
+
+// Stream reads values of type T from an endpoint which sends data-only server-sent events. The stream is terminated
+// by either a data: [DONE] message or the server closing the connection.
+type Stream[T any] struct {
+	body    io.ReadCloser
+	scanner *sseScanner
+	// codec decodes events. If nil, StdCodec is used.
+	codec Codec
+
+	// usage is the token usage reported by the stream, if any.
+	usage *Usage
+	// onUsage, if set, is called with the token usage once it is received.
+	onUsage func(u *Usage)
+}
+
+// usageReporter is implemented by streamed values which may report the token usage of the request, such as
+// *ChatCompletionChunk.
+type usageReporter interface {
+	streamUsage() *Usage
+}
+
+// newStream returns a *Stream which reads events from |body|.
+func newStream[T any](body io.ReadCloser) *Stream[T] {
+	return &Stream[T]{
+		body:    body,
+		scanner: newSSEScanner(body),
+	}
+}
+
+// Recv blocks until the next event is received and returns its decoded value. Recv returns io.EOF once the stream has
+// been terminated. If the server sends an error event, it is returned as an *APIError.
+func (s *Stream[T]) Recv() (T, error) {
+	var v T
+	var err = s.RecvInto(&v)
+
+	return v, err
+}
+
+// RecvInto is like Recv, but decodes the next event into |v|, which is reset first. If T is a pointer type (e.g.
+// *ChatCompletionChunk) and |*v| is not nil, the value it points to is reused rather than allocating a new one for
+// each event, so |*v| must not be retained across calls.
+func (s *Stream[T]) RecvInto(v *T) error {
+	var data, err = s.scanner.next()
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(data, doneData) {
+		return io.EOF
+	}
+
+	var codec = codecOrDefault(s.codec)
+	// Only events which may be errors are decoded as such, so that other events are decoded once.
+	if bytes.Contains(data, errorField) {
+		var er = &errorResponse{}
+		if err = codec.Unmarshal(data, er); err == nil && er.Error != nil {
+			return er.Error
+		}
+	}
+
+	reset(v)
+	if err = codec.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if r, ok := any(*v).(usageReporter); ok && r.streamUsage() != nil {
+		s.usage = r.streamUsage()
+		if s.onUsage != nil {
+			s.onUsage(s.usage)
+		}
+	}
+
+	return nil
+}
+
+// reset sets |v|, or the value it points to if it is a non-nil pointer, to its zero value, so that decoding into it
+// does not leave fields of a prior value behind.
+func reset[T any](v *T) {
+	var rv = reflect.ValueOf(v).Elem()
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	rv.Set(reflect.Zero(rv.Type()))
+}
+
+// Usage returns the token usage of the request, once it has been received. For chat completions, it is sent in the
+// final chunk if requested with StreamOptions.IncludeUsage; Usage returns nil until then.
+func (s *Stream[T]) Usage() *Usage {
+	return s.usage
+}
+
+// Channel reads the stream in a separate goroutine, and sends its values on the returned value channel, for use in
+// select-based pipelines. Both channels are closed, and the stream is closed, once the stream ends, an error occurs,
+// or |ctx| is done. At most one error (never io.EOF) is sent on the error channel, which is buffered, before the
+// value channel is closed:
+//
+//	var values, errs = stream.Channel(ctx)
+//	for v := range values {
+//		...
+//	}
+//	if err := <-errs; err != nil {
+//		...
+//	}
+func (s *Stream[T]) Channel(ctx context.Context) (<-chan T, <-chan error) {
+	var values = make(chan T)
+	var errs = make(chan error, 1)
+
+	// Closing the stream when |ctx| is done unblocks a pending Recv.
+	var done = make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.Close()
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(errs)
+		defer close(values)
+		defer s.Close()
+		defer close(done)
+
+		for {
+			var v, err = s.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				errs <- err
+				return
+			}
+
+			select {
+			case values <- v:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return values, errs
+}
+
+// texter is implemented by streamed values which contain generated text, such as *ChatCompletionChunk.
+type texter interface {
+	Text() string
+}
+
+// errNoText is returned by the TextReader of a stream whose values do not contain text.
+var errNoText = errors.New("openai: stream values do not contain text")
+
+// TextReader returns an io.Reader which reads only the text generated by the model (e.g. the content deltas of
+// chat completion chunks), so that it can be piped into io.Copy, a bufio.Scanner, or an http.ResponseWriter. The
+// reader returns io.EOF once the stream has been terminated, and any other error of the stream as is. It is still the
+// caller's responsibility to close the stream.
+func (s *Stream[T]) TextReader() io.Reader {
+	return &textReader[T]{stream: s}
+}
+
+// textReader reads the text of the values of a Stream.
+type textReader[T any] struct {
+	stream *Stream[T]
+	// buf is the unread text of the last value.
+	buf []byte
+	err error
+}
+
+// Read implements the io.Reader interface.
+func (r *textReader[T]) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		var v, err = r.stream.Recv()
+		if err != nil {
+			r.err = err
+			continue
+		}
+
+		var t, ok = any(v).(texter)
+		if !ok {
+			r.err = errNoText
+			continue
+		}
+		r.buf = append(r.buf[:0], t.Text()...)
+	}
+
+	var n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// Close closes the underlying connection. It should always be called once the caller is done with the stream.
+func (s *Stream[T]) Close() error {
+	return s.body.Close()
+}
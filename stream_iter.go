@@ -0,0 +1,35 @@
+//go:build go1.23
+
+package openai
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the values of the stream, which closes the stream once the loop exits:
+//
+//	for chunk, err := range stream.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The iterator stops after yielding an error, and does not yield io.EOF. It may only be used once.
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+
+		for {
+			var v, err = s.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
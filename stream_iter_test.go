@@ -0,0 +1,79 @@
+//go:build go1.23
+
+package openai
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// closeRecorder is an io.ReadCloser which records whether it has been closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestStreamAll(t *testing.T) {
+	var ts = chatStreamServer(nil, testChatChunks...)
+	defer ts.Close()
+
+	var client, _ = newTestClient(ts.URL)
+	var stream, err = client.CreateChatCompletionStream(context.Background(), &ChatCompletionRequest[models.Chat]{
+		Model:    models.GPT4o,
+		Messages: []*ChatMessage{UserMessage("What's the weather in Paris?")},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionStream error: %v", err)
+	}
+
+	var acc Accumulator[models.Chat]
+	for chunk, err := range stream.All() {
+		if err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+		acc.Add(chunk)
+	}
+	if got := acc.Response().Choices[0].Message.Content; got != "Let me check." {
+		t.Fatalf("unexpected content %q", got)
+	}
+
+	// Breaking out of the loop closes the stream.
+	var body = &closeRecorder{Reader: strings.NewReader("data: 1\n\ndata: 2\n\ndata: 3\n\n")}
+	var values []int
+	for v, err := range newStream[int](body).All() {
+		if err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+		values = append(values, v)
+		if v == 2 {
+			break
+		}
+	}
+	if len(values) != 2 || !body.closed {
+		t.Fatalf("expected the stream to be closed after 2 values, got %v (closed: %t)", values, body.closed)
+	}
+
+	// Errors end the iteration.
+	body = &closeRecorder{Reader: strings.NewReader("data: 1\n\ndata: x\n\ndata: 3\n\n")}
+	values = nil
+	var errs int
+	for v, err := range newStream[int](body).All() {
+		if err != nil {
+			errs++
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) != 1 || errs != 1 || !body.closed {
+		t.Fatalf("expected 1 value and 1 error, got %v and %d errors (closed: %t)", values, errs, body.closed)
+	}
+}
@@ -0,0 +1,62 @@
+// Package tiers contains the enum values which represent the service
+// tiers used to process requests.
+package tiers
+
+// Tier represents the enum values for the latency tier used to process
+// a request.
+type Tier int
+
+const (
+	// Invalid represents an invalid Tier option.
+	Invalid Tier = iota
+	// Auto uses the scale tier credits of the project, if it has any,
+	// and the default tier otherwise.
+	Auto
+	// Default processes the request with the default service tier, with
+	// a lower uptime SLA and no latency guarantee.
+	Default
+	// Flex processes the request with flex processing: lower prices in
+	// exchange for slower responses and occasional resource
+	// unavailability. Suited for non-production or batch-like workloads.
+	Flex
+	// Scale is the tier reported for requests processed with the scale
+	// tier credits of the project.
+	Scale
+)
+
+// String implements the fmt.Stringer interface.
+func (t Tier) String() string {
+	return tierToString[t]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t Tier) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |t| to Invalid.
+func (t *Tier) UnmarshalText(b []byte) error {
+	if val, ok := stringToTier[(string(b))]; ok {
+		*t = val
+		return nil
+	}
+
+	*t = Invalid
+
+	return nil
+}
+
+var tierToString = map[Tier]string{
+	Auto:    "auto",
+	Default: "default",
+	Flex:    "flex",
+	Scale:   "scale",
+}
+
+var stringToTier = map[string]Tier{
+	"auto":    Auto,
+	"default": Default,
+	"flex":    Flex,
+	"scale":   Scale,
+}
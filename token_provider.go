@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before its expiry a Token is refreshed, so that it does not expire in flight.
+const tokenRefreshMargin = 2 * time.Minute
+
+// Token is a short-lived bearer token.
+type Token struct {
+	// Value is the bearer token.
+	Value string
+	// Expiry is the time at which the token expires. A zero Expiry means the token never expires.
+	Expiry time.Time
+}
+
+// TokenProvider supplies bearer tokens to authenticate requests (see WithTokenProvider), e.g. from Azure AD client
+// credentials or an OAuth flow.
+type TokenProvider interface {
+	// Token returns a new token. It is called whenever the previous token is about to expire.
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenProviderFunc is an adapter which allows the use of an ordinary function as a TokenProvider.
+type TokenProviderFunc func(ctx context.Context) (*Token, error)
+
+// Token implements the TokenProvider interface by calling f(ctx).
+func (f TokenProviderFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// cachedTokenProvider caches the token returned by a TokenProvider until shortly before it expires.
+type cachedTokenProvider struct {
+	provider TokenProvider
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// get returns the cached token, refreshing it if it is missing or about to expire.
+func (p *cachedTokenProvider) get(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != nil && (p.token.Expiry.IsZero() || time.Until(p.token.Expiry) > tokenRefreshMargin) {
+		return p.token.Value, nil
+	}
+
+	var t, err = p.provider.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.token = t
+
+	return t.Value, nil
+}
@@ -0,0 +1,195 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Pre-tokenization patterns, which split text into the pieces that are encoded independently. Go's regexp package
+// does not support lookahead, so the \s+(?!\S) alternative of the tiktoken patterns is emulated by BPE instead.
+const (
+	// PatternO200k is the pre-tokenization pattern of O200kBase.
+	PatternO200k = `[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+`
+	// PatternCL100k is the pre-tokenization pattern of CL100kBase.
+	PatternCL100k = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`
+	// PatternR50k is the pre-tokenization pattern of R50kBase and P50kBase.
+	PatternR50k = `'s|'t|'re|'ve|'m|'ll|'d| ?\p{L}+| ?\p{N}+| ?[^\s\p{L}\p{N}]+|\s+`
+)
+
+// BPE is a Tokenizer which implements byte pair encoding, compatible with tiktoken. It is safe for concurrent use.
+type BPE struct {
+	ranks   map[string]int
+	decoder map[int]string
+	pattern *regexp.Regexp
+}
+
+// NewBPE returns a *BPE which merges byte sequences according to |ranks| (lower ranks are merged first, and each rank
+// is also the token's ID), after splitting text with the regular expression |pattern| (e.g. PatternCL100k).
+func NewBPE(ranks map[string]int, pattern string) (*BPE, error) {
+	var re, err = regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoder = make(map[int]string, len(ranks))
+	for k, v := range ranks {
+		decoder[v] = k
+	}
+
+	return &BPE{ranks: ranks, decoder: decoder, pattern: re}, nil
+}
+
+// LoadBPE reads ranks in the tiktoken format (one base64 encoded token and its rank per line) from |r|, and returns a
+// *BPE which uses them with |pattern|.
+func LoadBPE(r io.Reader, pattern string) (*BPE, error) {
+	var ranks = map[string]int{}
+
+	var s = bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		var fields = strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("tokenizer: invalid rank on line %d", line)
+		}
+
+		var token, err = base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: invalid token on line %d: %w", line, err)
+		}
+
+		var rank int
+		if rank, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("tokenizer: invalid rank on line %d: %w", line, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewBPE(ranks, pattern)
+}
+
+// LoadBPEFile is like LoadBPE, but reads the ranks from the file at |path|.
+func LoadBPEFile(path, pattern string) (*BPE, error) {
+	var f, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadBPE(f, pattern)
+}
+
+// Count implements the Tokenizer interface.
+func (b *BPE) Count(text string) int {
+	var n int
+	b.split(text, func(piece string) {
+		if _, ok := b.ranks[piece]; ok {
+			n++
+			return
+		}
+		n += len(b.merge(piece))
+	})
+
+	return n
+}
+
+// Encode returns the tokens of |text|. Special tokens (e.g. <|endoftext|>) are encoded as ordinary text.
+func (b *BPE) Encode(text string) []int {
+	var tokens []int
+	b.split(text, func(piece string) {
+		if rank, ok := b.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			return
+		}
+		for _, part := range b.merge(piece) {
+			tokens = append(tokens, b.ranks[part])
+		}
+	})
+
+	return tokens
+}
+
+// Decode returns the text of |tokens|. Unknown tokens are skipped.
+func (b *BPE) Decode(tokens []int) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteString(b.decoder[t])
+	}
+
+	return sb.String()
+}
+
+// split calls |fn| with each piece of |text| matched by the pattern of |b|. A run of whitespace followed by other text
+// gives up its last character to that text, as \s+(?!\S) does in tiktoken.
+func (b *BPE) split(text string, fn func(piece string)) {
+	for len(text) > 0 {
+		var loc = b.pattern.FindStringIndex(text)
+		if loc == nil {
+			return
+		}
+
+		var end = loc[1]
+		var piece = text[loc[0]:end]
+		if end < len(text) && isSpace(piece) && !strings.HasSuffix(piece, "\n") && !strings.HasSuffix(piece, "\r") {
+			if next, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(next) {
+				if _, size := utf8.DecodeLastRuneInString(piece); size < len(piece) {
+					end -= size
+					piece = piece[:len(piece)-size]
+				}
+			}
+		}
+
+		fn(piece)
+		text = text[end:]
+	}
+}
+
+// merge splits |piece| into its tokens, by repeatedly merging the adjacent pair with the lowest rank.
+func (b *BPE) merge(piece string) []string {
+	var parts = make([]string, len(piece))
+	for i := 0; i < len(piece); i++ {
+		parts[i] = piece[i : i+1]
+	}
+
+	for len(parts) > 1 {
+		var best, at = -1, -1
+		for i := 0; i < len(parts)-1; i++ {
+			if rank, ok := b.ranks[parts[i]+parts[i+1]]; ok && (best < 0 || rank < best) {
+				best, at = rank, i
+			}
+		}
+		if at < 0 {
+			break
+		}
+
+		parts[at] += parts[at+1]
+		parts = append(parts[:at+1], parts[at+2:]...)
+	}
+
+	return parts
+}
+
+// isSpace reports whether |s| consists only of whitespace.
+func isSpace(s string) bool {
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
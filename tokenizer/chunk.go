@@ -0,0 +1,131 @@
+package tokenizer
+
+import (
+	"sort"
+	"unicode"
+)
+
+// ChunkOptions configures Chunk.
+type ChunkOptions struct {
+	// Size is the maximum number of tokens in each chunk.
+	// Defaults to 512.
+	Size int
+	// Overlap is the approximate number of tokens at the end of each chunk which are repeated at the start of the next,
+	// so that text near a boundary keeps its context in both chunks. It must be less than Size.
+	// Defaults to 0.
+	Overlap int
+	// Sentences, if set, splits between sentences rather than between words. Sentences longer than Size are still
+	// split between words.
+	// Defaults to false.
+	Sentences bool
+}
+
+// Chunk splits |text| into chunks of at most |opts|.Size tokens, as counted by |t|, e.g. to embed a long document or
+// prepare it for fine-tuning. Chunks are split between words (or sentences), and only split within a word which is
+// itself longer than Size. |opts| may be nil.
+func Chunk(t Tokenizer, text string, opts *ChunkOptions) []string {
+	var size, overlap = 512, 0
+	var sentences bool
+	if opts != nil {
+		if opts.Size > 0 {
+			size = opts.Size
+		}
+		if opts.Overlap > 0 && opts.Overlap < size {
+			overlap = opts.Overlap
+		}
+		sentences = opts.Sentences
+	}
+
+	if text == "" {
+		return nil
+	}
+
+	var units []string
+	if sentences {
+		for _, s := range splitSentences(text) {
+			if t.Count(s) > size {
+				units = append(units, splitWords(s)...)
+			} else {
+				units = append(units, s)
+			}
+		}
+	} else {
+		units = splitWords(text)
+	}
+
+	// Split any unit which is too long on its own into its characters.
+	var fitted []string
+	for _, u := range units {
+		if t.Count(u) > size {
+			fitted = append(fitted, splitRunes(u)...)
+		} else {
+			fitted = append(fitted, u)
+		}
+	}
+	units = fitted
+
+	var chunks []string
+	for i := 0; i < len(units); {
+		var k = gallop(len(units)-i, func(k int) bool {
+			return t.Count(join(units[i:i+k])) <= size
+		})
+		if k == 0 {
+			k = 1
+		}
+		chunks = append(chunks, join(units[i:i+k]))
+
+		var end = i + k
+		if end == len(units) {
+			break
+		}
+
+		// Start the next chunk with the longest suffix of this one which fits within the overlap.
+		var o = 0
+		if overlap > 0 {
+			o = gallop(k-1, func(o int) bool {
+				return t.Count(join(units[end-o:end])) <= overlap
+			})
+		}
+		i = end - o
+	}
+
+	return chunks
+}
+
+// ChunkForModel is like Chunk, but counts tokens with the Tokenizer for |model| (see ForModel).
+func ChunkForModel(model, text string, opts *ChunkOptions) []string {
+	return Chunk(ForModel(model), text, opts)
+}
+
+// gallop returns the largest k in [0, n] for which |fn| is true, assuming that |fn| is true for all smaller k. Unlike
+// largest, its cost depends on the result rather than on |n|, as |fn| is first evaluated at exponentially increasing
+// k.
+func gallop(n int, fn func(k int) bool) int {
+	var lo, hi = 0, 1
+	for hi <= n && fn(hi) {
+		lo, hi = hi, hi*2
+	}
+	if hi > n {
+		hi = n + 1
+	}
+
+	// fn(lo) is true, and fn(hi) is false or hi is out of range.
+	return lo + sort.Search(hi-lo-1, func(i int) bool { return !fn(lo + 1 + i) })
+}
+
+// splitWords splits |text| into words, each including its trailing whitespace.
+func splitWords(text string) []string {
+	var units []string
+	var start int
+	var space bool
+
+	for i, r := range text {
+		if space && !unicode.IsSpace(r) {
+			units = append(units, text[start:i])
+			start = i
+		}
+		space = unicode.IsSpace(r)
+	}
+
+	return append(units, text[start:])
+}
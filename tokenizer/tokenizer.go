@@ -0,0 +1,117 @@
+// Package tokenizer counts the tokens in text the way OpenAI models do, so that prompts can be measured against
+// context windows and priced before they are sent.
+//
+// Exact counts require the byte pair encoding used by the model. The rank files which define the encodings are large
+// and are published by OpenAI (e.g. https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken), so they
+// are not bundled; load one with LoadBPE and Register it under its encoding name. Models whose encoding has not been
+// registered fall back to an Estimator.
+package tokenizer
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Encoding names, as used by tiktoken.
+const (
+	// O200kBase is the encoding used by GPT-4o and the o-series models.
+	O200kBase = "o200k_base"
+	// CL100kBase is the encoding used by GPT-4, GPT-3.5 Turbo, and the text-embedding-3 and ada-002 embedding models.
+	CL100kBase = "cl100k_base"
+	// P50kBase is the encoding used by the Codex and text-davinci-002/003 models.
+	P50kBase = "p50k_base"
+	// R50kBase is the encoding used by the GPT-3 models (e.g. davinci) and first generation embedding models.
+	R50kBase = "r50k_base"
+)
+
+// Tokenizer counts the tokens in text.
+type Tokenizer interface {
+	// Count returns the number of tokens in |text|.
+	Count(text string) int
+}
+
+// Estimator is a Tokenizer which approximates token counts from the length of the text, without an encoding. It
+// assumes roughly 4 characters per token, which holds for typical English text.
+type Estimator struct {
+	// CharsPerToken is the average number of characters per token.
+	// Defaults to 4.
+	CharsPerToken float64
+}
+
+// Count implements the Tokenizer interface.
+func (e Estimator) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	var cpt = e.CharsPerToken
+	if cpt <= 0 {
+		cpt = 4
+	}
+
+	var n = int(float64(utf8.RuneCountInString(text))/cpt + 0.5)
+	if n == 0 {
+		return 1
+	}
+
+	return n
+}
+
+var (
+	mu        sync.RWMutex
+	encodings = map[string]Tokenizer{}
+)
+
+// Register makes |t| the Tokenizer for the encoding named |encoding| (e.g. CL100kBase).
+func Register(encoding string, t Tokenizer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	encodings[encoding] = t
+}
+
+// ForModel returns the Tokenizer registered for the encoding used by |model|, or an Estimator if there is none.
+func ForModel(model string) Tokenizer {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if t, ok := encodings[EncodingForModel(model)]; ok {
+		return t
+	}
+
+	return Estimator{}
+}
+
+// Count returns the number of tokens in |text| when sent to |model|.
+func Count(model, text string) int {
+	return ForModel(model).Count(text)
+}
+
+// EncodingForModel returns the name of the encoding used by |model|. Fine-tuned models (e.g. "ft:gpt-4o-mini:org::id")
+// use the encoding of their base model. Unrecognized models are assumed to use O200kBase.
+func EncodingForModel(model string) string {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.SplitN(strings.TrimPrefix(model, "ft:"), ":", 2)[0]
+	}
+
+	switch {
+	case strings.HasPrefix(model, "gpt-4o"), strings.HasPrefix(model, "chatgpt-4o"),
+		strings.HasPrefix(model, "gpt-4.1"), strings.HasPrefix(model, "gpt-4.5"),
+		strings.HasPrefix(model, "o1"), strings.HasPrefix(model, "o3"), strings.HasPrefix(model, "o4"):
+		return O200kBase
+	case strings.HasPrefix(model, "gpt-4"), strings.HasPrefix(model, "gpt-3.5"), strings.HasPrefix(model, "gpt-35"),
+		strings.HasPrefix(model, "text-embedding-"), strings.HasPrefix(model, "davinci-002"),
+		strings.HasPrefix(model, "babbage-002"):
+		return CL100kBase
+	case strings.HasPrefix(model, "text-davinci-002"), strings.HasPrefix(model, "text-davinci-003"),
+		strings.HasPrefix(model, "code-"):
+		return P50kBase
+	case strings.HasPrefix(model, "text-"), model == "davinci", model == "curie", model == "babbage", model == "ada",
+		strings.HasPrefix(model, "davinci:"), strings.HasPrefix(model, "curie:"),
+		strings.HasPrefix(model, "babbage:"), strings.HasPrefix(model, "ada:"):
+		return R50kBase
+	default:
+		return O200kBase
+	}
+}
@@ -0,0 +1,176 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testRanks returns ranks for every byte, followed by a few merges.
+func testRanks() map[string]int {
+	var ranks = map[string]int{}
+	for i := 0; i < 256; i++ {
+		ranks[string([]byte{byte(i)})] = i
+	}
+	for i, m := range []string{"he", "ll", "hell", "hello", " w", "or", " wor", " world"} {
+		ranks[m] = 256 + i
+	}
+
+	return ranks
+}
+
+func TestBPE(t *testing.T) {
+	var b, err = NewBPE(testRanks(), PatternCL100k)
+	if err != nil {
+		t.Fatalf("NewBPE error: %v", err)
+	}
+
+	var tokens = b.Encode("hello world!")
+	if want := []int{259, 263, '!'}; !reflect.DeepEqual(tokens, want) {
+		t.Fatalf("expected tokens %v, got %v", want, tokens)
+	}
+	if got := b.Decode(tokens); got != "hello world!" {
+		t.Fatalf("unexpected decoded text %q", got)
+	}
+	if n := b.Count("hello world!"); n != 3 {
+		t.Fatalf("expected 3 tokens, got %d", n)
+	}
+
+	// The whitespace before "world" stays with it, as \s+(?!\S) does in tiktoken.
+	if tokens = b.Encode("hello   world"); !reflect.DeepEqual(tokens, []int{259, ' ', ' ', 263}) {
+		t.Fatalf("unexpected tokens for repeated whitespace: %v", tokens)
+	}
+}
+
+func TestLoadBPE(t *testing.T) {
+	var sb strings.Builder
+	for token, rank := range testRanks() {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+
+	var b, err = LoadBPE(strings.NewReader(sb.String()), PatternR50k)
+	if err != nil {
+		t.Fatalf("LoadBPE error: %v", err)
+	}
+	if n := b.Count("hello world"); n != 2 {
+		t.Fatalf("expected 2 tokens, got %d", n)
+	}
+
+	if _, err = LoadBPE(strings.NewReader("aGVsbG8=\n"), PatternR50k); err == nil {
+		t.Fatal("expected an error for a line without a rank")
+	}
+}
+
+func TestForModel(t *testing.T) {
+	for model, want := range map[string]string{
+		"gpt-4o-mini":                 O200kBase,
+		"ft:gpt-4o-mini:acme::abc123": O200kBase,
+		"gpt-4-turbo":                 CL100kBase,
+		"text-embedding-3-small":      CL100kBase,
+		"text-davinci-003":            P50kBase,
+		"davinci":                     R50kBase,
+		"text-similarity-ada-001":     R50kBase,
+		"some-future-model":           O200kBase,
+	} {
+		if got := EncodingForModel(model); got != want {
+			t.Errorf("expected encoding %s for %s, got %s", want, model, got)
+		}
+	}
+
+	if n := Count("gpt-4o", "four score and seven"); n != 5 {
+		t.Fatalf("expected an estimate of 5 tokens, got %d", n)
+	}
+
+	var b, _ = NewBPE(testRanks(), PatternO200k)
+	Register(O200kBase, b)
+	defer Register(O200kBase, Estimator{})
+
+	if n := Count("gpt-4o", "hello world"); n != 2 {
+		t.Fatalf("expected 2 tokens from the registered encoding, got %d", n)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	// Each character is a token.
+	var chars = Estimator{CharsPerToken: 1}
+	var text = "The first sentence. The second one! A third? Done."
+
+	if got := Truncate(chars, text, 100, nil); got != text {
+		t.Fatalf("expected text within the budget to be unchanged, got %q", got)
+	}
+
+	for _, tc := range []struct {
+		opts *TruncateOptions
+		want string
+	}{
+		{nil, "The first s"},
+		{&TruncateOptions{Strategy: TruncateStart}, "hird? Done."},
+		{&TruncateOptions{Strategy: TruncateMiddle}, "The f…Done."},
+		{&TruncateOptions{Sentences: true}, ""},
+		{&TruncateOptions{Strategy: TruncateStart, Sentences: true}, "Done."},
+	} {
+		if got := Truncate(chars, text, 11, tc.opts); got != tc.want {
+			t.Errorf("Truncate(%+v) = %q, expected %q", tc.opts, got, tc.want)
+		}
+	}
+
+	if got := Truncate(chars, text, 20, &TruncateOptions{Sentences: true}); got != "The first sentence. " {
+		t.Fatalf("expected the first sentence, got %q", got)
+	}
+	if got := Truncate(chars, "e.g. 3.14 is pi. Yes.", 17, &TruncateOptions{Sentences: true}); got != "e.g. 3.14 is pi. " {
+		t.Fatalf("expected abbreviations and decimals not to end sentences, got %q", got)
+	}
+
+	// A marker which does not fit within the budget on its own is omitted.
+	var marker = " [... content removed ...] "
+	for _, tc := range []struct {
+		strategy Strategy
+		budget   int
+		want     string
+	}{
+		{TruncateEnd, 1, "T"},
+		{TruncateEnd, 2, "Th"},
+		{TruncateEnd, 3, "The"},
+		{TruncateStart, 1, "."},
+		{TruncateStart, 2, "e."},
+		{TruncateStart, 3, "ne."},
+		{TruncateMiddle, 1, "."},
+		{TruncateMiddle, 2, "T."},
+		{TruncateMiddle, 3, "Te."},
+	} {
+		var opts = &TruncateOptions{Strategy: tc.strategy, Marker: &marker}
+		if got := Truncate(chars, text, tc.budget, opts); got != tc.want {
+			t.Errorf("Truncate(%+v, %d) = %q, expected %q", tc.strategy, tc.budget, got, tc.want)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	var chars = Estimator{CharsPerToken: 1}
+
+	var chunks = Chunk(chars, "one two three four five six", &ChunkOptions{Size: 10})
+	if want := []string{"one two ", "three ", "four five ", "six"}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("expected chunks %q, got %q", want, chunks)
+	}
+
+	chunks = Chunk(chars, "one two three four five six", &ChunkOptions{Size: 10, Overlap: 5})
+	if want := []string{"one two ", "two three ", "four five ", "five six"}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("expected overlapping chunks %q, got %q", want, chunks)
+	}
+
+	chunks = Chunk(chars, "Short one. Another sentence here.", &ChunkOptions{Size: 25, Sentences: true})
+	if want := []string{"Short one. ", "Another sentence here."}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("expected sentence chunks %q, got %q", want, chunks)
+	}
+
+	chunks = Chunk(chars, "abcdefghij", &ChunkOptions{Size: 4})
+	if want := []string{"abcd", "efgh", "ij"}; !reflect.DeepEqual(chunks, want) {
+		t.Fatalf("expected a long word to be split, got %q", chunks)
+	}
+
+	if chunks = Chunk(chars, "", nil); chunks != nil {
+		t.Fatalf("expected no chunks for empty text, got %q", chunks)
+	}
+}
@@ -0,0 +1,158 @@
+package tokenizer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Strategy determines which part of the text Truncate removes.
+type Strategy int
+
+const (
+	// TruncateEnd removes text from the end, keeping the start.
+	TruncateEnd Strategy = iota
+	// TruncateStart removes text from the start, keeping the end (e.g. the most recent part of a transcript).
+	TruncateStart
+	// TruncateMiddle removes text from the middle, keeping the start and the end, separated by the Marker.
+	TruncateMiddle
+)
+
+// TruncateOptions configures Truncate.
+type TruncateOptions struct {
+	// Strategy determines which part of the text is removed.
+	// Defaults to TruncateEnd.
+	Strategy Strategy
+	// Sentences, if set, only removes whole sentences (ending in ., !, ?, or a newline), so the text is never cut
+	// mid-sentence. This may remove more text than necessary.
+	// Defaults to false.
+	Sentences bool
+	// Marker is inserted where text was removed. Its tokens count towards the budget; it is omitted if it does not fit
+	// within the budget on its own.
+	// Defaults to "…" for TruncateMiddle, and to no marker otherwise.
+	Marker *string
+}
+
+// Truncate returns the longest part of |text| which fits within |budget| tokens, as counted by |t|, according to
+// |opts| (which may be nil). Text which already fits is returned unchanged.
+func Truncate(t Tokenizer, text string, budget int, opts *TruncateOptions) string {
+	if opts == nil {
+		opts = &TruncateOptions{}
+	}
+
+	if t.Count(text) <= budget {
+		return text
+	}
+	if budget <= 0 {
+		return ""
+	}
+
+	var units = splitRunes(text)
+	if opts.Sentences {
+		units = splitSentences(text)
+	}
+
+	var fits = func(s string) bool {
+		return t.Count(s) <= budget
+	}
+
+	var marker string
+	switch {
+	case opts.Marker != nil:
+		marker = *opts.Marker
+	case opts.Strategy == TruncateMiddle:
+		marker = "…"
+	}
+	if !fits(marker) {
+		marker = ""
+	}
+
+	switch opts.Strategy {
+	case TruncateStart:
+		var k = largest(len(units), func(k int) bool {
+			return fits(marker + join(units[len(units)-k:]))
+		})
+		if k <= 0 {
+			return ""
+		}
+		return marker + join(units[len(units)-k:])
+	case TruncateMiddle:
+		// Keep up to half of the budget from the start, then as much of the end as still fits.
+		var half = budget / 2
+		var h = largest(len(units), func(k int) bool {
+			return t.Count(join(units[:k])) <= half
+		})
+		var head = join(units[:h])
+		var k = largest(len(units)-h, func(k int) bool {
+			return fits(head + marker + join(units[len(units)-k:]))
+		})
+		// The head and the marker may not fit together, even though each fits on its own.
+		if k < 0 || h == 0 && k == 0 {
+			return ""
+		}
+		return head + marker + join(units[len(units)-k:])
+	default:
+		var k = largest(len(units), func(k int) bool {
+			return fits(join(units[:k]) + marker)
+		})
+		if k <= 0 {
+			return ""
+		}
+		return join(units[:k]) + marker
+	}
+}
+
+// TruncateForModel is like Truncate, but counts tokens with the Tokenizer for |model| (see ForModel).
+func TruncateForModel(model, text string, budget int, opts *TruncateOptions) string {
+	return Truncate(ForModel(model), text, budget, opts)
+}
+
+// largest returns the largest k in [0, n] for which |fn| is true, assuming that |fn| is true for all smaller k.
+func largest(n int, fn func(k int) bool) int {
+	return sort.Search(n+1, func(k int) bool { return !fn(k) }) - 1
+}
+
+// join concatenates |units|.
+func join(units []string) string {
+	return strings.Join(units, "")
+}
+
+// splitRunes splits |text| into its characters.
+func splitRunes(text string) []string {
+	var units = make([]string, 0, len(text))
+	for _, r := range text {
+		units = append(units, string(r))
+	}
+
+	return units
+}
+
+// splitSentences splits |text| into sentences, each including its trailing whitespace. A sentence ends with a newline,
+// or with ., !, or ? followed by whitespace.
+func splitSentences(text string) []string {
+	var units []string
+	var start int
+	// punct is whether the last character was sentence punctuation, and end whether the current sentence has ended.
+	var punct, end bool
+
+	for i, r := range text {
+		if end && !unicode.IsSpace(r) {
+			units = append(units, text[start:i])
+			start = i
+			punct, end = false, false
+		}
+
+		switch {
+		case r == '\n':
+			end = true
+		case r == '.' || r == '!' || r == '?':
+			punct = true
+		case unicode.IsSpace(r):
+			end = end || punct
+		default:
+			punct = false
+		}
+	}
+
+	return append(units, text[start:])
+}
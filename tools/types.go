@@ -0,0 +1,53 @@
+// Package tools contains the enum values which represent the various
+// types of tools which can be made available to models.
+package tools
+
+// Type represents the enum values for the types of tools.
+type Type int
+
+const (
+	// TypeInvalid represents an invalid Type option.
+	TypeInvalid Type = iota
+	// TypeCodeInterpreter specifies the code interpreter tool, which allows the model to write and run Python code
+	// in a sandboxed execution environment.
+	TypeCodeInterpreter
+	// TypeFileSearch specifies the file search tool, which allows the model to search the contents of uploaded files.
+	TypeFileSearch
+	// TypeFunction specifies a function tool, which allows the model to call a function defined by the caller.
+	TypeFunction
+)
+
+// String implements the fmt.Stringer interface.
+func (t Type) String() string {
+	return typeToString[t]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t Type) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// On unrecognized value, it sets |t| to TypeInvalid.
+func (t *Type) UnmarshalText(b []byte) error {
+	if val, ok := stringToType[(string(b))]; ok {
+		*t = val
+		return nil
+	}
+
+	*t = TypeInvalid
+
+	return nil
+}
+
+var typeToString = map[Type]string{
+	TypeCodeInterpreter: "code_interpreter",
+	TypeFileSearch:      "file_search",
+	TypeFunction:        "function",
+}
+
+var stringToType = map[string]Type{
+	"code_interpreter": TypeCodeInterpreter,
+	"file_search":      TypeFileSearch,
+	"function":         TypeFunction,
+}
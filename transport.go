@@ -0,0 +1,142 @@
+package openai
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportConfig tunes the connections used to send requests (see WithTransportConfig). Zero fields keep the
+// setting of the transport, which is that of http.DefaultTransport unless a custom *http.Transport was set with
+// WithHTTPClient.
+type TransportConfig struct {
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections to each host. The default of the net/http package
+	// is only 2, so highly concurrent clients (e.g. embedding pipelines) repeatedly open new connections unless it is
+	// raised to around their concurrency.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the total number of connections to each host, including those in use.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open before it is closed.
+	IdleConnTimeout time.Duration
+	// DialTimeout is the maximum amount of time to wait for a connection to be established.
+	DialTimeout time.Duration
+	// KeepAlive is the interval between TCP keep-alive probes of open connections.
+	KeepAlive time.Duration
+	// TLSHandshakeTimeout is the maximum amount of time to wait for a TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// DisableHTTP2 disables HTTP/2, so that requests are spread across several HTTP/1.1 connections rather than
+	// multiplexed over one, which can be faster for many large concurrent requests.
+	DisableHTTP2 bool
+	// DNSCacheTTL caches the addresses of hosts for the given duration, rather than resolving them for every new
+	// connection.
+	DNSCacheTTL time.Duration
+}
+
+// apply applies |cfg| to |t|.
+func (cfg *TransportConfig) apply(t *http.Transport) {
+	if cfg.MaxIdleConns > 0 {
+		t.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	if cfg.TLSHandshakeTimeout > 0 {
+		t.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout
+	}
+
+	if cfg.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto disables HTTP/2.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if cfg.DialTimeout > 0 || cfg.KeepAlive > 0 || cfg.DNSCacheTTL > 0 {
+		// The defaults of http.DefaultTransport.
+		var d = &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if cfg.DialTimeout > 0 {
+			d.Timeout = cfg.DialTimeout
+		}
+		if cfg.KeepAlive > 0 {
+			d.KeepAlive = cfg.KeepAlive
+		}
+
+		t.DialContext = d.DialContext
+		if cfg.DNSCacheTTL > 0 {
+			t.DialContext = (&dnsCache{ttl: cfg.DNSCacheTTL, dialer: d}).dial
+		}
+	}
+}
+
+// dnsEntry is the cached addresses of a host.
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// dnsCache dials connections to the cached addresses of hosts.
+type dnsCache struct {
+	ttl    time.Duration
+	dialer *net.Dialer
+
+	mu      sync.Mutex
+	entries map[string]*dnsEntry
+}
+
+// dial implements the DialContext function of an *http.Transport. Each address of the host is tried in turn.
+func (dc *dnsCache) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	var host, port, err = net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return dc.dialer.DialContext(ctx, network, addr)
+	}
+
+	var addrs []string
+	if addrs, err = dc.lookup(ctx, host); err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	for _, a := range addrs {
+		if conn, err = dc.dialer.DialContext(ctx, network, net.JoinHostPort(a, port)); err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
+
+// lookup returns the addresses of |host|, resolving them if they are not cached.
+func (dc *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	var now = time.Now()
+
+	dc.mu.Lock()
+	var e, ok = dc.entries[host]
+	dc.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.addrs, nil
+	}
+
+	var addrs, err = net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dc.mu.Lock()
+	if dc.entries == nil {
+		dc.entries = map[string]*dnsEntry{}
+	}
+	dc.entries[host] = &dnsEntry{addrs: addrs, expires: now.Add(dc.ttl)}
+	dc.mu.Unlock()
+
+	return addrs, nil
+}
@@ -0,0 +1,227 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"time"
+
+	"github.com/fabiustech/openai/files"
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+const (
+	// MaxUploadPartSize is the maximum size of a single part of an Upload (64 MB).
+	MaxUploadPartSize = 64 << 20
+	// defaultUploadPartAttempts is the default number of times a part is attempted before UploadParts gives up.
+	defaultUploadPartAttempts = 3
+)
+
+// UploadRequest contains all relevant fields for requests to create an Upload.
+type UploadRequest struct {
+	// Filename is the name of the file to upload.
+	Filename string `json:"filename"`
+	// Purpose is the intended purpose of the uploaded file.
+	Purpose files.Purpose `json:"purpose"`
+	// Bytes is the number of bytes in the file being uploaded. An Upload can accept at most 8 GB in total.
+	Bytes int64 `json:"bytes"`
+	// MimeType is the MIME type of the file. This must fall within the supported MIME types for Purpose.
+	MimeType string `json:"mime_type"`
+}
+
+// Upload represents an intermediate object to which parts of a file can be added. Once completed, the Upload creates
+// a File that is ready for use.
+type Upload struct {
+	ResponseMeta
+
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	Bytes     int64          `json:"bytes"`
+	CreatedAt uint64         `json:"created_at"`
+	Filename  string         `json:"filename"`
+	Purpose   files.Purpose  `json:"purpose"`
+	// Status is one of "pending", "completed", "cancelled", or "expired".
+	Status    string `json:"status"`
+	ExpiresAt uint64 `json:"expires_at"`
+	// File is the ready File object created once the Upload is completed.
+	File *File `json:"file,omitempty"`
+}
+
+// UploadPart represents a chunk of bytes which has been added to an Upload.
+type UploadPart struct {
+	ResponseMeta
+
+	ID        string         `json:"id"`
+	Object    objects.Object `json:"object"`
+	CreatedAt uint64         `json:"created_at"`
+	UploadID  string         `json:"upload_id"`
+}
+
+// CompleteUploadRequest contains all relevant fields for requests to complete an Upload.
+type CompleteUploadRequest struct {
+	// PartIDs is the ordered list of part IDs.
+	PartIDs []string `json:"part_ids"`
+	// MD5 is the optional md5 checksum for the file contents to verify if the bytes uploaded matches what you expect.
+	MD5 string `json:"md5,omitempty"`
+}
+
+// UploadPartsOptions configures how UploadParts splits and sends the contents of a file.
+type UploadPartsOptions struct {
+	// PartSize is the number of bytes sent in each part. Must be no greater than MaxUploadPartSize.
+	// Defaults to MaxUploadPartSize.
+	PartSize int
+	// MaxAttempts is the number of times each part is attempted before giving up. Each attempt is itself retried
+	// according to the RetryPolicy of the Client, if any.
+	// Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt of a part. Like with RetryConfig, the delay doubles with
+	// each attempt, up to 8s, and up to half of it is random jitter.
+	// Defaults to 500ms.
+	InitialBackoff time.Duration
+}
+
+// UploadPartsError is returned by UploadParts when a part fails to upload. It contains enough information to resume
+// the upload: seek the source to Offset and call UploadParts again, appending the returned part IDs to PartIDs.
+type UploadPartsError struct {
+	// PartIDs are the IDs of the parts which were successfully added, in order.
+	PartIDs []string
+	// Offset is the offset of the first byte which was not successfully added.
+	Offset int64
+	// Err is the error returned by the last attempt to add the failed part.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *UploadPartsError) Error() string {
+	return fmt.Sprintf("openai: failed to upload part at offset %d: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *UploadPartsError) Unwrap() error {
+	return e.Err
+}
+
+// CreateUpload creates an Upload to which parts can be added. Use this for files larger than the 512 MB limit of
+// UploadFile. An Upload expires after an hour if it is not completed.
+func (c *Client) CreateUpload(ctx context.Context, ur *UploadRequest, opts ...RequestOption) (*Upload, error) {
+	var res, err = c.post(ctx, routes.Uploads, ur, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &Upload{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// AddUploadPart adds a part to an Upload. Each part can be at most 64 MB, and parts can be added in parallel.
+func (c *Client) AddUploadPart(ctx context.Context, uploadID string, data io.Reader, opts ...RequestOption) (*UploadPart, error) {
+	var res, err = c.postForm(ctx, path.Join(routes.Uploads, uploadID, "parts"), func(w *multipart.Writer) error {
+		return writeFormFile(w, "data", "part", data)
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &UploadPart{}
+	if err = res.decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// UploadParts reads |r| until EOF, adding its contents to the specified Upload in parts of |opts.PartSize| bytes.
+// Failed parts are attempted up to |opts.MaxAttempts| times, backing off between attempts. It returns the IDs of the
+// added parts, in order, which should be passed to CompleteUpload. If a part cannot be added, an *UploadPartsError is
+// returned. |opts| may be nil.
+func (c *Client) UploadParts(ctx context.Context, uploadID string, r io.Reader, opts *UploadPartsOptions, reqOpts ...RequestOption) ([]string, error) {
+	var size, attempts = MaxUploadPartSize, defaultUploadPartAttempts
+	var backoff = &RetryConfig{}
+	if opts != nil {
+		backoff.InitialBackoff = opts.InitialBackoff
+		if opts.PartSize > 0 {
+			size = opts.PartSize
+		}
+		if opts.MaxAttempts > 0 {
+			attempts = opts.MaxAttempts
+		}
+	}
+
+	if size > MaxUploadPartSize {
+		return nil, fmt.Errorf("openai: part size %d exceeds the maximum of %d bytes", size, MaxUploadPartSize)
+	}
+
+	var ids []string
+	var offset int64
+	var buf = make([]byte, size)
+	for {
+		var n, err = io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return ids, &UploadPartsError{PartIDs: ids, Offset: offset, Err: err}
+		}
+
+		var p *UploadPart
+		for attempt := 1; ; attempt++ {
+			if p, err = c.AddUploadPart(ctx, uploadID, bytes.NewReader(buf[:n]), reqOpts...); err == nil || attempt >= attempts {
+				break
+			}
+			if serr := sleep(ctx, backoff.backoff(attempt)); serr != nil {
+				err = serr
+				break
+			}
+		}
+		if err != nil {
+			return ids, &UploadPartsError{PartIDs: ids, Offset: offset, Err: err}
+		}
+
+		ids = append(ids, p.ID)
+		offset += int64(n)
+
+		if n < size {
+			return ids, nil
+		}
+	}
+}
+
+// CompleteUpload completes an Upload. The returned Upload contains a nested File that is ready to use in the rest of
+// the platform. The number of bytes uploaded must match the number of bytes specified when creating the Upload.
+func (c *Client) CompleteUpload(ctx context.Context, uploadID string, cr *CompleteUploadRequest, opts ...RequestOption) (*Upload, error) {
+	var res, err = c.post(ctx, path.Join(routes.Uploads, uploadID, "complete"), cr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &Upload{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// CancelUpload cancels an Upload. No parts may be added after an Upload is cancelled.
+func (c *Client) CancelUpload(ctx context.Context, uploadID string, opts ...RequestOption) (*Upload, error) {
+	var res, err = c.post(ctx, path.Join(routes.Uploads, uploadID, "cancel"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var u = &Upload{}
+	if err = res.decode(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
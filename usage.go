@@ -9,4 +9,46 @@ type Usage struct {
 	CompletionTokens int `json:"completion_tokens,omitempty"`
 	// Total tokens is the sum of PromptTokens and CompletionTokens.
 	TotalTokens int `json:"total_tokens"`
+	// CompletionTokensDetails breaks down the CompletionTokens. Only set for chat completions.
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+// CompletionTokensDetails is a breakdown of the tokens used in a completion.
+type CompletionTokensDetails struct {
+	// ReasoningTokens is the number of tokens generated by a reasoning model for reasoning. They are not visible in
+	// the response, but are billed as (and included in) completion tokens.
+	ReasoningTokens int `json:"reasoning_tokens"`
+	// AcceptedPredictionTokens is the number of tokens of the Prediction which appeared in the completion.
+	AcceptedPredictionTokens int `json:"accepted_prediction_tokens,omitempty"`
+	// RejectedPredictionTokens is the number of tokens of the Prediction which did not appear in the completion. Like
+	// reasoning tokens, they are billed as (and included in) completion tokens.
+	RejectedPredictionTokens int `json:"rejected_prediction_tokens,omitempty"`
+}
+
+// ReasoningTokens returns the number of reasoning tokens included in the CompletionTokens, or 0 if unknown.
+func (u *Usage) ReasoningTokens() int {
+	if u == nil || u.CompletionTokensDetails == nil {
+		return 0
+	}
+
+	return u.CompletionTokensDetails.ReasoningTokens
+}
+
+// add adds |v|, which may be nil, to |u|.
+func (u *Usage) add(v *Usage) {
+	if v == nil {
+		return
+	}
+
+	u.PromptTokens += v.PromptTokens
+	u.CompletionTokens += v.CompletionTokens
+	u.TotalTokens += v.TotalTokens
+	if d := v.CompletionTokensDetails; d != nil {
+		if u.CompletionTokensDetails == nil {
+			u.CompletionTokensDetails = &CompletionTokensDetails{}
+		}
+		u.CompletionTokensDetails.ReasoningTokens += d.ReasoningTokens
+		u.CompletionTokensDetails.AcceptedPredictionTokens += d.AcceptedPredictionTokens
+		u.CompletionTokensDetails.RejectedPredictionTokens += d.RejectedPredictionTokens
+	}
 }
@@ -0,0 +1,139 @@
+package openai
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// UsageTotals is the cumulative token usage of a set of requests.
+type UsageTotals struct {
+	// Requests is the number of requests which reported their usage.
+	Requests int
+	// PromptTokens is the total number of prompt tokens.
+	PromptTokens int
+	// CompletionTokens is the total number of completion tokens.
+	CompletionTokens int
+	// TotalTokens is the total number of tokens.
+	TotalTokens int
+	// Cost is the total cost in US dollars of the requests whose cost is known (see WithPricing).
+	Cost float64
+}
+
+// add adds |u| and |cost| to |t|.
+func (t *UsageTotals) add(u *Usage, cost *float64) {
+	t.Requests++
+	t.PromptTokens += u.PromptTokens
+	t.CompletionTokens += u.CompletionTokens
+	t.TotalTokens += u.TotalTokens
+	if cost != nil {
+		t.Cost += *cost
+	}
+}
+
+// UsageTracker aggregates the token usage of every response across requests (see WithUsageTracker), in total and rolled
+// up by model and by end user (the "user" field of requests). It is safe for concurrent use, so that long-running
+// services can expose cumulative consumption at runtime. Streamed chat completions are tracked once they report their
+// usage, which they only do if requested with StreamOptions.IncludeUsage. The zero value is ready to use.
+type UsageTracker struct {
+	mu     sync.Mutex
+	total  UsageTotals
+	models map[string]*UsageTotals
+	users  map[string]*UsageTotals
+}
+
+// NewUsageTracker returns an empty *UsageTracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{
+		models: map[string]*UsageTotals{},
+		users:  map[string]*UsageTotals{},
+	}
+}
+
+// Record adds |u| to the totals for |model| and |user|, which may be empty. |cost| may be nil if it is unknown. The
+// Client calls Record for every response; it may also be called directly, e.g. for usage reported by streams.
+func (t *UsageTracker) Record(model, user string, u *Usage, cost *float64) {
+	if u == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.models == nil {
+		t.models = map[string]*UsageTotals{}
+		t.users = map[string]*UsageTotals{}
+	}
+
+	t.total.add(u, cost)
+	if model != "" {
+		rollup(t.models, model).add(u, cost)
+	}
+	if user != "" {
+		rollup(t.users, user).add(u, cost)
+	}
+}
+
+// Total returns the totals across all requests.
+func (t *UsageTracker) Total() UsageTotals {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.total
+}
+
+// ByModel returns the totals for each model.
+func (t *UsageTracker) ByModel() map[string]UsageTotals {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return snapshot(t.models)
+}
+
+// ByUser returns the totals for each end user. Requests without a user are only included in Total.
+func (t *UsageTracker) ByUser() map[string]UsageTotals {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return snapshot(t.users)
+}
+
+// Reset clears all totals.
+func (t *UsageTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total = UsageTotals{}
+	t.models = map[string]*UsageTotals{}
+	t.users = map[string]*UsageTotals{}
+}
+
+// rollup returns the totals for |key| in |m|, adding them if necessary.
+func rollup(m map[string]*UsageTotals, key string) *UsageTotals {
+	var t, ok = m[key]
+	if !ok {
+		t = &UsageTotals{}
+		m[key] = t
+	}
+
+	return t
+}
+
+// snapshot returns a copy of |m|.
+func snapshot(m map[string]*UsageTotals) map[string]UsageTotals {
+	var out = make(map[string]UsageTotals, len(m))
+	for k, v := range m {
+		out[k] = *v
+	}
+
+	return out
+}
+
+// jsonUser returns the "user" field of the JSON encoded request body |b|, if any.
+func jsonUser(b []byte) string {
+	var v struct {
+		User string `json:"user"`
+	}
+	_ = json.Unmarshal(b, &v)
+
+	return v.User
+}
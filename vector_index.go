@@ -0,0 +1,191 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/fabiustech/openai/models"
+)
+
+// maxEmbeddingInputs is the maximum number of inputs the embeddings endpoint accepts in a single request.
+const maxEmbeddingInputs = 2048
+
+// Document is a piece of text stored in a VectorIndex.
+type Document struct {
+	// ID uniquely identifies the document within the index. Adding a document with an existing ID replaces it.
+	ID string
+	// Text is the text of the document. It is embedded when the document is added, unless Vector is set.
+	Text string
+	// Metadata contains arbitrary attributes of the document, which searches can be filtered on.
+	Metadata map[string]string
+	// Vector is the embedding of Text.
+	Vector []float64
+}
+
+// SearchResult is a document found by a search of a VectorIndex.
+type SearchResult struct {
+	*Document
+	// Score is the cosine similarity of the document to the query.
+	Score float64
+}
+
+// VectorIndex is a small in-memory vector store, which embeds documents and queries with the embeddings endpoint and
+// searches them exhaustively. It is intended for semantic search demos, tests, and small corpora (up to tens of
+// thousands of documents); use the Vector Stores API for larger ones. It is safe for concurrent use.
+type VectorIndex struct {
+	api   EmbeddingsAPI
+	model models.Embedding
+
+	mu   sync.RWMutex
+	docs map[string]*Document
+}
+
+// NewVectorIndex returns an empty *VectorIndex which embeds text with |model| using |api| (e.g. a *Client).
+func NewVectorIndex(api EmbeddingsAPI, model models.Embedding) *VectorIndex {
+	return &VectorIndex{
+		api:   api,
+		model: model,
+		docs:  map[string]*Document{},
+	}
+}
+
+// Add embeds |docs| which do not have a Vector, in as few requests as possible, and adds them to the index. If any
+// request fails, no documents are added and none of |docs| are modified.
+func (x *VectorIndex) Add(ctx context.Context, docs ...*Document) error {
+	var pending []*Document
+	for _, d := range docs {
+		if d.ID == "" {
+			return errors.New("openai: document has no ID")
+		}
+		if d.Vector == nil {
+			pending = append(pending, d)
+		}
+	}
+
+	// The vectors are only assigned once every batch has been embedded.
+	var vectors = make([][]float64, 0, len(pending))
+	for start := 0; start < len(pending); start += maxEmbeddingInputs {
+		var batch = pending[start:]
+		if len(batch) > maxEmbeddingInputs {
+			batch = batch[:maxEmbeddingInputs]
+		}
+
+		var input = make([]string, len(batch))
+		for i, d := range batch {
+			input[i] = d.Text
+		}
+
+		var embedded, err = x.embed(ctx, input)
+		if err != nil {
+			return err
+		}
+		vectors = append(vectors, embedded...)
+	}
+	for i, d := range pending {
+		d.Vector = vectors[i]
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, d := range docs {
+		x.docs[d.ID] = d
+	}
+
+	return nil
+}
+
+// Get returns the document with |id|, or nil if there is none.
+func (x *VectorIndex) Get(id string) *Document {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.docs[id]
+}
+
+// Delete removes the documents with |ids| from the index. Unknown IDs are ignored.
+func (x *VectorIndex) Delete(ids ...string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, id := range ids {
+		delete(x.docs, id)
+	}
+}
+
+// Len returns the number of documents in the index.
+func (x *VectorIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return len(x.docs)
+}
+
+// Search embeds |query| and returns the |k| documents most similar to it, most similar first. If |filter| is not
+// empty, only documents whose Metadata contains all of its key-value pairs are considered.
+func (x *VectorIndex) Search(ctx context.Context, query string, k int, filter map[string]string) ([]*SearchResult, error) {
+	var vectors, err = x.embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+
+	return x.SearchVector(vectors[0], k, filter), nil
+}
+
+// SearchVector is like Search, but searches for documents similar to the embedding |vector|.
+func (x *VectorIndex) SearchVector(vector []float64, k int, filter map[string]string) []*SearchResult {
+	if k <= 0 {
+		return nil
+	}
+
+	x.mu.RLock()
+	var results []*SearchResult
+	for _, d := range x.docs {
+		if matches(d.Metadata, filter) {
+			results = append(results, &SearchResult{Document: d, Score: CosineSimilarity(vector, d.Vector)})
+		}
+	}
+	x.mu.RUnlock()
+
+	// Break ties by ID, so that results are deterministic.
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if k < len(results) {
+		results = results[:k]
+	}
+
+	return results
+}
+
+// embed returns the embeddings of |input|.
+func (x *VectorIndex) embed(ctx context.Context, input []string) ([][]float64, error) {
+	var resp, err = x.api.CreateEmbeddings(ctx, &EmbeddingRequest{Input: input, Model: x.model})
+	if err != nil {
+		return nil, err
+	}
+
+	var vectors = resp.Vectors()
+	if len(vectors) != len(input) {
+		return nil, errors.New("openai: embeddings response does not match the number of inputs")
+	}
+
+	return vectors, nil
+}
+
+// matches reports whether |metadata| contains all of the key-value pairs of |filter|.
+func matches(metadata, filter map[string]string) bool {
+	for k, v := range filter {
+		if metadata[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
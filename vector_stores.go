@@ -0,0 +1,356 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/fabiustech/openai/objects"
+	"github.com/fabiustech/openai/routes"
+)
+
+// ChunkingStrategy specifies how files are split into chunks before being added to a vector store. The zero value
+// uses the "auto" strategy, which currently uses a max_chunk_size_tokens of 800 and chunk_overlap_tokens of 400.
+type ChunkingStrategy struct {
+	// Static specifies a static chunking strategy. If nil, the "auto" strategy is used.
+	Static *StaticChunkingStrategy
+}
+
+// StaticChunkingStrategy specifies the chunk size and overlap of a static chunking strategy.
+type StaticChunkingStrategy struct {
+	// MaxChunkSizeTokens is the maximum number of tokens in each chunk. Must be between 100 and 4096.
+	// Defaults to 800.
+	MaxChunkSizeTokens int `json:"max_chunk_size_tokens"`
+	// ChunkOverlapTokens is the number of tokens that overlap between chunks. Must not exceed half of
+	// MaxChunkSizeTokens.
+	// Defaults to 400.
+	ChunkOverlapTokens int `json:"chunk_overlap_tokens"`
+}
+
+type chunkingStrategy struct {
+	Type   string                  `json:"type"`
+	Static *StaticChunkingStrategy `json:"static,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (cs ChunkingStrategy) MarshalJSON() ([]byte, error) {
+	if cs.Static == nil {
+		return json.Marshal(&chunkingStrategy{Type: "auto"})
+	}
+
+	return json.Marshal(&chunkingStrategy{Type: "static", Static: cs.Static})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (cs *ChunkingStrategy) UnmarshalJSON(b []byte) error {
+	var v = &chunkingStrategy{}
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+
+	cs.Static = v.Static
+
+	return nil
+}
+
+// ExpirationPolicy specifies when a vector store expires.
+type ExpirationPolicy struct {
+	// Anchor is the timestamp after which the expiration policy applies. Currently only "last_active_at" is
+	// supported.
+	Anchor string `json:"anchor"`
+	// Days is the number of days after the anchor time that the vector store will expire.
+	Days int `json:"days"`
+}
+
+// FileCounts contains the number of files in a vector store (or file batch), grouped by status.
+type FileCounts struct {
+	InProgress int `json:"in_progress"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+	Cancelled  int `json:"cancelled"`
+	Total      int `json:"total"`
+}
+
+// VectorStoreRequest contains all relevant fields for requests to the vector_stores endpoints.
+type VectorStoreRequest struct {
+	// Name is the name of the vector store.
+	Name *string `json:"name,omitempty"`
+	// FileIDs is a list of file IDs that the vector store should use. Only used when creating a vector store.
+	FileIDs []string `json:"file_ids,omitempty"`
+	// ExpiresAfter is the expiration policy for the vector store.
+	ExpiresAfter *ExpirationPolicy `json:"expires_after,omitempty"`
+	// ChunkingStrategy is the chunking strategy used to chunk FileIDs. Only used when creating a vector store.
+	// Defaults to the "auto" strategy.
+	ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	// Metadata is a set of 16 key-value pairs that can be attached to the vector store. Keys can be a maximum of 64
+	// characters long and values can be a maximum of 512 characters long.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// VectorStore represents a collection of processed files that can be used by the file_search tool.
+type VectorStore struct {
+	ResponseMeta
+
+	ID         string         `json:"id"`
+	Object     objects.Object `json:"object"`
+	CreatedAt  uint64         `json:"created_at"`
+	Name       string         `json:"name"`
+	UsageBytes int            `json:"usage_bytes"`
+	FileCounts *FileCounts    `json:"file_counts"`
+	// Status is one of "expired", "in_progress", or "completed".
+	Status       string            `json:"status"`
+	ExpiresAfter *ExpirationPolicy `json:"expires_after"`
+	ExpiresAt    *uint64           `json:"expires_at"`
+	LastActiveAt *uint64           `json:"last_active_at"`
+	Metadata     map[string]string `json:"metadata"`
+}
+
+// VectorStoreFileRequest contains all relevant fields for requests to attach a file to a vector store.
+type VectorStoreFileRequest struct {
+	// FileID is the ID of a file that the vector store should use.
+	FileID string `json:"file_id"`
+	// ChunkingStrategy is the chunking strategy used to chunk the file.
+	// Defaults to the "auto" strategy.
+	ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+}
+
+// VectorStoreFileError contains details about why a file could not be added to a vector store.
+type VectorStoreFileError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// VectorStoreFile represents a file attached to a vector store.
+type VectorStoreFile struct {
+	ResponseMeta
+
+	ID            string         `json:"id"`
+	Object        objects.Object `json:"object"`
+	UsageBytes    int            `json:"usage_bytes"`
+	CreatedAt     uint64         `json:"created_at"`
+	VectorStoreID string         `json:"vector_store_id"`
+	// Status is one of "in_progress", "completed", "cancelled", or "failed".
+	Status           string                `json:"status"`
+	LastError        *VectorStoreFileError `json:"last_error"`
+	ChunkingStrategy *ChunkingStrategy     `json:"chunking_strategy"`
+}
+
+// VectorStoreFileBatchRequest contains all relevant fields for requests to attach a batch of files to a vector store.
+type VectorStoreFileBatchRequest struct {
+	// FileIDs is a list of file IDs that the vector store should use.
+	FileIDs []string `json:"file_ids"`
+	// ChunkingStrategy is the chunking strategy used to chunk the files.
+	// Defaults to the "auto" strategy.
+	ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+}
+
+// VectorStoreFileBatch represents a batch of files attached to a vector store.
+type VectorStoreFileBatch struct {
+	ResponseMeta
+
+	ID            string         `json:"id"`
+	Object        objects.Object `json:"object"`
+	CreatedAt     uint64         `json:"created_at"`
+	VectorStoreID string         `json:"vector_store_id"`
+	// Status is one of "in_progress", "completed", "cancelled", or "failed".
+	Status     string      `json:"status"`
+	FileCounts *FileCounts `json:"file_counts"`
+}
+
+// CreateVectorStore creates a vector store.
+func (c *Client) CreateVectorStore(ctx context.Context, vr *VectorStoreRequest, opts ...RequestOption) (*VectorStore, error) {
+	var res, err = c.post(ctx, routes.VectorStores, vr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs = &VectorStore{}
+	if err = res.decode(vs); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// ListVectorStores returns a list of vector stores. Paginate the results with WithListOptions.
+func (c *Client) ListVectorStores(ctx context.Context, opts ...RequestOption) (*List[*VectorStore], error) {
+	var res, err = c.get(ctx, routes.VectorStores, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*VectorStore]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveVectorStore retrieves a vector store.
+func (c *Client) RetrieveVectorStore(ctx context.Context, id string, opts ...RequestOption) (*VectorStore, error) {
+	var res, err = c.get(ctx, path.Join(routes.VectorStores, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs = &VectorStore{}
+	if err = res.decode(vs); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// ModifyVectorStore modifies a vector store. Only Name, ExpiresAfter, and Metadata can be modified.
+func (c *Client) ModifyVectorStore(ctx context.Context, id string, vr *VectorStoreRequest, opts ...RequestOption) (*VectorStore, error) {
+	var res, err = c.post(ctx, path.Join(routes.VectorStores, id), vr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs = &VectorStore{}
+	if err = res.decode(vs); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// DeleteVectorStore deletes a vector store.
+func (c *Client) DeleteVectorStore(ctx context.Context, id string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.VectorStores, id), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateVectorStoreFile attaches a file to a vector store.
+func (c *Client) CreateVectorStoreFile(ctx context.Context, vectorStoreID string, fr *VectorStoreFileRequest, opts ...RequestOption) (*VectorStoreFile, error) {
+	var res, err = c.post(ctx, path.Join(routes.VectorStores, vectorStoreID, "files"), fr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var f = &VectorStoreFile{}
+	if err = res.decode(f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ListVectorStoreFiles returns a list of the files attached to a vector store. Paginate the results with
+// WithListOptions.
+func (c *Client) ListVectorStoreFiles(ctx context.Context, vectorStoreID string, opts ...RequestOption) (*List[*VectorStoreFile], error) {
+	var res, err = c.get(ctx, path.Join(routes.VectorStores, vectorStoreID, "files"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*VectorStoreFile]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RetrieveVectorStoreFile retrieves a file attached to a vector store.
+func (c *Client) RetrieveVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...RequestOption) (*VectorStoreFile, error) {
+	var res, err = c.get(ctx, path.Join(routes.VectorStores, vectorStoreID, "files", fileID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var f = &VectorStoreFile{}
+	if err = res.decode(f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// DeleteVectorStoreFile removes a file from a vector store. The file itself is not deleted; use DeleteFile for that.
+func (c *Client) DeleteVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, opts ...RequestOption) (*DeletionResponse, error) {
+	var res, err = c.delete(ctx, path.Join(routes.VectorStores, vectorStoreID, "files", fileID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var d = &DeletionResponse{}
+	if err = res.decode(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// CreateVectorStoreFileBatch attaches a batch of files to a vector store.
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, br *VectorStoreFileBatchRequest, opts ...RequestOption) (*VectorStoreFileBatch, error) {
+	var res, err = c.post(ctx, path.Join(routes.VectorStores, vectorStoreID, "file_batches"), br, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var fb = &VectorStoreFileBatch{}
+	if err = res.decode(fb); err != nil {
+		return nil, err
+	}
+
+	return fb, nil
+}
+
+// RetrieveVectorStoreFileBatch retrieves a vector store file batch.
+func (c *Client) RetrieveVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*VectorStoreFileBatch, error) {
+	var res, err = c.get(ctx, path.Join(routes.VectorStores, vectorStoreID, "file_batches", batchID), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var fb = &VectorStoreFileBatch{}
+	if err = res.decode(fb); err != nil {
+		return nil, err
+	}
+
+	return fb, nil
+}
+
+// CancelVectorStoreFileBatch cancels a vector store file batch. This attempts to cancel the processing of files in the
+// batch as soon as possible.
+func (c *Client) CancelVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*VectorStoreFileBatch, error) {
+	var res, err = c.post(ctx, path.Join(routes.VectorStores, vectorStoreID, "file_batches", batchID, "cancel"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var fb = &VectorStoreFileBatch{}
+	if err = res.decode(fb); err != nil {
+		return nil, err
+	}
+
+	return fb, nil
+}
+
+// ListVectorStoreFileBatchFiles returns a list of the files in a vector store file batch. Paginate the results with
+// WithListOptions.
+func (c *Client) ListVectorStoreFileBatchFiles(ctx context.Context, vectorStoreID, batchID string, opts ...RequestOption) (*List[*VectorStoreFile], error) {
+	var res, err = c.get(ctx, path.Join(routes.VectorStores, vectorStoreID, "file_batches", batchID, "files"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var l = &List[*VectorStoreFile]{}
+	if err = res.decode(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}